@@ -0,0 +1,35 @@
+// Command todoist-fake serves an in-memory fake of the Todoist API, for
+// demos, screenshots and manual testing:
+//
+//	todoist-fake &
+//	todoist-tui --base-url http://127.0.0.1:8080
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/hy4ri/todoist-tui/internal/fake"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8080", "Address to listen on")
+	empty := flag.Bool("empty", false, "Start with just an Inbox instead of demo data")
+	token := flag.String("token", "", "Only accept this API token (default: any)")
+	flag.Parse()
+
+	srv := fake.NewDemo()
+	if *empty {
+		srv = fake.New()
+	}
+	srv.Token = *token
+
+	fmt.Fprintf(os.Stderr, "Serving a fake Todoist API on http://%s\n", *addr)
+	fmt.Fprintf(os.Stderr, "Run: todoist-tui --base-url http://%s\n", *addr)
+	if err := http.ListenAndServe(*addr, srv); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -2,31 +2,81 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/auth"
+	"github.com/hy4ri/todoist-tui/internal/cli"
 	"github.com/hy4ri/todoist-tui/internal/config"
+	"github.com/hy4ri/todoist-tui/internal/control"
+	"github.com/hy4ri/todoist-tui/internal/statusbar"
 	"github.com/hy4ri/todoist-tui/internal/tui"
 	"github.com/hy4ri/todoist-tui/internal/tui/styles"
 )
 
 const version = "2.0.0"
 
+// HTTP options. The flags default to the environment so that commands,
+// which skip flag parsing, can use them too.
+var (
+	debugHTTP  = os.Getenv("TODOIST_TUI_DEBUG_HTTP") != ""
+	replayFile = os.Getenv("TODOIST_TUI_REPLAY")
+	baseURL    = os.Getenv("TODOIST_TUI_BASE_URL")
+)
+
+// profileName selects the account; empty uses the profile setting of the
+// config. Like the HTTP options it defaults to the environment.
+var profileName = os.Getenv("TODOIST_TUI_PROFILE")
+
 const helpText = `todoist-tui - Terminal-based Todoist client with Vim keybindings
 
 USAGE:
     todoist-tui [OPTIONS]
+    todoist-tui COMMAND [ARGS]
 
 OPTIONS:
     -h, --help      Show this help message
     -v, --version   Show version information
     --init          Create a template config file
-    --json          Output today's and overdue tasks in JSON format
+    --status        Output a task summary for status bars (see status_bar in the config)
+    --format NAME   Status bar format: waybar, polybar, i3blocks, tmux, plain or template
+    --json          Same as --format waybar
+    --debug-http    Log every API request and response to http.jsonl in the
+                    data directory, with the token removed
+    --replay FILE   Answer API requests from a --debug-http log instead of
+                    the network
+    --base-url URL  Talk to another API server, such as todoist-fake; the
+                    stored token is not sent to it
+    --profile NAME  Use the token, cache and default view of another
+                    account (see profiles in the config); may also
+                    precede a command
+
+COMMANDS:
+` + "%s" + `
+EXIT CODES:
+    0   Success
+    1   Unexpected error
+    2   Invalid arguments
+    3   Missing or rejected API token
+    4   Task or project not found
+    5   Network failure, rate limit or server error
+
+ENVIRONMENT:
+    TODOIST_TUI_DEBUG_HTTP  Set to enable --debug-http, also for commands
+    TODOIST_TUI_REPLAY      Default for --replay, also for commands
+    TODOIST_TUI_BASE_URL    Default for --base-url, also for commands
+    TODOIST_TUI_PROFILE     Default for --profile, also for commands
 
 CONFIGURATION:
     Config file: ~/.config/todoist-tui/config.yaml
@@ -47,6 +97,9 @@ ui:
   # Calendar default view (compact, expanded)
   calendar_default_view: "compact"
 
+  # Time available for tasks with a duration; longer days are flagged (default: 8h)
+  # workday_length: "8h"
+
   # Theme configuration (uncomment to override defaults)
   theme:
      # Core colors
@@ -76,9 +129,55 @@ ui:
      # Status bar colors
      status_bar_bg: "#1F1F1F"
      status_bar_fg: "#DDDDDD"
+
+# Status bar output (todoist-tui --status)
+# status_bar:
+#   # Output format: waybar, polybar, i3blocks, tmux, plain or template
+#   format: "waybar"
+#   # Todoist filter query selecting the tasks
+#   filter: "today | overdue"
+#   # Fields shown per task line: content, due, priority, project, labels
+#   fields: ["priority", "content"]
+#   # Go template used by the template format
+#   template: "{{.Total}} tasks ({{.Overdue}} overdue)"
+
+# Control socket for external tools (todoist-tui remote ...)
+# control:
+#   enabled: true
+
+# Connection settings, e.g. behind a corporate proxy
+# network:
+#   # Proxy URL (default: HTTPS_PROXY from the environment)
+#   proxy: "http://proxy.example.com:3128"
+#   # Extra trusted certificates (PEM)
+#   ca_file: "~/.config/todoist-tui/corporate-ca.pem"
+#   # Per-request timeout
+#   timeout: "30s"
+#   # Retries of failed requests and the wait before the first one
+#   max_retries: 3
+#   retry_base_delay: "500ms"
+
+# Separate accounts, each with its own token and cache. Select one with
+# --profile NAME, or with :profile NAME while running. Sign in to each with
+# todoist-tui --profile NAME login
+# profile: "work"           # Used when --profile is not given
+# profiles:
+#   work:
+#     default_view: "today"
+#   personal:
+#     default_view: "inbox"
 `
 
 func main() {
+	args := os.Args[1:]
+	// --profile may precede a command, which skips flag parsing
+	if name, rest, ok := cutProfileFlag(args); ok {
+		profileName, args = name, rest
+	}
+	if len(args) > 0 && cli.IsCommand(args[0]) {
+		os.Exit(runCommand(args))
+	}
+
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -98,6 +197,8 @@ func run() error {
 		viewToday    bool
 		viewInbox    bool
 		outputJSON   bool
+		showStatus   bool
+		statusFormat string
 	)
 
 	flag.BoolVar(&showHelp, "help", false, "Show help message")
@@ -111,17 +212,23 @@ func run() error {
 	flag.BoolVar(&viewLabels, "labels", false, "Start in labels view")
 	flag.BoolVar(&viewInbox, "inbox", false, "Start in inbox view")
 	flag.BoolVar(&viewToday, "today", false, "Start in today view")
-	flag.BoolVar(&outputJSON, "json", false, "Output tasks in JSON format")
+	flag.BoolVar(&outputJSON, "json", false, "Output tasks in Waybar JSON format")
+	flag.BoolVar(&showStatus, "status", false, "Output a task summary for status bars")
+	flag.StringVar(&statusFormat, "format", "", "Status bar output format")
+	flag.BoolVar(&debugHTTP, "debug-http", debugHTTP, "Log API requests to the data directory")
+	flag.StringVar(&replayFile, "replay", replayFile, "Replay API responses from a --debug-http log")
+	flag.StringVar(&baseURL, "base-url", baseURL, "API server to use instead of Todoist")
+	flag.StringVar(&profileName, "profile", profileName, "Account profile to use")
 
 	flag.Usage = func() {
-		fmt.Print(helpText)
+		fmt.Printf(helpText, cli.Usage())
 	}
 
 	flag.Parse()
 
 	// Handle flags
 	if showHelp {
-		fmt.Print(helpText)
+		fmt.Printf(helpText, cli.Usage())
 		return nil
 	}
 
@@ -135,7 +242,11 @@ func run() error {
 	}
 
 	if outputJSON {
-		return runJSONOutput()
+		return runStatusOutput("waybar")
+	}
+
+	if showStatus || statusFormat != "" {
+		return runStatusOutput(statusFormat)
 	}
 
 	// determine initial view
@@ -210,34 +321,88 @@ func runApp(initialView string) error {
 	// Apply user theme
 	styles.InitTheme(&cfg.UI.Theme)
 
+	// The profile decides which token and cache are used
+	if err := useProfile(cfg); err != nil {
+		return err
+	}
+
 	// Get token from secure storage
-	token, err := config.GetToken()
+	token, err := getToken()
 	if err != nil {
 		return fmt.Errorf("failed to get token: %w", err)
 	}
 
-	// If no token, prompt for it
+	// If no token, sign in through the browser when an OAuth app is
+	// configured, otherwise prompt for a token
 	if token == "" {
-		token, err = promptForToken()
-		if err != nil {
-			return err
+		token, err = auth.GetAccessToken(context.Background(), cfg)
+		if errors.Is(err, auth.ErrNoOAuthApp) {
+			token, err = promptForToken()
+			if err != nil {
+				return err
+			}
+			if token == "" {
+				return fmt.Errorf("no token provided")
+			}
+			// Save the token securely
+			err = config.SaveToken(token)
 		}
-		if token == "" {
-			return fmt.Errorf("no token provided")
+		if err != nil {
+			return fmt.Errorf("failed to sign in: %w", err)
 		}
-		// Save the token securely
-		if err := config.SaveToken(token); err != nil {
-			return fmt.Errorf("failed to save token: %w", err)
+	}
+
+	// Another server's data must not mix with the cache, offline outbox
+	// and HTTP log of the real account, so it gets a throwaway data directory
+	if baseURL != "" {
+		dataHome, err := os.MkdirTemp("", "todoist-tui-")
+		if err != nil {
+			return fmt.Errorf("failed to create data directory: %w", err)
 		}
+		defer os.RemoveAll(dataHome)
+		os.Setenv("XDG_DATA_HOME", dataHome)
 	}
 
+	logs := openHTTPLogs()
+	defer logs.Close()
+
 	// Create API client
-	client := api.NewClient(token)
+	client, err := newAPIClient(cfg, token, logs)
+	if err != nil {
+		return err
+	}
+
+	// Requests still in flight when the TUI exits are abandoned
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	// Create and run TUI
 	app := tui.NewApp(client, cfg, initialView)
+	app.Ctx = ctx
+	// :profile signs in to the newly selected profile
+	app.NewClient = func() (*api.Client, error) {
+		token, err := getToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token: %w", err)
+		}
+		if token == "" {
+			profile := config.CurrentProfile()
+			return nil, fmt.Errorf("no token for profile %s. Run 'todoist-tui --profile %s login' first", profile, profile)
+		}
+		return newAPIClient(cfg, token, logs)
+	}
 	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
+	// Let external tools drive the app through the control socket
+	if cfg.Control.Enabled {
+		srv, err := startControlServer(p)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: control server disabled: %v\n", err)
+		} else {
+			defer srv.Close()
+		}
+	}
+
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("failed to run TUI: %w", err)
 	}
@@ -245,6 +410,142 @@ func runApp(initialView string) error {
 	return nil
 }
 
+// startControlServer listens on the control socket and forwards requests to p.
+func startControlServer(p *tea.Program) (*control.Server, error) {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	srv, err := control.Listen(control.SocketPath(dataDir))
+	if err != nil {
+		return nil, err
+	}
+	go srv.Serve(p.Send)
+	return srv, nil
+}
+
+// cutProfileFlag removes a leading --profile NAME or --profile=NAME from args.
+func cutProfileFlag(args []string) (string, []string, bool) {
+	if len(args) == 0 {
+		return "", args, false
+	}
+	for _, name := range []string{"--profile", "-profile"} {
+		if args[0] == name && len(args) > 1 {
+			return args[1], args[2:], true
+		}
+		if value, ok := strings.CutPrefix(args[0], name+"="); ok {
+			return value, args[1:], true
+		}
+	}
+	return "", args, false
+}
+
+// useProfile activates the profile given by --profile, or else the one
+// configured for startup.
+func useProfile(cfg *config.Config) error {
+	name := profileName
+	if name == "" {
+		name = cfg.StartupProfile()
+	}
+	if !cfg.HasProfile(name) {
+		return fmt.Errorf("unknown profile %q (add it under profiles in the config)", name)
+	}
+	return config.SetProfile(name)
+}
+
+// getToken returns the API token. With --base-url the keyring is skipped so
+// the real token never leaves for another server; TODOIST_TOKEN still
+// applies, and any other token is accepted by todoist-fake.
+func getToken() (string, error) {
+	if baseURL == "" {
+		return config.GetToken()
+	}
+	if token := os.Getenv("TODOIST_TOKEN"); token != "" {
+		return token, nil
+	}
+	return "local", nil
+}
+
+// newAPIClient creates the API client with the network settings of cfg and
+// the HTTP options, which take precedence. With --debug-http its exchanges
+// are written to logs.
+func newAPIClient(cfg *config.Config, token string, logs *httpLogs) (*api.Client, error) {
+	network := cfg.Network.API()
+	if baseURL != "" {
+		network.BaseURL = baseURL
+	}
+	client, err := api.NewClientWithNetwork(token, network)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network settings: %w", err)
+	}
+
+	if replayFile != "" {
+		replay, err := api.LoadReplayTransport(replayFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load replay log: %w", err)
+		}
+		client.SetTransport(replay)
+		// Replayed responses need no pacing
+		client.Limiter = nil
+		client.FullSyncLimiter = nil
+	}
+
+	if logs != nil {
+		w, err := logs.writer()
+		if err != nil {
+			return nil, err
+		}
+		client.SetTransport(api.NewLoggingTransport(client.Transport(), w))
+	}
+
+	return client, nil
+}
+
+// httpLogs holds the --debug-http log of each data directory, opened once
+// however many clients use it, e.g. after switching profiles back and forth.
+type httpLogs struct {
+	files map[string]*os.File
+}
+
+// openHTTPLogs returns the logs to pass to newAPIClient, or nil without
+// --debug-http. They must be closed on exit.
+func openHTTPLogs() *httpLogs {
+	if !debugHTTP {
+		return nil
+	}
+	return &httpLogs{files: make(map[string]*os.File)}
+}
+
+// writer returns the log of the current data directory, opening it on
+// first use. Each exchange is written in a single call.
+func (l *httpLogs) writer() (io.Writer, error) {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	if f, ok := l.files[dataDir]; ok {
+		return f, nil
+	}
+	f, err := os.OpenFile(filepath.Join(dataDir, "http.jsonl"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open HTTP log: %w", err)
+	}
+	l.files[dataDir] = f
+	return f, nil
+}
+
+// Close closes the logs opened so far.
+func (l *httpLogs) Close() error {
+	if l == nil {
+		return nil
+	}
+	var errs []error
+	for _, f := range l.files {
+		errs = append(errs, f.Close())
+	}
+	return errors.Join(errs...)
+}
+
 // ensureConfig creates a default config file if it doesn't exist.
 func ensureConfig() error {
 	path, err := config.ConfigPath()
@@ -287,88 +588,148 @@ func promptForToken() (string, error) {
 	return strings.TrimSpace(token), nil
 }
 
-// runJSONOutput fetches today's and overdue tasks and outputs them as JSON.
-func runJSONOutput() error {
-	// Get token from secure storage
-	token, err := config.GetToken()
-	if err != nil {
-		return fmt.Errorf("failed to get token: %w", err)
+// runCommand runs a scripting subcommand and returns its exit code.
+func runCommand(args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// A broken config only matters to commands that talk to Todoist
+	cfg, cfgErr := config.Load()
+	var profileErr error
+	if cfgErr == nil {
+		profileErr = useProfile(cfg)
+	} else if profileName != "" {
+		profileErr = fmt.Errorf("failed to load config: %w", cfgErr)
 	}
-	if token == "" {
-		return fmt.Errorf("no token configured. Run 'todoist-tui' first to set up")
+	if profileErr != nil {
+		// Going on would use the token of another account
+		fmt.Fprintf(os.Stderr, "Error: %v\n", profileErr)
+		return cli.ExitUsage
 	}
 
-	// Create API client
-	client := api.NewClient(token)
+	logs := openHTTPLogs()
+	defer logs.Close()
 
-	// Fetch tasks
-	tasks, err := client.GetTasksByFilter("today | overdue")
-	if err != nil {
-		return fmt.Errorf("failed to fetch tasks: %w", err)
+	newClient := func(token string) (*api.Client, error) {
+		if cfgErr != nil {
+			return nil, fmt.Errorf("failed to load config: %w", cfgErr)
+		}
+		return newAPIClient(cfg, token, logs)
 	}
 
-	// Simplified output structure
-	type simpleTask struct {
-		Name     string `json:"name"`
-		Date     string `json:"date"`
-		Priority int    `json:"priority"`
+	runner := &cli.Runner{
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		NewClient: func() (*api.Client, error) {
+			token, err := getToken()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get token: %w", err)
+			}
+			if token == "" {
+				return nil, cli.ErrNoToken
+			}
+			return newClient(token)
+		},
+		NewClientWithToken: newClient,
+		SaveToken:          config.SaveToken,
+		ClearToken:         config.ClearToken,
 	}
-
-	type jsonOutput struct {
-		// Waybar specific fields
-		Text    string `json:"text"`    // What shows on the bar
-		Tooltip string `json:"tooltip"` // What shows on hover
-		Class   string `json:"class"`   // For CSS styling
-
-		// Data fields
-		Tasks          []simpleTask `json:"tasks"`
-		PriorityCounts map[int]int  `json:"priority_counts"`
-		Total          int          `json:"total"`
+	if baseURL != "" {
+		// Like getToken, leave the keyring of the real account alone: a token
+		// accepted by another server must neither replace nor remove it
+		errStoredToken := errors.New("login and logout are not available with --base-url; set TODOIST_TOKEN instead")
+		runner.SaveToken = func(string) error { return errStoredToken }
+		runner.ClearToken = func() error { return errStoredToken }
+	} else if cfgErr == nil && auth.HasOAuthApp(cfg) {
+		runner.OAuthLogin = func(ctx context.Context) (string, error) {
+			return auth.Login(ctx, cfg)
+		}
 	}
-
-	res := jsonOutput{
-		Tasks:          make([]simpleTask, 0, len(tasks)),
-		PriorityCounts: make(map[int]int),
-		Total:          len(tasks),
+	if dataDir, err := config.DataDir(); err == nil {
+		runner.ControlSocket = control.SocketPath(dataDir)
 	}
+	return runner.Run(ctx, args)
+}
 
-	var tooltipLines []string
-	maxPriority := 1 // Todoist P1 is 1 (natural), P4 is 4 (urgent)
+// runStatusOutput prints a task summary for status bars. An empty format
+// falls back to the status_bar section of the config, then to Waybar.
+func runStatusOutput(format string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := useProfile(cfg); err != nil {
+		return err
+	}
+	sb := cfg.StatusBar
 
-	for _, t := range tasks {
-		date := ""
-		if t.Due != nil {
-			date = t.Due.Date
-		}
+	if format == "" {
+		format = sb.Format
+	}
+	if format == "" {
+		format = "waybar"
+	}
+	filter := sb.Filter
+	if filter == "" {
+		filter = statusbar.DefaultFilter
+	}
+	if err := statusbar.ValidateFields(sb.Fields); err != nil {
+		return err
+	}
 
-		res.Tasks = append(res.Tasks, simpleTask{
-			Name:     t.Content,
-			Date:     date,
-			Priority: t.Priority,
-		})
+	opts := statusbar.Options{
+		Fields:   sb.Fields,
+		Template: sb.Template,
+		Colors: map[int]string{
+			4: cfg.UI.Theme.Priority1,
+			3: cfg.UI.Theme.Priority2,
+			2: cfg.UI.Theme.Priority3,
+		},
+	}
+	formatter, err := statusbar.New(format, opts)
+	if err != nil {
+		return err
+	}
 
-		res.PriorityCounts[t.Priority]++
+	// Get token from secure storage
+	token, err := getToken()
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+	if token == "" {
+		return cli.ErrNoToken
+	}
 
-		// Track highest priority for styling
-		if t.Priority > maxPriority {
-			maxPriority = t.Priority
-		}
+	logs := openHTTPLogs()
+	defer logs.Close()
 
-		// Build a nice tooltip line for each task with priority
-		tooltipLines = append(tooltipLines, fmt.Sprintf("P%d: %s", 5-t.Priority, t.Content))
+	// Create API client
+	client, err := newAPIClient(cfg, token, logs)
+	if err != nil {
+		return err
 	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	// Map the logic to Waybar fields
-	res.Text = fmt.Sprintf("%d", res.Total)
-	res.Tooltip = strings.Join(tooltipLines, "\n")
-	res.Class = fmt.Sprintf("p%d", maxPriority)
-
-	// Output as single-line JSON (better for Waybar exec)
-	output, err := json.Marshal(res)
+	// Fetch tasks
+	tasks, err := client.GetTasksByFilter(ctx, filter)
 	if err != nil {
-		return fmt.Errorf("failed to marshal JSON: %w", err)
+		return fmt.Errorf("failed to fetch tasks: %w", err)
 	}
 
-	fmt.Println(string(output))
-	return nil
+	// Project names are only needed when shown
+	projectNames := make(map[string]string)
+	if slices.Contains(sb.Fields, statusbar.FieldProject) {
+		projects, err := client.GetProjects(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch projects: %w", err)
+		}
+		for _, p := range projects {
+			projectNames[p.ID] = p.Name
+		}
+	}
+
+	summary := statusbar.Build(tasks, projectNames, opts, time.Now())
+	return formatter.Format(os.Stdout, summary)
 }
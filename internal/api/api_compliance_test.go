@@ -2,21 +2,26 @@ package api_test
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
 )
 
-func TestAPICompliance(t *testing.T) {
-	// allowedURLs is a list of approved URL prefixes.
-	// strictly standardizing on API v1 https://api.todoist.com/api/v1 // and OAuth.
-	allowedURLs := []string{
-		"https://api.todoist.com/api/v1",
-		"https://todoist.com/oauth/authorize",
-		"https://todoist.com/oauth/access_token",
-	}
+// allowedURLs is a list of approved URL prefixes.
+// strictly standardizing on API v1 https://api.todoist.com/api/v1 // and OAuth.
+var allowedURLs = []string{
+	"https://api.todoist.com/api/v1",
+	"https://todoist.com/oauth/authorize",
+	"https://todoist.com/oauth/access_token",
+}
 
+func TestAPICompliance(t *testing.T) {
 	// Get current working directory
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -121,3 +126,61 @@ func TestAPICompliance(t *testing.T) {
 		t.Fatalf("Failed to walk directories: %v", err)
 	}
 }
+
+// TestAPICompliance_Fixtures checks the captured --debug-http logs in
+// testdata: they only talk to approved endpoints, hold no credentials, and
+// the client still understands the recorded responses.
+func TestAPICompliance_Fixtures(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.jsonl"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no fixtures found: %v", err)
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var ex api.Exchange
+			if err := json.Unmarshal([]byte(line), &ex); err != nil {
+				t.Fatalf("%s:%d: %v", path, i+1, err)
+			}
+			if !slices.ContainsFunc(allowedURLs, func(p string) bool { return strings.HasPrefix(ex.URL, p) }) {
+				t.Errorf("%s:%d: unauthorized URL %s", path, i+1, ex.URL)
+			}
+			if auth := ex.RequestHeader.Get("Authorization"); auth != "" && auth != "REDACTED" {
+				t.Errorf("%s:%d: token not redacted", path, i+1)
+			}
+		}
+	}
+
+	replay, err := api.LoadReplayTransport(filepath.Join("testdata", "session.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := api.NewClient("test-token")
+	client.SetTransport(replay)
+	ctx := context.Background()
+
+	projects, err := client.GetProjects(ctx)
+	if err != nil || len(projects) != 3 || !projects[0].InboxProject || projects[2].Name != "Home" {
+		t.Errorf("unexpected projects %+v (%v)", projects, err)
+	}
+
+	tasks, err := client.GetTasksByFilter(ctx, "today")
+	if err != nil || len(tasks) != 2 || tasks[0].Priority != 4 || tasks[1].Due == nil || !tasks[1].Due.IsRecurring {
+		t.Errorf("unexpected tasks %+v (%v)", tasks, err)
+	}
+
+	resp, err := client.ReadResources(ctx, "", "projects", "items")
+	if err != nil || !resp.FullSync || len(resp.Items) != 1 || resp.SyncToken == "" {
+		t.Errorf("unexpected sync response %+v (%v)", resp, err)
+	}
+
+	_, err = client.GetProject(ctx, "6Jf8VQXxpwv56VQX")
+	apiErr, ok := api.IsAPIError(err)
+	if !ok || !apiErr.IsNotFound() || apiErr.Details.Tag != "PROJECT_NOT_FOUND" {
+		t.Errorf("expected a decoded 404, got %v", err)
+	}
+}
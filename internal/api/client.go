@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -28,28 +29,30 @@ type Client struct {
 	MaxRetries int
 	// RetryBaseDelay is the initial delay for exponential backoff (default 500ms).
 	RetryBaseDelay time.Duration
+	// Limiter spaces out requests to stay within RequestQuota. nil disables it.
+	Limiter *RateLimiter
+	// FullSyncLimiter additionally spaces out full syncs to stay within
+	// FullSyncQuota. nil disables it.
+	FullSyncLimiter *RateLimiter
 }
 
 // NewClient creates a new Todoist API client with the given access token.
 // Uses connection pooling for better performance.
 func NewClient(accessToken string) *Client {
-	transport := &http.Transport{
-		MaxIdleConns:        20,               // Total max idle connections
-		MaxIdleConnsPerHost: 10,               // Max idle connections per host
-		MaxConnsPerHost:     20,               // Max total connections per host
-		IdleConnTimeout:     90 * time.Second, // How long idle connections stay in pool
-		DisableKeepAlives:   false,            // Enable keep-alive for connection reuse
-	}
+	// Without a proxy or CA file the transport cannot fail
+	transport, _ := NewTransport(Network{})
 
 	return &Client{
 		httpClient: &http.Client{
 			Timeout:   DefaultTimeout,
 			Transport: transport,
 		},
-		baseURL:        BaseURL,
-		accessToken:    accessToken,
-		MaxRetries:     3,
-		RetryBaseDelay: 500 * time.Millisecond,
+		baseURL:         BaseURL,
+		accessToken:     accessToken,
+		MaxRetries:      3,
+		RetryBaseDelay:  500 * time.Millisecond,
+		Limiter:         NewRateLimiter(RequestQuota, QuotaWindow, 50),
+		FullSyncLimiter: NewRateLimiter(FullSyncQuota, QuotaWindow, 10),
 	}
 }
 
@@ -58,8 +61,28 @@ func (c *Client) SetHTTPClient(httpClient *http.Client) {
 	c.httpClient = httpClient
 }
 
-// do performs an HTTP request and decodes the JSON response.
-func (c *Client) do(method, path string, body interface{}, result interface{}) error {
+// Transport returns the RoundTripper requests are sent through.
+func (c *Client) Transport() http.RoundTripper {
+	if c.httpClient.Transport == nil {
+		return http.DefaultTransport
+	}
+	return c.httpClient.Transport
+}
+
+// SetTransport replaces the RoundTripper requests are sent through, e.g.
+// with a LoggingTransport wrapping Transport().
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// SetBaseURL overrides the API base URL (e.g. to point at a local server).
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// do performs an HTTP request and decodes the JSON response. A non-empty
+// requestID is sent as X-Request-Id.
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, result interface{}, requestID string) error {
 	// Build URL
 	reqURL := c.baseURL + path
 
@@ -74,7 +97,7 @@ func (c *Client) do(method, path string, body interface{}, result interface{}) e
 	}
 
 	// Create request
-	req, err := http.NewRequest(method, reqURL, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -84,6 +107,9 @@ func (c *Client) do(method, path string, body interface{}, result interface{}) e
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if requestID != "" {
+		req.Header.Set("X-Request-Id", requestID)
+	}
 
 	// Execute request
 	resp, err := c.httpClient.Do(req)
@@ -100,10 +126,7 @@ func (c *Client) do(method, path string, body interface{}, result interface{}) e
 
 	// Check for errors
 	if resp.StatusCode >= 400 {
-		return &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(respBody),
-		}
+		return newAPIError(resp, respBody)
 	}
 
 	// Decode response (if expected)
@@ -117,26 +140,27 @@ func (c *Client) do(method, path string, body interface{}, result interface{}) e
 }
 
 // Get performs a GET request with retry on transient failures.
-func (c *Client) Get(path string, result interface{}) error {
-	return c.doWithRetry(http.MethodGet, path, nil, result)
+func (c *Client) Get(ctx context.Context, path string, result interface{}) error {
+	return c.doWithRetry(ctx, http.MethodGet, path, nil, result)
 }
 
 // GetWithQuery performs a GET request with query parameters and retry on transient failures.
-func (c *Client) GetWithQuery(path string, query url.Values, result interface{}) error {
+func (c *Client) GetWithQuery(ctx context.Context, path string, query url.Values, result interface{}) error {
 	if len(query) > 0 {
 		path = path + "?" + query.Encode()
 	}
-	return c.doWithRetry(http.MethodGet, path, nil, result)
+	return c.doWithRetry(ctx, http.MethodGet, path, nil, result)
 }
 
-// Post performs a POST request.
-func (c *Client) Post(path string, body interface{}, result interface{}) error {
-	return c.do(http.MethodPost, path, body, result)
+// Post performs a POST request with retry on transient failures. Retries
+// reuse the request's X-Request-Id, so they cannot apply a change twice.
+func (c *Client) Post(ctx context.Context, path string, body interface{}, result interface{}) error {
+	return c.doWithRetry(ctx, http.MethodPost, path, body, result)
 }
 
 // Delete performs a DELETE request with retry on transient failures.
-func (c *Client) Delete(path string) error {
-	return c.doWithRetry(http.MethodDelete, path, nil, nil)
+func (c *Client) Delete(ctx context.Context, path string) error {
+	return c.doWithRetry(ctx, http.MethodDelete, path, nil, nil)
 }
 
 // buildFilterQuery builds query parameters for task filtering.
@@ -0,0 +1,405 @@
+package api
+
+// Builders for task-related Sync API commands.
+// They mirror the REST task endpoints so the same change can be sent either
+// immediately or later as part of a batch.
+
+// AddTaskCommand builds an item_add command with a temp ID.
+// Later commands in the same batch may use the temp ID as task or parent ID.
+func AddTaskCommand(req CreateTaskRequest) SyncCommand {
+	args := map[string]interface{}{
+		"content": req.Content,
+	}
+	if req.Description != "" {
+		args["description"] = req.Description
+	}
+	if req.ProjectID != "" {
+		args["project_id"] = req.ProjectID
+	}
+	if req.SectionID != "" {
+		args["section_id"] = req.SectionID
+	}
+	if req.ParentID != "" {
+		args["parent_id"] = req.ParentID
+	}
+	if req.Order != 0 {
+		args["child_order"] = req.Order
+	}
+	if len(req.Labels) > 0 {
+		args["labels"] = req.Labels
+	}
+	if req.Priority != 0 {
+		args["priority"] = req.Priority
+	}
+	if due := syncDue(req.DueString, req.DueDate, req.DueDatetime, req.DueLang); due != nil {
+		args["due"] = due
+	}
+	if req.AssigneeID != "" {
+		args["responsible_uid"] = req.AssigneeID
+	}
+	if req.Duration > 0 && req.DurationUnit != "" {
+		args["duration"] = map[string]interface{}{
+			"amount": req.Duration,
+			"unit":   req.DurationUnit,
+		}
+	}
+	if req.DeadlineDate != "" {
+		args["deadline"] = map[string]interface{}{"date": req.DeadlineDate}
+	}
+
+	return NewSyncCommandWithTempID("item_add", args)
+}
+
+// UpdateTaskCommand builds an item_update command.
+// Project and section changes are not part of item_update; use MoveTaskCommand.
+func UpdateTaskCommand(id string, req UpdateTaskRequest) SyncCommand {
+	args := map[string]interface{}{
+		"id": id,
+	}
+	if req.Content != nil {
+		args["content"] = *req.Content
+	}
+	if req.Description != nil {
+		args["description"] = *req.Description
+	}
+	if req.Labels != nil {
+		args["labels"] = req.Labels
+	}
+	if req.Priority != nil {
+		args["priority"] = *req.Priority
+	}
+	if req.DueString != nil && *req.DueString == "no date" {
+		// Clearing the due date is expressed as a null due object
+		args["due"] = nil
+	} else if due := syncDue(deref(req.DueString), deref(req.DueDate), deref(req.DueDatetime), deref(req.DueLang)); due != nil {
+		args["due"] = due
+	}
+	if req.AssigneeID != nil {
+		if *req.AssigneeID == "" {
+			args["responsible_uid"] = nil
+		} else {
+			args["responsible_uid"] = *req.AssigneeID
+		}
+	}
+	if req.Duration != nil && *req.Duration == 0 {
+		args["duration"] = nil
+	} else if req.Duration != nil && req.DurationUnit != nil {
+		args["duration"] = map[string]interface{}{
+			"amount": *req.Duration,
+			"unit":   *req.DurationUnit,
+		}
+	}
+	if req.DeadlineDate != nil {
+		if *req.DeadlineDate == "" {
+			args["deadline"] = nil
+		} else {
+			args["deadline"] = map[string]interface{}{"date": *req.DeadlineDate}
+		}
+	}
+
+	return NewSyncCommand("item_update", args)
+}
+
+// MoveTaskCommand builds an item_move command.
+// item_move accepts a single destination, so the most specific non-empty one
+// wins: parent, then section, then project.
+func MoveTaskCommand(id string, sectionID, projectID, parentID *string) SyncCommand {
+	args := map[string]interface{}{
+		"id": id,
+	}
+	switch {
+	case parentID != nil && *parentID != "":
+		args["parent_id"] = *parentID
+	case sectionID != nil && *sectionID != "":
+		args["section_id"] = *sectionID
+	case projectID != nil && *projectID != "":
+		args["project_id"] = *projectID
+	}
+	return NewSyncCommand("item_move", args)
+}
+
+// CloseTaskCommand builds an item_close command.
+// Recurring tasks are moved to their next occurrence.
+func CloseTaskCommand(id string) SyncCommand {
+	return NewSyncCommand("item_close", map[string]interface{}{"id": id})
+}
+
+// CompleteTaskCommand builds an item_complete command. Unlike item_close it
+// never reschedules recurring tasks. completedAt may be empty for now.
+func CompleteTaskCommand(id, completedAt string) SyncCommand {
+	args := map[string]interface{}{"id": id}
+	if completedAt != "" {
+		args["completed_at"] = completedAt
+	}
+	return NewSyncCommand("item_complete", args)
+}
+
+// ReopenTaskCommand builds an item_uncomplete command.
+func ReopenTaskCommand(id string) SyncCommand {
+	return NewSyncCommand("item_uncomplete", map[string]interface{}{"id": id})
+}
+
+// DeleteTaskCommand builds an item_delete command.
+func DeleteTaskCommand(id string) SyncCommand {
+	return NewSyncCommand("item_delete", map[string]interface{}{"id": id})
+}
+
+// AddProjectCommand builds a project_add command with a temp ID.
+// parentID may be empty or the (temp) ID of another project.
+func AddProjectCommand(name, parentID string) SyncCommand {
+	args := map[string]interface{}{"name": name}
+	if parentID != "" {
+		args["parent_id"] = parentID
+	}
+	return NewSyncCommandWithTempID("project_add", args)
+}
+
+// AddSectionCommand builds a section_add command with a temp ID.
+// projectID may be the temp ID of a project created in the same batch.
+func AddSectionCommand(name, projectID string) SyncCommand {
+	return NewSyncCommandWithTempID("section_add", map[string]interface{}{
+		"name":       name,
+		"project_id": projectID,
+	})
+}
+
+// AddCommentCommand builds a note_add command on a task.
+// taskID may be the temp ID of a task created in the same batch.
+func AddCommentCommand(taskID, content string) SyncCommand {
+	return NewSyncCommandWithTempID("note_add", map[string]interface{}{
+		"item_id": taskID,
+		"content": content,
+	})
+}
+
+// UpdateCommentReactionsCommand builds a note_update command that replaces
+// the reactions of a comment, a map of emoji to the IDs of the users who
+// reacted with it.
+func UpdateCommentReactionsCommand(commentID string, reactions map[string][]string) SyncCommand {
+	if reactions == nil {
+		reactions = map[string][]string{}
+	}
+	return NewSyncCommand("note_update", map[string]interface{}{
+		"id":        commentID,
+		"reactions": reactions,
+	})
+}
+
+// AddProjectCommentCommand builds a project_note_add command.
+// projectID may be the temp ID of a project created in the same batch.
+func AddProjectCommentCommand(projectID, content string) SyncCommand {
+	return NewSyncCommandWithTempID("project_note_add", map[string]interface{}{
+		"project_id": projectID,
+		"content":    content,
+	})
+}
+
+// RestoreProjectCommand builds a project_add command that recreates p with
+// its color, view style, favorite flag and position among its siblings.
+func RestoreProjectCommand(p Project) SyncCommand {
+	args := map[string]interface{}{
+		"name":        p.Name,
+		"child_order": p.ChildOrder,
+		"is_favorite": p.IsFavorite,
+	}
+	if p.Description != "" {
+		args["description"] = p.Description
+	}
+	if p.Color != "" {
+		args["color"] = p.Color
+	}
+	if p.ViewStyle != "" {
+		args["view_style"] = p.ViewStyle
+	}
+	if p.ParentID != nil && *p.ParentID != "" {
+		args["parent_id"] = *p.ParentID
+	}
+	return NewSyncCommandWithTempID("project_add", args)
+}
+
+// RestoreSectionCommand builds a section_add command that recreates s at
+// its position in the project.
+func RestoreSectionCommand(s Section) SyncCommand {
+	return NewSyncCommandWithTempID("section_add", map[string]interface{}{
+		"name":          s.Name,
+		"project_id":    s.ProjectID,
+		"section_order": s.SectionOrder,
+	})
+}
+
+// RestoreReminderCommand builds a reminder_add command that recreates r on
+// the task r.ItemID.
+func RestoreReminderCommand(r Reminder) SyncCommand {
+	args := map[string]interface{}{
+		"item_id": r.ItemID,
+		"type":    r.Type,
+	}
+	if r.Due != nil {
+		args["due"] = r.Due
+	}
+	if r.Type == "relative" {
+		args["minute_offset"] = r.MinuteOffset
+	}
+	return NewSyncCommandWithTempID("reminder_add", args)
+}
+
+// RestoreLabelCommand builds a label_add command that recreates l.
+func RestoreLabelCommand(l Label) SyncCommand {
+	args := map[string]interface{}{
+		"name":        l.Name,
+		"item_order":  l.ItemOrder,
+		"is_favorite": l.IsFavorite,
+	}
+	if l.Color != "" {
+		args["color"] = l.Color
+	}
+	return NewSyncCommandWithTempID("label_add", args)
+}
+
+// RestoreFilterCommand builds a filter_add command that recreates f.
+func RestoreFilterCommand(f Filter) SyncCommand {
+	args := map[string]interface{}{
+		"name":        f.Name,
+		"query":       f.Query,
+		"item_order":  f.ItemOrder,
+		"is_favorite": f.IsFavorite,
+	}
+	if f.Color != "" {
+		args["color"] = f.Color
+	}
+	return NewSyncCommandWithTempID("filter_add", args)
+}
+
+// RestoreTaskCommand builds an item_add command that recreates t, keeping its
+// position, due date and other attributes. The new task gets a new ID; the
+// command's temp ID stands in for it until the server assigns one.
+func RestoreTaskCommand(t Task) SyncCommand {
+	args := map[string]interface{}{
+		"content":     t.Content,
+		"description": t.Description,
+		"project_id":  t.ProjectID,
+		"priority":    t.Priority,
+		"child_order": t.ChildOrder,
+		"labels":      t.Labels,
+	}
+	if t.SectionID != nil && *t.SectionID != "" {
+		args["section_id"] = *t.SectionID
+	}
+	if t.ParentID != nil && *t.ParentID != "" {
+		args["parent_id"] = *t.ParentID
+	}
+	if t.Due != nil {
+		args["due"] = dueObject(t.Due)
+	}
+	if t.Deadline != nil {
+		args["deadline"] = map[string]interface{}{"date": t.Deadline.Date}
+	}
+	if t.Duration != nil {
+		args["duration"] = map[string]interface{}{
+			"amount": t.Duration.Amount,
+			"unit":   t.Duration.Unit,
+		}
+	}
+	if t.ResponsibleUID != nil {
+		args["responsible_uid"] = *t.ResponsibleUID
+	}
+
+	return NewSyncCommandWithTempID("item_add", args)
+}
+
+// RevertTaskCommand builds an item_update command that sets the editable
+// fields of a task back to the values in t.
+func RevertTaskCommand(t Task) SyncCommand {
+	labels := t.Labels
+	if labels == nil {
+		// A null list would leave the current labels untouched
+		labels = []string{}
+	}
+	args := map[string]interface{}{
+		"id":              t.ID,
+		"content":         t.Content,
+		"description":     t.Description,
+		"priority":        t.Priority,
+		"labels":          labels,
+		"due":             nil,
+		"deadline":        nil,
+		"duration":        nil,
+		"responsible_uid": nil,
+	}
+	if t.Due != nil {
+		args["due"] = dueObject(t.Due)
+	}
+	if t.Deadline != nil {
+		args["deadline"] = map[string]interface{}{"date": t.Deadline.Date}
+	}
+	if t.Duration != nil {
+		args["duration"] = map[string]interface{}{
+			"amount": t.Duration.Amount,
+			"unit":   t.Duration.Unit,
+		}
+	}
+	if t.ResponsibleUID != nil {
+		args["responsible_uid"] = *t.ResponsibleUID
+	}
+	return NewSyncCommand("item_update", args)
+}
+
+// SetDueCommand builds an item_update command that sets a task's due date to
+// due exactly, including recurrence. A nil due removes the date.
+func SetDueCommand(id string, due *Due) SyncCommand {
+	args := map[string]interface{}{
+		"id":  id,
+		"due": nil,
+	}
+	if due != nil {
+		args["due"] = dueObject(due)
+	}
+	return NewSyncCommand("item_update", args)
+}
+
+// dueObject converts a task's due date to a Sync API due object.
+func dueObject(due *Due) map[string]interface{} {
+	obj := map[string]interface{}{
+		"date":         due.Date,
+		"is_recurring": due.IsRecurring,
+	}
+	if due.Datetime != nil && *due.Datetime != "" {
+		obj["date"] = *due.Datetime
+	}
+	if due.String != "" {
+		obj["string"] = due.String
+	}
+	if due.Timezone != nil {
+		obj["timezone"] = *due.Timezone
+	}
+	if due.Lang != "" {
+		obj["lang"] = due.Lang
+	}
+	return obj
+}
+
+// syncDue converts REST-style due fields to a Sync API due object.
+func syncDue(dueString, dueDate, dueDatetime, dueLang string) map[string]interface{} {
+	due := map[string]interface{}{}
+	switch {
+	case dueString != "":
+		due["string"] = dueString
+	case dueDatetime != "":
+		due["date"] = dueDatetime
+	case dueDate != "":
+		due["date"] = dueDate
+	default:
+		return nil
+	}
+	if dueLang != "" {
+		due["lang"] = dueLang
+	}
+	return due
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
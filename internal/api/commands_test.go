@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateTaskCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		req  UpdateTaskRequest
+		want map[string]interface{}
+	}{
+		{
+			name: "priority only",
+			req:  UpdateTaskRequest{Priority: IntPtr(4)},
+			want: map[string]interface{}{"id": "t1", "priority": 4},
+		},
+		{
+			name: "due date",
+			req:  UpdateTaskRequest{DueDate: StringPtr("2026-03-01")},
+			want: map[string]interface{}{"id": "t1", "due": map[string]interface{}{"date": "2026-03-01"}},
+		},
+		{
+			name: "recurring due string wins over date",
+			req:  UpdateTaskRequest{DueDate: StringPtr("2026-03-01"), DueString: StringPtr("every monday")},
+			want: map[string]interface{}{"id": "t1", "due": map[string]interface{}{"string": "every monday"}},
+		},
+		{
+			name: "remove due date",
+			req:  UpdateTaskRequest{DueString: StringPtr("no date")},
+			want: map[string]interface{}{"id": "t1", "due": nil},
+		},
+		{
+			name: "remove duration",
+			req:  UpdateTaskRequest{Duration: IntPtr(0)},
+			want: map[string]interface{}{"id": "t1", "duration": nil},
+		},
+		{
+			name: "deadline",
+			req:  UpdateTaskRequest{DeadlineDate: StringPtr("2026-03-06")},
+			want: map[string]interface{}{"id": "t1", "deadline": map[string]interface{}{"date": "2026-03-06"}},
+		},
+		{
+			name: "remove deadline",
+			req:  UpdateTaskRequest{DeadlineDate: StringPtr("")},
+			want: map[string]interface{}{"id": "t1", "deadline": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := UpdateTaskCommand("t1", tt.req)
+			if cmd.Type != "item_update" || cmd.UUID == "" {
+				t.Errorf("unexpected command header: %+v", cmd)
+			}
+			if !reflect.DeepEqual(cmd.Args, tt.want) {
+				t.Errorf("args = %#v, want %#v", cmd.Args, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddTaskCommand(t *testing.T) {
+	cmd := AddTaskCommand(CreateTaskRequest{
+		Content:   "Write report",
+		ProjectID: "p1",
+		ParentID:  "parent-temp",
+		DueString: "tomorrow",
+		Priority:  3,
+	})
+
+	if cmd.Type != "item_add" || cmd.TempID == "" {
+		t.Fatalf("expected item_add with temp ID, got %+v", cmd)
+	}
+	args := cmd.Args.(map[string]interface{})
+	if args["parent_id"] != "parent-temp" || args["project_id"] != "p1" || args["priority"] != 3 {
+		t.Errorf("unexpected args: %#v", args)
+	}
+	if due := args["due"].(map[string]interface{}); due["string"] != "tomorrow" {
+		t.Errorf("expected due string, got %#v", due)
+	}
+}
+
+func TestMoveTaskCommand(t *testing.T) {
+	empty := ""
+	project := "p1"
+	section := "s1"
+
+	cmd := MoveTaskCommand("t1", nil, &project, &empty)
+	want := map[string]interface{}{"id": "t1", "project_id": "p1"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("outdent to root: args = %#v, want %#v", cmd.Args, want)
+	}
+
+	cmd = MoveTaskCommand("t1", &section, &project, nil)
+	want = map[string]interface{}{"id": "t1", "section_id": "s1"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("move to section: args = %#v, want %#v", cmd.Args, want)
+	}
+}
+
+func TestUpdateCommentReactionsCommand(t *testing.T) {
+	cmd := UpdateCommentReactionsCommand("c1", nil)
+	args := cmd.Args.(map[string]interface{})
+	if cmd.Type != "note_update" || args["id"] != "c1" {
+		t.Fatalf("unexpected command: %+v", cmd)
+	}
+	// Clearing the last reaction must still send an empty map
+	data, _ := json.Marshal(cmd.Args)
+	if string(data) != `{"id":"c1","reactions":{}}` {
+		t.Errorf("args = %s", data)
+	}
+}
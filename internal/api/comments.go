@@ -1,69 +1,87 @@
 package api
 
 import (
+	"context"
 	"fmt"
-	"net/url"
+	"sync"
 )
 
+// maxConcurrentCommentRequests limits parallel requests in GetCommentsForTasks.
+const maxConcurrentCommentRequests = 5
+
 // GetComments returns comments for a task or project.
 // Either taskID or projectID must be provided.
 // Handles v1 API pagination automatically, fetching all pages.
-func (c *Client) GetComments(taskID, projectID string) ([]Comment, error) {
-	var allComments []Comment
-	query := url.Values{}
-	if taskID != "" {
-		query.Set("task_id", taskID)
-	} else if projectID != "" {
-		query.Set("project_id", projectID)
-	}
+func (c *Client) GetComments(ctx context.Context, taskID, projectID string) ([]Comment, error) {
+	return collect(c.Comments(ctx, taskID, projectID), "comments")
+}
 
-	for {
-		var response PaginatedResponse[Comment]
-		if err := c.GetWithQuery("/comments", query, &response); err != nil {
-			return nil, fmt.Errorf("failed to get comments: %w", err)
-		}
+// GetCommentsForTasks fetches the comments of several tasks concurrently.
+// The result is keyed by task ID. The first error is returned along with
+// the comments that could be fetched.
+func (c *Client) GetCommentsForTasks(ctx context.Context, taskIDs []string) (map[string][]Comment, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		comments = make(map[string][]Comment)
+		sem      = make(chan struct{}, maxConcurrentCommentRequests)
+	)
 
-		allComments = append(allComments, response.Results...)
+	for _, id := range taskIDs {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
 
-		if response.NextCursor == nil || *response.NextCursor == "" {
-			break
-		}
-		query.Set("cursor", *response.NextCursor)
+			list, err := c.GetComments(ctx, id, "")
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			comments[id] = list
+		}(id)
 	}
+	wg.Wait()
 
-	return allComments, nil
+	return comments, firstErr
 }
 
 // GetComment returns a single comment by ID.
-func (c *Client) GetComment(id string) (*Comment, error) {
+func (c *Client) GetComment(ctx context.Context, id string) (*Comment, error) {
 	var comment Comment
-	if err := c.Get("/comments/"+id, &comment); err != nil {
+	if err := c.Get(ctx, "/comments/"+id, &comment); err != nil {
 		return nil, fmt.Errorf("failed to get comment %s: %w", id, err)
 	}
 	return &comment, nil
 }
 
 // CreateComment creates a new comment on a task or project.
-func (c *Client) CreateComment(req CreateCommentRequest) (*Comment, error) {
+func (c *Client) CreateComment(ctx context.Context, req CreateCommentRequest) (*Comment, error) {
 	var comment Comment
-	if err := c.Post("/comments", req, &comment); err != nil {
+	if err := c.Post(ctx, "/comments", req, &comment); err != nil {
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
 	return &comment, nil
 }
 
 // UpdateComment updates an existing comment.
-func (c *Client) UpdateComment(id string, req UpdateCommentRequest) (*Comment, error) {
+func (c *Client) UpdateComment(ctx context.Context, id string, req UpdateCommentRequest) (*Comment, error) {
 	var comment Comment
-	if err := c.Post("/comments/"+id, req, &comment); err != nil {
+	if err := c.Post(ctx, "/comments/"+id, req, &comment); err != nil {
 		return nil, fmt.Errorf("failed to update comment %s: %w", id, err)
 	}
 	return &comment, nil
 }
 
 // DeleteComment deletes a comment.
-func (c *Client) DeleteComment(id string) error {
-	if err := c.Delete("/comments/" + id); err != nil {
+func (c *Client) DeleteComment(ctx context.Context, id string) error {
+	if err := c.Delete(ctx, "/comments/"+id); err != nil {
 		return fmt.Errorf("failed to delete comment %s: %w", id, err)
 	}
 	return nil
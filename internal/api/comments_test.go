@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -54,7 +55,7 @@ func TestGetComments(t *testing.T) {
 			client := NewClient("test-token")
 			client.baseURL = server.URL
 
-			comments, err := client.GetComments(tt.taskID, "")
+			comments, err := client.GetComments(context.Background(), tt.taskID, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetComments() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -81,7 +82,7 @@ func TestCreateComment(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	comment, err := client.CreateComment(CreateCommentRequest{Content: content, TaskID: taskID})
+	comment, err := client.CreateComment(context.Background(), CreateCommentRequest{Content: content, TaskID: taskID})
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -104,7 +105,7 @@ func TestDeleteComment(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	err := client.DeleteComment(id)
+	err := client.DeleteComment(context.Background(), id)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
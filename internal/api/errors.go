@@ -1,11 +1,57 @@
 package api
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrorDetails is the error payload Todoist sends with a failed request or
+// Sync command.
+type ErrorDetails struct {
+	Message  string                 `json:"error"`
+	Code     int                    `json:"error_code"`
+	Tag      string                 `json:"error_tag"`
+	HTTPCode int                    `json:"http_code"`
+	Extra    map[string]interface{} `json:"error_extra"`
+}
+
+// IsPremiumRequired reports whether the request failed because it needs a
+// paid plan.
+func (d ErrorDetails) IsPremiumRequired() bool {
+	return strings.Contains(strings.ToUpper(d.Tag), "PREMIUM") ||
+		strings.Contains(strings.ToLower(d.Message), "premium")
+}
 
 // APIError represents an error returned by the Todoist API.
 type APIError struct {
 	StatusCode int
-	Message    string
+	// Message is Details.Message if the response held an error payload,
+	// otherwise the response body.
+	Message string
+	// Details is the decoded error payload; zero if there was none.
+	Details ErrorDetails
+	// RetryAfter is how long the server asked to wait before retrying,
+	// from the Retry-After header; 0 if it did not say.
+	RetryAfter time.Duration
+}
+
+// newAPIError builds an APIError from a failed response and its body.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(body)),
+		RetryAfter: parseRetryAfter(resp.Header, time.Now()),
+	}
+	if err := json.Unmarshal(body, &e.Details); err == nil && e.Details.Message != "" {
+		e.Message = e.Details.Message
+	}
+	return e
 }
 
 // Error implements the error interface.
@@ -38,8 +84,63 @@ func (e *APIError) IsServerError() bool {
 	return e.StatusCode >= 500 && e.StatusCode < 600
 }
 
-// IsAPIError checks if an error is an APIError and returns it.
+// SyncError is the failure of a single Sync command, as reported in the
+// response's sync_status. The request itself succeeded.
+type SyncError struct {
+	CommandUUID string
+	Details     ErrorDetails
+}
+
+// Error implements the error interface.
+func (e *SyncError) Error() string {
+	return "sync command failed: " + e.Details.Message
+}
+
+// IsNotFound reports whether the command referred to an object that does
+// not exist.
+func (e *SyncError) IsNotFound() bool {
+	return e.Details.HTTPCode == 404
+}
+
+// newSyncError decodes the sync_status entry of a failed command.
+func newSyncError(cmdUUID string, raw json.RawMessage) *SyncError {
+	e := &SyncError{CommandUUID: cmdUUID}
+	var status string
+	if err := json.Unmarshal(raw, &status); err == nil {
+		e.Details.Message = status
+		return e
+	}
+	if err := json.Unmarshal(raw, &e.Details); err != nil || e.Details.Message == "" {
+		e.Details.Message = string(raw)
+	}
+	return e
+}
+
+// IsAPIError checks if an error is or wraps an APIError and returns it.
 func IsAPIError(err error) (*APIError, bool) {
-	apiErr, ok := err.(*APIError)
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
 	return apiErr, ok
 }
+
+// IsNetworkError reports whether err is a connectivity failure rather than a
+// response from the API, i.e. the request may succeed once the network is back.
+func IsNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := IsAPIError(err); ok {
+		return false
+	}
+	var syncErr *SyncError
+	if errors.As(err, &syncErr) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError_DecodesPayload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error": "Premium only feature", "error_code": 32, "error_tag": "PREMIUM_ONLY", "http_code": 403, "error_extra": {"event_id": "abc"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	err := client.Get(context.Background(), "/tasks", nil)
+	apiErr, ok := IsAPIError(fmt.Errorf("loading tasks: %w", err))
+	if !ok {
+		t.Fatalf("expected a wrapped *APIError to match, got %v", err)
+	}
+	if apiErr.Message != "Premium only feature" {
+		t.Errorf("expected the decoded message, got %q", apiErr.Message)
+	}
+	if apiErr.Details.Code != 32 || apiErr.Details.HTTPCode != 403 || apiErr.Details.Extra["event_id"] != "abc" {
+		t.Errorf("unexpected details %+v", apiErr.Details)
+	}
+	if !apiErr.Details.IsPremiumRequired() {
+		t.Error("expected a premium error")
+	}
+}
+
+func TestAPIError_KeepsPlainBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid argument value\n"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	apiErr, ok := IsAPIError(client.Get(context.Background(), "/tasks", nil))
+	if !ok || apiErr.Message != "Invalid argument value" || apiErr.Details.Message != "" {
+		t.Errorf("expected the body as message and no details, got %+v", apiErr)
+	}
+}
+
+func TestCommandError(t *testing.T) {
+	result := &SyncResponse{SyncStatus: map[string]json.RawMessage{
+		"ok":     json.RawMessage(`"ok"`),
+		"failed": json.RawMessage(`{"error": "Project not found", "error_code": 21, "error_tag": "PROJECT_NOT_FOUND", "http_code": 404}`),
+		"odd":    json.RawMessage(`"bad"`),
+	}}
+
+	if err := result.CommandError("ok"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := result.CommandError("missing"); err != nil {
+		t.Errorf("expected no error for an unknown command, got %v", err)
+	}
+
+	var syncErr *SyncError
+	if err := result.CommandError("failed"); !errors.As(err, &syncErr) {
+		t.Fatalf("expected a *SyncError, got %v", err)
+	}
+	if syncErr.CommandUUID != "failed" || !syncErr.IsNotFound() || syncErr.Details.Tag != "PROJECT_NOT_FOUND" {
+		t.Errorf("unexpected sync error %+v", syncErr)
+	}
+	if syncErr.Error() != "sync command failed: Project not found" {
+		t.Errorf("unexpected message %q", syncErr.Error())
+	}
+
+	if err := result.CommandError("odd"); err == nil || err.Error() != "sync command failed: bad" {
+		t.Errorf("expected a failure for a non-ok status, got %v", err)
+	}
+	if IsNetworkError(result.CommandError("failed")) {
+		t.Error("a command failure is not a network error")
+	}
+}
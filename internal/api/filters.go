@@ -1,14 +1,8 @@
 package api
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-
-	"github.com/google/uuid"
 )
 
 // Filter represents a saved filter in Todoist.
@@ -23,38 +17,10 @@ type Filter struct {
 }
 
 // GetFilters fetches all filters via the Sync API.
-func (c *Client) GetFilters() ([]Filter, error) {
-	syncURL := c.baseURL + "/sync"
-
-	formData := url.Values{}
-	formData.Set("sync_token", "*")
-	formData.Set("resource_types", "[\"filters\"]")
-
-	req, err := http.NewRequest("POST", syncURL, bytes.NewBufferString(formData.Encode()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create sync request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.httpClient.Do(req)
+func (c *Client) GetFilters(ctx context.Context) ([]Filter, error) {
+	result, err := c.ReadResources(ctx, "*", "filters")
 	if err != nil {
-		return nil, fmt.Errorf("sync request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("sync API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	var result struct {
-		Filters []Filter `json:"filters"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode sync response: %w", err)
+		return nil, err
 	}
 
 	filtered := []Filter{}
@@ -68,12 +34,7 @@ func (c *Client) GetFilters() ([]Filter, error) {
 }
 
 // CreateFilter creates a new filter via Sync API.
-func (c *Client) CreateFilter(name, query, color string) (*Filter, error) {
-	syncURL := c.baseURL + "/sync"
-
-	tempID := uuid.New().String()
-	cmdUUID := uuid.New().String()
-
+func (c *Client) CreateFilter(ctx context.Context, name, query, color string) (*Filter, error) {
 	args := map[string]string{
 		"name":  name,
 		"query": query,
@@ -82,57 +43,18 @@ func (c *Client) CreateFilter(name, query, color string) (*Filter, error) {
 		args["color"] = color
 	}
 
-	command := map[string]interface{}{
-		"type":    "filter_add",
-		"temp_id": tempID,
-		"uuid":    cmdUUID,
-		"args":    args,
-	}
-
-	commands, err := json.Marshal([]interface{}{command})
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal sync commands: %w", err)
-	}
-
-	formData := url.Values{}
-	formData.Set("commands", string(commands))
-
-	req, err := http.NewRequest("POST", syncURL, bytes.NewBufferString(formData.Encode()))
+	cmd := NewSyncCommandWithTempID("filter_add", args)
+	result, err := c.ExecuteCommands(ctx, []SyncCommand{cmd})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create sync request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("sync request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("sync API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Parse response to get the real ID
-	var result struct {
-		TempIDMapping map[string]string `json:"temp_id_mapping"`
-		SyncStatus    map[string]string `json:"sync_status"`
-	}
-
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to decode sync response: %w", err)
+		return nil, err
 	}
 
 	// Check if command succeeded
-	if status, ok := result.SyncStatus[cmdUUID]; ok && status != "ok" {
-		return nil, fmt.Errorf("filter creation failed: %s", status)
+	if err := result.CommandError(cmd.UUID); err != nil {
+		return nil, fmt.Errorf("filter creation failed: %w", err)
 	}
 
-	realID := result.TempIDMapping[tempID]
+	realID := result.TempIDMapping[cmd.TempID]
 	return &Filter{
 		ID:    realID,
 		Name:  name,
@@ -141,56 +63,20 @@ func (c *Client) CreateFilter(name, query, color string) (*Filter, error) {
 }
 
 // DeleteFilter deletes a filter via Sync API.
-func (c *Client) DeleteFilter(id string) error {
-	syncURL := c.baseURL + "/sync"
-
-	cmdUUID := uuid.New().String()
-
-	command := map[string]interface{}{
-		"type": "filter_delete",
-		"uuid": cmdUUID,
-		"args": map[string]string{
-			"id": id,
-		},
-	}
-
-	commands, err := json.Marshal([]interface{}{command})
-	if err != nil {
-		return fmt.Errorf("failed to marshal sync commands: %w", err)
-	}
-
-	formData := url.Values{}
-	formData.Set("commands", string(commands))
+func (c *Client) DeleteFilter(ctx context.Context, id string) error {
+	cmd := NewSyncCommand("filter_delete", map[string]string{
+		"id": id,
+	})
 
-	req, err := http.NewRequest("POST", syncURL, bytes.NewBufferString(formData.Encode()))
+	result, err := c.ExecuteCommands(ctx, []SyncCommand{cmd})
 	if err != nil {
-		return fmt.Errorf("failed to create sync request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("sync request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("sync API error %d: %s", resp.StatusCode, string(body))
+		return err
 	}
-
-	return nil
+	return result.CommandError(cmd.UUID)
 }
 
 // UpdateFilter updates a filter via Sync API.
-func (c *Client) UpdateFilter(id, name, query string) (*Filter, error) {
-	syncURL := c.baseURL + "/sync"
-
-	cmdUUID := uuid.New().String()
-
+func (c *Client) UpdateFilter(ctx context.Context, id, name, query string) (*Filter, error) {
 	args := map[string]string{"id": id}
 	if name != "" {
 		args["name"] = name
@@ -199,38 +85,13 @@ func (c *Client) UpdateFilter(id, name, query string) (*Filter, error) {
 		args["query"] = query
 	}
 
-	command := map[string]interface{}{
-		"type": "filter_update",
-		"uuid": cmdUUID,
-		"args": args,
-	}
-
-	commands, err := json.Marshal([]interface{}{command})
+	cmd := NewSyncCommand("filter_update", args)
+	result, err := c.ExecuteCommands(ctx, []SyncCommand{cmd})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal sync commands: %w", err)
+		return nil, err
 	}
-
-	formData := url.Values{}
-	formData.Set("commands", string(commands))
-
-	req, err := http.NewRequest("POST", syncURL, bytes.NewBufferString(formData.Encode()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create sync request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("sync request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("sync API error %d: %s", resp.StatusCode, string(body))
+	if err := result.CommandError(cmd.UUID); err != nil {
+		return nil, err
 	}
 
 	return &Filter{
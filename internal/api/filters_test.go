@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -48,7 +49,7 @@ func TestGetFilters(t *testing.T) {
 			client := NewClient("test-token")
 			client.baseURL = server.URL
 
-			filters, err := client.GetFilters()
+			filters, err := client.GetFilters(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetFilters() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -81,7 +82,7 @@ func TestCreateFilter(t *testing.T) {
 	client.baseURL = server.URL
 
 	// We expect the ID to be empty because our mock doesn't return the mapping for the random tempID.
-	filter, err := client.CreateFilter(name, query, color)
+	filter, err := client.CreateFilter(context.Background(), name, query, color)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -107,7 +108,7 @@ func TestDeleteFilter(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	err := client.DeleteFilter(id)
+	err := client.DeleteFilter(context.Background(), id)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
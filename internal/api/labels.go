@@ -1,63 +1,46 @@
 package api
 
 import (
+	"context"
 	"fmt"
-	"net/url"
 )
 
 // GetLabels returns all personal labels.
 // Handles v1 API pagination automatically, fetching all pages.
-func (c *Client) GetLabels() ([]Label, error) {
-	var allLabels []Label
-	query := url.Values{}
-
-	for {
-		var response PaginatedResponse[Label]
-		if err := c.GetWithQuery("/labels", query, &response); err != nil {
-			return nil, fmt.Errorf("failed to get labels: %w", err)
-		}
-
-		allLabels = append(allLabels, response.Results...)
-
-		if response.NextCursor == nil || *response.NextCursor == "" {
-			break
-		}
-		query.Set("cursor", *response.NextCursor)
-	}
-
-	return allLabels, nil
+func (c *Client) GetLabels(ctx context.Context) ([]Label, error) {
+	return collect(c.Labels(ctx), "labels")
 }
 
 // GetLabel returns a single label by ID.
-func (c *Client) GetLabel(id string) (*Label, error) {
+func (c *Client) GetLabel(ctx context.Context, id string) (*Label, error) {
 	var label Label
-	if err := c.Get("/labels/"+id, &label); err != nil {
+	if err := c.Get(ctx, "/labels/"+id, &label); err != nil {
 		return nil, fmt.Errorf("failed to get label %s: %w", id, err)
 	}
 	return &label, nil
 }
 
 // CreateLabel creates a new personal label.
-func (c *Client) CreateLabel(req CreateLabelRequest) (*Label, error) {
+func (c *Client) CreateLabel(ctx context.Context, req CreateLabelRequest) (*Label, error) {
 	var label Label
-	if err := c.Post("/labels", req, &label); err != nil {
+	if err := c.Post(ctx, "/labels", req, &label); err != nil {
 		return nil, fmt.Errorf("failed to create label: %w", err)
 	}
 	return &label, nil
 }
 
 // UpdateLabel updates an existing label.
-func (c *Client) UpdateLabel(id string, req UpdateLabelRequest) (*Label, error) {
+func (c *Client) UpdateLabel(ctx context.Context, id string, req UpdateLabelRequest) (*Label, error) {
 	var label Label
-	if err := c.Post("/labels/"+id, req, &label); err != nil {
+	if err := c.Post(ctx, "/labels/"+id, req, &label); err != nil {
 		return nil, fmt.Errorf("failed to update label %s: %w", id, err)
 	}
 	return &label, nil
 }
 
 // DeleteLabel deletes a label.
-func (c *Client) DeleteLabel(id string) error {
-	if err := c.Delete("/labels/" + id); err != nil {
+func (c *Client) DeleteLabel(ctx context.Context, id string) error {
+	if err := c.Delete(ctx, "/labels/"+id); err != nil {
 		return fmt.Errorf("failed to delete label %s: %w", id, err)
 	}
 	return nil
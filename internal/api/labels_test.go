@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -49,7 +50,7 @@ func TestGetLabels(t *testing.T) {
 			client := NewClient("test-token")
 			client.baseURL = server.URL
 
-			labels, err := client.GetLabels()
+			labels, err := client.GetLabels(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetLabels() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -76,7 +77,7 @@ func TestCreateLabel(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	label, err := client.CreateLabel(CreateLabelRequest{Name: name})
+	label, err := client.CreateLabel(context.Background(), CreateLabelRequest{Name: name})
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -99,7 +100,7 @@ func TestDeleteLabel(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	err := client.DeleteLabel(id)
+	err := client.DeleteLabel(context.Background(), id)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Network holds the connection settings of a Client. Zero values keep the
+// defaults.
+type Network struct {
+	// BaseURL replaces the Todoist API base URL, e.g. for an API gateway.
+	BaseURL string
+	// Proxy is the URL of an HTTP(S) or SOCKS5 proxy. Without it the
+	// HTTPS_PROXY and NO_PROXY environment variables apply.
+	Proxy string
+	// CAFile is a PEM bundle of certificates trusted in addition to the
+	// system roots, e.g. for a TLS-intercepting corporate proxy.
+	CAFile string
+	// Timeout limits each HTTP request (default DefaultTimeout).
+	Timeout time.Duration
+	// MaxRetries and RetryBaseDelay override the Client fields of the same name.
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+}
+
+// NewTransport returns a pooled HTTP transport using the proxy and CA bundle
+// of n.
+func NewTransport(n Network) (*http.Transport, error) {
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		MaxIdleConns:        20,               // Total max idle connections
+		MaxIdleConnsPerHost: 10,               // Max idle connections per host
+		MaxConnsPerHost:     20,               // Max total connections per host
+		IdleConnTimeout:     90 * time.Second, // How long idle connections stay in pool
+		DisableKeepAlives:   false,            // Enable keep-alive for connection reuse
+	}
+
+	if n.Proxy != "" {
+		proxyURL, err := url.Parse(n.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", n.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if n.CAFile != "" {
+		pem, err := os.ReadFile(n.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", n.CAFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return transport, nil
+}
+
+// NewHTTPClient returns an HTTP client with the transport and timeout of n,
+// for requests made outside a Client such as the OAuth token exchange.
+func NewHTTPClient(n Network) (*http.Client, error) {
+	transport, err := NewTransport(n)
+	if err != nil {
+		return nil, err
+	}
+	timeout := n.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
+
+// NewClientWithNetwork creates a Client like NewClient, with the connection
+// settings of n.
+func NewClientWithNetwork(accessToken string, n Network) (*Client, error) {
+	httpClient, err := NewHTTPClient(n)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewClient(accessToken)
+	c.httpClient = httpClient
+	if n.BaseURL != "" {
+		c.SetBaseURL(n.BaseURL)
+	}
+	if n.MaxRetries > 0 {
+		c.MaxRetries = n.MaxRetries
+	}
+	if n.RetryBaseDelay > 0 {
+		c.RetryBaseDelay = n.RetryBaseDelay
+	}
+	return c, nil
+}
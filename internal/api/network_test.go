@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClientWithNetwork(t *testing.T) {
+	client, err := NewClientWithNetwork("token", Network{
+		BaseURL:        "http://localhost:8080/",
+		Timeout:        5 * time.Second,
+		MaxRetries:     7,
+		RetryBaseDelay: time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithNetwork() error = %v", err)
+	}
+	if client.baseURL != "http://localhost:8080" {
+		t.Errorf("baseURL = %q", client.baseURL)
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("timeout = %v", client.httpClient.Timeout)
+	}
+	if client.MaxRetries != 7 || client.RetryBaseDelay != time.Second {
+		t.Errorf("retries = %d, %v", client.MaxRetries, client.RetryBaseDelay)
+	}
+
+	defaults, err := NewClientWithNetwork("token", Network{})
+	if err != nil {
+		t.Fatalf("NewClientWithNetwork() error = %v", err)
+	}
+	if defaults.baseURL != BaseURL || defaults.httpClient.Timeout != DefaultTimeout || defaults.MaxRetries != 3 {
+		t.Errorf("zero Network changed the defaults: %+v", defaults)
+	}
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte(`{"results": []}`))
+	}))
+	defer proxy.Close()
+
+	client, err := NewClientWithNetwork("token", Network{BaseURL: "http://todoist.invalid/api/v1", Proxy: proxy.URL})
+	if err != nil {
+		t.Fatalf("NewClientWithNetwork() error = %v", err)
+	}
+	if _, err := client.GetProjects(context.Background()); err != nil {
+		t.Fatalf("GetProjects() error = %v", err)
+	}
+	if proxied != "http://todoist.invalid/api/v1/projects" {
+		t.Errorf("proxy received %q", proxied)
+	}
+
+	if _, err := NewTransport(Network{Proxy: "not a url"}); err == nil {
+		t.Error("expected an error for an invalid proxy URL")
+	}
+}
+
+func TestNewTransport_CAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, cert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	untrusted, _ := NewClientWithNetwork("token", Network{BaseURL: server.URL, MaxRetries: 1, RetryBaseDelay: time.Millisecond})
+	if _, err := untrusted.GetProjects(context.Background()); err == nil {
+		t.Error("expected a certificate error without the CA file")
+	}
+
+	trusted, err := NewClientWithNetwork("token", Network{BaseURL: server.URL, CAFile: caFile})
+	if err != nil {
+		t.Fatalf("NewClientWithNetwork() error = %v", err)
+	}
+	if _, err := trusted.GetProjects(context.Background()); err != nil {
+		t.Errorf("GetProjects() error = %v", err)
+	}
+
+	empty := filepath.Join(dir, "empty.pem")
+	os.WriteFile(empty, []byte("no certificates here"), 0o600)
+	if _, err := NewTransport(Network{CAFile: empty}); err == nil || !strings.Contains(err.Error(), "no certificates") {
+		t.Errorf("expected an error for a file without certificates, got %v", err)
+	}
+}
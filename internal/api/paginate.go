@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// MaxPageSize is the largest page the list endpoints return.
+const MaxPageSize = 200
+
+// PageOption configures a paginated request.
+type PageOption func(*pageConfig)
+
+type pageConfig struct {
+	limit int
+}
+
+// WithPageSize sets how many results each request returns, up to
+// MaxPageSize. Without it the server default is used.
+func WithPageSize(n int) PageOption {
+	return func(cfg *pageConfig) {
+		cfg.limit = min(n, MaxPageSize)
+	}
+}
+
+// Pages iterates over the pages of a cursor-paginated list endpoint,
+// requesting each page only when the previous one has been consumed. query
+// is not modified. After an error, iteration stops.
+func Pages[T any](ctx context.Context, c *Client, path string, query url.Values, opts ...PageOption) iter.Seq2[[]T, error] {
+	var cfg pageConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(yield func([]T, error) bool) {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = append([]string(nil), v...)
+		}
+		if cfg.limit > 0 {
+			q.Set("limit", strconv.Itoa(cfg.limit))
+		}
+
+		for {
+			var response PaginatedResponse[T]
+			if err := c.GetWithQuery(ctx, path, q, &response); err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(response.Results, nil) {
+				return
+			}
+			if response.NextCursor == nil || *response.NextCursor == "" {
+				return
+			}
+			q.Set("cursor", *response.NextCursor)
+		}
+	}
+}
+
+// Paginate iterates over the results of a cursor-paginated list endpoint
+// one at a time. See Pages.
+func Paginate[T any](ctx context.Context, c *Client, path string, query url.Values, opts ...PageOption) iter.Seq2[T, error] {
+	return flatten(Pages[T](ctx, c, path, query, opts...))
+}
+
+// collect reads every result of seq. Errors are wrapped with what, e.g.
+// "failed to get tasks: ...".
+func collect[T any](seq iter.Seq2[T, error], what string) ([]T, error) {
+	var all []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", what, err)
+		}
+		all = append(all, item)
+	}
+	return all, nil
+}
+
+// TaskPages iterates over the pages of active tasks matching filter.
+func (c *Client) TaskPages(ctx context.Context, filter TaskFilter, opts ...PageOption) iter.Seq2[[]Task, error] {
+	return Pages[Task](ctx, c, "/tasks", buildFilterQuery(filter), opts...)
+}
+
+// Tasks iterates over the active tasks matching filter.
+func (c *Client) Tasks(ctx context.Context, filter TaskFilter, opts ...PageOption) iter.Seq2[Task, error] {
+	return flatten(c.TaskPages(ctx, filter, opts...))
+}
+
+// FilterTaskPages iterates over the pages of tasks matching a Todoist
+// filter query.
+func (c *Client) FilterTaskPages(ctx context.Context, filterQuery string, opts ...PageOption) iter.Seq2[[]Task, error] {
+	query := url.Values{}
+	query.Set("query", filterQuery)
+	return Pages[Task](ctx, c, "/tasks/filter", query, opts...)
+}
+
+// FilterTasks iterates over the tasks matching a Todoist filter query.
+func (c *Client) FilterTasks(ctx context.Context, filterQuery string, opts ...PageOption) iter.Seq2[Task, error] {
+	return flatten(c.FilterTaskPages(ctx, filterQuery, opts...))
+}
+
+// Projects iterates over all projects.
+func (c *Client) Projects(ctx context.Context, opts ...PageOption) iter.Seq2[Project, error] {
+	return Paginate[Project](ctx, c, "/projects", nil, opts...)
+}
+
+// Sections iterates over all sections, or those of one project.
+func (c *Client) Sections(ctx context.Context, projectID string, opts ...PageOption) iter.Seq2[Section, error] {
+	query := url.Values{}
+	if projectID != "" {
+		query.Set("project_id", projectID)
+	}
+	return Paginate[Section](ctx, c, "/sections", query, opts...)
+}
+
+// Labels iterates over all personal labels.
+func (c *Client) Labels(ctx context.Context, opts ...PageOption) iter.Seq2[Label, error] {
+	return Paginate[Label](ctx, c, "/labels", nil, opts...)
+}
+
+// Comments iterates over the comments of a task or, if taskID is empty, a
+// project.
+func (c *Client) Comments(ctx context.Context, taskID, projectID string, opts ...PageOption) iter.Seq2[Comment, error] {
+	query := url.Values{}
+	if taskID != "" {
+		query.Set("task_id", taskID)
+	} else if projectID != "" {
+		query.Set("project_id", projectID)
+	}
+	return Paginate[Comment](ctx, c, "/comments", query, opts...)
+}
+
+// flatten turns an iterator over pages into one over results.
+func flatten[T any](pages iter.Seq2[[]T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page, err := range pages {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
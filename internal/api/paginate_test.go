@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// pagedServer serves three pages of projects and counts the requests.
+func pagedServer(t *testing.T, requests *int, limits *[]string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		*limits = append(*limits, r.URL.Query().Get("limit"))
+
+		cursors := map[string]string{"": "c2", "c2": "c3", "c3": ""}
+		cursor := r.URL.Query().Get("cursor")
+		next, ok := cursors[cursor]
+		if !ok {
+			t.Errorf("unexpected cursor %q", cursor)
+		}
+		resp := PaginatedResponse[Project]{Results: []Project{{ID: "after-" + cursor}}}
+		if next != "" {
+			resp.NextCursor = &next
+		}
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func TestPages(t *testing.T) {
+	var requests int
+	var limits []string
+	server := mockServer(pagedServer(t, &requests, &limits))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	var ids []string
+	for page, err := range Pages[Project](context.Background(), client, "/projects", nil, WithPageSize(500)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, p := range page {
+			ids = append(ids, p.ID)
+		}
+	}
+	if len(ids) != 3 || requests != 3 {
+		t.Errorf("expected 3 pages in 3 requests, got %v in %d", ids, requests)
+	}
+	for _, limit := range limits {
+		if limit != "200" {
+			t.Errorf("expected the page size to be capped at 200, got %q", limit)
+		}
+	}
+}
+
+func TestPaginate_StopsEarly(t *testing.T) {
+	var requests int
+	var limits []string
+	server := mockServer(pagedServer(t, &requests, &limits))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	for p, err := range client.Projects(context.Background()) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if p.ID != "after-" {
+			t.Errorf("unexpected first project %q", p.ID)
+		}
+		break
+	}
+	if requests != 1 {
+		t.Errorf("expected one request, got %d", requests)
+	}
+	if limits[0] != "" {
+		t.Errorf("expected no limit without WithPageSize, got %q", limits[0])
+	}
+}
+
+func TestPaginate_Error(t *testing.T) {
+	server := mockServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	var errs int
+	for _, err := range client.Labels(context.Background()) {
+		if err != nil {
+			errs++
+		}
+	}
+	if errs != 1 {
+		t.Errorf("expected exactly one error, got %d", errs)
+	}
+	if _, err := client.GetLabels(context.Background()); err == nil {
+		t.Error("expected GetLabels to fail")
+	}
+}
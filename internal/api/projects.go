@@ -1,73 +1,97 @@
 package api
 
 import (
+	"context"
 	"fmt"
-	"net/url"
+	"sync"
 )
 
+// maxConcurrentCollaboratorRequests limits parallel requests in
+// GetCollaboratorsForProjects.
+const maxConcurrentCollaboratorRequests = 5
+
 // GetProjects returns all projects.
 // Handles v1 API pagination automatically, fetching all pages.
-func (c *Client) GetProjects() ([]Project, error) {
-	var allProjects []Project
-	query := url.Values{}
-
-	for {
-		var response PaginatedResponse[Project]
-		if err := c.GetWithQuery("/projects", query, &response); err != nil {
-			return nil, fmt.Errorf("failed to get projects: %w", err)
-		}
-
-		allProjects = append(allProjects, response.Results...)
-
-		if response.NextCursor == nil || *response.NextCursor == "" {
-			break
-		}
-		query.Set("cursor", *response.NextCursor)
-	}
-
-	return allProjects, nil
+func (c *Client) GetProjects(ctx context.Context) ([]Project, error) {
+	return collect(c.Projects(ctx), "projects")
 }
 
 // GetProject returns a single project by ID.
-func (c *Client) GetProject(id string) (*Project, error) {
+func (c *Client) GetProject(ctx context.Context, id string) (*Project, error) {
 	var project Project
-	if err := c.Get("/projects/"+id, &project); err != nil {
+	if err := c.Get(ctx, "/projects/"+id, &project); err != nil {
 		return nil, fmt.Errorf("failed to get project %s: %w", id, err)
 	}
 	return &project, nil
 }
 
 // CreateProject creates a new project.
-func (c *Client) CreateProject(req CreateProjectRequest) (*Project, error) {
+func (c *Client) CreateProject(ctx context.Context, req CreateProjectRequest) (*Project, error) {
 	var project Project
-	if err := c.Post("/projects", req, &project); err != nil {
+	if err := c.Post(ctx, "/projects", req, &project); err != nil {
 		return nil, fmt.Errorf("failed to create project: %w", err)
 	}
 	return &project, nil
 }
 
 // UpdateProject updates an existing project.
-func (c *Client) UpdateProject(id string, req UpdateProjectRequest) (*Project, error) {
+func (c *Client) UpdateProject(ctx context.Context, id string, req UpdateProjectRequest) (*Project, error) {
 	var project Project
-	if err := c.Post("/projects/"+id, req, &project); err != nil {
+	if err := c.Post(ctx, "/projects/"+id, req, &project); err != nil {
 		return nil, fmt.Errorf("failed to update project %s: %w", id, err)
 	}
 	return &project, nil
 }
 
 // DeleteProject deletes a project.
-func (c *Client) DeleteProject(id string) error {
-	if err := c.Delete("/projects/" + id); err != nil {
+func (c *Client) DeleteProject(ctx context.Context, id string) error {
+	if err := c.Delete(ctx, "/projects/"+id); err != nil {
 		return fmt.Errorf("failed to delete project %s: %w", id, err)
 	}
 	return nil
 }
 
 // GetProjectCollaborators returns all collaborators for a shared project.
-func (c *Client) GetProjectCollaborators(projectID string) ([]Collaborator, error) {
+func (c *Client) GetProjectCollaborators(ctx context.Context, projectID string) ([]Collaborator, error) {
 	var collaborators []Collaborator
-	if err := c.Get("/projects/"+projectID+"/collaborators", &collaborators); err != nil {
+	if err := c.Get(ctx, "/projects/"+projectID+"/collaborators", &collaborators); err != nil {
 		return nil, fmt.Errorf("failed to get collaborators for project %s: %w", projectID, err)
 	}
 	return collaborators, nil
 }
+
+// GetCollaboratorsForProjects fetches the collaborators of several projects
+// in parallel. On error it returns the projects fetched so far along with the
+// first error.
+func (c *Client) GetCollaboratorsForProjects(ctx context.Context, projectIDs []string) (map[string][]Collaborator, error) {
+	var (
+		mu            sync.Mutex
+		wg            sync.WaitGroup
+		firstErr      error
+		collaborators = make(map[string][]Collaborator, len(projectIDs))
+		sem           = make(chan struct{}, maxConcurrentCollaboratorRequests)
+	)
+
+	for _, id := range projectIDs {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			list, err := c.GetProjectCollaborators(ctx, id)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			collaborators[id] = list
+		}(id)
+	}
+	wg.Wait()
+
+	return collaborators, firstErr
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -69,7 +70,7 @@ func TestGetProjects(t *testing.T) {
 			client := NewClient("test-token")
 			client.baseURL = server.URL
 
-			projects, err := client.GetProjects()
+			projects, err := client.GetProjects(context.Background())
 
 			if tt.wantErr {
 				if err == nil {
@@ -120,7 +121,7 @@ func TestGetProject(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	result, err := client.GetProject(projectID)
+	result, err := client.GetProject(context.Background(), projectID)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -197,7 +198,7 @@ func TestCreateProject(t *testing.T) {
 			client := NewClient("test-token")
 			client.baseURL = server.URL
 
-			project, err := client.CreateProject(tt.request)
+			project, err := client.CreateProject(context.Background(), tt.request)
 
 			if tt.wantErr {
 				if err == nil {
@@ -252,7 +253,7 @@ func TestUpdateProject(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	project, err := client.UpdateProject(projectID, UpdateProjectRequest{
+	project, err := client.UpdateProject(context.Background(), projectID, UpdateProjectRequest{
 		Name:       &newName,
 		IsFavorite: &isFavorite,
 	})
@@ -290,7 +291,7 @@ func TestDeleteProject(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	err := client.DeleteProject(projectID)
+	err := client.DeleteProject(context.Background(), projectID)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Todoist's documented quotas. Each user may make RequestQuota requests in
+// any QuotaWindow, of which at most FullSyncQuota may be full syncs.
+const (
+	RequestQuota  = 1000
+	FullSyncQuota = 100
+	QuotaWindow   = 15 * time.Minute
+)
+
+// RateLimiter is a token bucket. Up to burst requests go out back to back;
+// after that they are spaced so that no window of the given length holds
+// more than limit requests.
+type RateLimiter struct {
+	mu       sync.Mutex
+	burst    float64
+	interval time.Duration // Time to earn one token
+	tokens   float64
+	last     time.Time // When tokens was last brought up to date
+	paused   time.Time // No request goes out before this, e.g. after a 429
+
+	now func() time.Time
+}
+
+// NewRateLimiter returns a limiter allowing limit requests per window, of
+// which burst may be sent at once. burst is capped at limit.
+func NewRateLimiter(limit int, window time.Duration, burst int) *RateLimiter {
+	burst = max(1, min(burst, limit))
+	// The bucket starts full, so only limit-burst more tokens may be earned
+	// within the first window
+	refill := max(1, limit-burst)
+	return &RateLimiter{
+		burst:    float64(burst),
+		interval: window / time.Duration(refill),
+		tokens:   float64(burst),
+		now:      time.Now,
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available. Otherwise it returns how long
+// to wait before trying again.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Before(l.paused) {
+		return l.paused.Sub(now)
+	}
+
+	if !l.last.IsZero() {
+		earned := float64(now.Sub(l.last)) / float64(l.interval)
+		l.tokens = min(l.burst, l.tokens+earned)
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) * float64(l.interval))
+}
+
+// Pause holds back all requests for d, e.g. for the Retry-After period of a
+// 429 response. A shorter pause does not cut a longer one short.
+func (l *RateLimiter) Pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := l.now().Add(d); until.After(l.paused) {
+		l.paused = until
+	}
+}
+
+// parseRetryAfter reads the Retry-After header of a response, given either
+// in seconds or as an HTTP date. It returns 0 if the header is missing or
+// invalid.
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(0, time.Duration(seconds)*time.Second)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(0, at.Sub(now))
+	}
+	return 0
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Bucket(t *testing.T) {
+	now := time.Date(2024, 1, 22, 12, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(10, 10*time.Second, 4) // 6 more tokens per 10s
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 4; i++ {
+		if d := l.reserve(); d != 0 {
+			t.Fatalf("request %d of the burst had to wait %v", i+1, d)
+		}
+	}
+	interval := 10 * time.Second / 6
+	if d := l.reserve(); d != interval {
+		t.Errorf("expected to wait %v once the burst is used, got %v", interval, d)
+	}
+
+	now = now.Add(interval)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("expected a token after %v, got a wait of %v", interval, d)
+	}
+
+	// Tokens never pile up beyond the burst
+	now = now.Add(time.Hour)
+	for i := 0; i < 4; i++ {
+		l.reserve()
+	}
+	if d := l.reserve(); d == 0 {
+		t.Error("expected the bucket to hold at most the burst")
+	}
+}
+
+func TestRateLimiter_Pause(t *testing.T) {
+	now := time.Date(2024, 1, 22, 12, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(10, time.Second, 10)
+	l.now = func() time.Time { return now }
+
+	l.Pause(30 * time.Second)
+	l.Pause(5 * time.Second) // Does not shorten the pause
+	if d := l.reserve(); d != 30*time.Second {
+		t.Errorf("expected to wait 30s, got %v", d)
+	}
+	now = now.Add(30 * time.Second)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("expected no wait after the pause, got %v", d)
+	}
+}
+
+func TestRateLimiter_WaitHonorsContext(t *testing.T) {
+	l := NewRateLimiter(1, time.Hour, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 22, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"7", 7 * time.Second},
+		{"-3", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.value != "" {
+			header.Set("Retry-After", tt.value)
+		}
+		if got := parseRetryAfter(header, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	var mu sync.Mutex
+	var attempts []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts = append(attempts, time.Now())
+		if len(attempts) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("test-token")
+	c.baseURL = server.URL
+	c.RetryBaseDelay = time.Millisecond
+
+	if err := c.Get(context.Background(), "/tasks", nil); err != nil {
+		t.Fatalf("expected success after the Retry-After period, got %v", err)
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(attempts))
+	}
+	if gap := attempts[1].Sub(attempts[0]); gap < time.Second {
+		t.Errorf("retried after %v, before the Retry-After period", gap)
+	}
+}
+
+func TestRetry_GivesUpOnLongRetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := NewClient("test-token")
+	c.baseURL = server.URL
+
+	err := c.Get(context.Background(), "/tasks", nil)
+	apiErr, ok := IsAPIError(err)
+	if !ok || !apiErr.IsRateLimited() || apiErr.RetryAfter != time.Hour {
+		t.Fatalf("expected a 429 with a one hour Retry-After, got %v", err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("expected 1 attempt, got %d", n)
+	}
+}
+
+func TestPost_RetriesWithSameRequestID(t *testing.T) {
+	var mu sync.Mutex
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ids = append(ids, r.Header.Get("X-Request-Id"))
+		n := len(ids)
+		mu.Unlock()
+		if n < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"id": "t1"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("test-token")
+	c.baseURL = server.URL
+	c.RetryBaseDelay = time.Millisecond
+
+	task, err := c.CreateTask(context.Background(), CreateTaskRequest{Content: "Buy milk"})
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if task.ID != "t1" {
+		t.Errorf("unexpected task %+v", task)
+	}
+	if len(ids) != 3 || ids[0] == "" || ids[1] != ids[0] || ids[2] != ids[0] {
+		t.Errorf("expected 3 attempts with one request ID, got %q", ids)
+	}
+
+	// A new request gets a new ID
+	c.CreateTask(context.Background(), CreateTaskRequest{Content: "Buy bread"})
+	if ids[3] == ids[0] {
+		t.Error("expected a fresh request ID for a new request")
+	}
+}
+
+func TestClient_LimitsBulkRequests(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("test-token")
+	c.baseURL = server.URL
+	c.Limiter = NewRateLimiter(100, time.Hour, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var err error
+	for i := 0; i < 5 && err == nil; i++ {
+		err = c.CloseTask(ctx, "t1")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the fourth request to wait for the limiter, got %v", err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("expected 3 requests to go out, got %d", n)
+	}
+}
@@ -1,14 +1,8 @@
 package api
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-
-	"github.com/google/uuid"
 )
 
 // Reminder represents a Todoist reminder.
@@ -47,38 +41,10 @@ type UpdateReminderRequest struct {
 }
 
 // GetReminders fetches all reminders via the Sync API.
-func (c *Client) GetReminders() ([]Reminder, error) {
-	syncURL := c.baseURL + "/sync"
-
-	formData := url.Values{}
-	formData.Set("sync_token", "*")
-	formData.Set("resource_types", "[\"reminders\"]")
-
-	req, err := http.NewRequest("POST", syncURL, bytes.NewBufferString(formData.Encode()))
+func (c *Client) GetReminders(ctx context.Context) ([]Reminder, error) {
+	result, err := c.ReadResources(ctx, "*", "reminders")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create sync request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("sync request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("sync API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	var result struct {
-		Reminders []Reminder `json:"reminders"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode sync response: %w", err)
+		return nil, err
 	}
 
 	activeReminders := []Reminder{}
@@ -92,8 +58,8 @@ func (c *Client) GetReminders() ([]Reminder, error) {
 }
 
 // GetRemindersForTask fetches reminders and filters them by task ID.
-func (c *Client) GetRemindersForTask(taskID string) ([]Reminder, error) {
-	allReminders, err := c.GetReminders()
+func (c *Client) GetRemindersForTask(ctx context.Context, taskID string) ([]Reminder, error) {
+	allReminders, err := c.GetReminders(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -109,12 +75,7 @@ func (c *Client) GetRemindersForTask(taskID string) ([]Reminder, error) {
 }
 
 // CreateReminder creates a new reminder via Sync API.
-func (c *Client) CreateReminder(req CreateReminderRequest) (*Reminder, error) {
-	syncURL := c.baseURL + "/sync"
-
-	tempID := uuid.New().String()
-	cmdUUID := uuid.New().String()
-
+func (c *Client) CreateReminder(ctx context.Context, req CreateReminderRequest) (*Reminder, error) {
 	args := map[string]interface{}{
 		"item_id": req.ItemID,
 		"type":    req.Type,
@@ -126,67 +87,18 @@ func (c *Client) CreateReminder(req CreateReminderRequest) (*Reminder, error) {
 		args["due"] = req.Due
 	}
 
-	command := map[string]interface{}{
-		"type":    "reminder_add",
-		"temp_id": tempID,
-		"uuid":    cmdUUID,
-		"args":    args,
-	}
-
-	commands, err := json.Marshal([]interface{}{command})
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal sync commands: %w", err)
-	}
-
-	formData := url.Values{}
-	formData.Set("commands", string(commands))
-
-	httpReq, err := http.NewRequest("POST", syncURL, bytes.NewBufferString(formData.Encode()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create sync request: %w", err)
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+c.accessToken)
-	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.httpClient.Do(httpReq)
+	cmd := NewSyncCommandWithTempID("reminder_add", args)
+	result, err := c.ExecuteCommands(ctx, []SyncCommand{cmd})
 	if err != nil {
-		return nil, fmt.Errorf("sync request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("sync API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Parse sync response
-	var result struct {
-		TempIDMapping map[string]string      `json:"temp_id_mapping"`
-		SyncStatus    map[string]interface{} `json:"sync_status"`
-	}
-
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to decode sync response: %w", err)
+		return nil, err
 	}
 
 	// Check if command succeeded
-	if status, ok := result.SyncStatus[cmdUUID]; ok {
-		if statusStr, ok := status.(string); ok && statusStr != "ok" {
-			return nil, fmt.Errorf("reminder creation failed: %s", statusStr)
-		}
-		// Sometimes status can be an object with error info
-		if _, ok := status.(map[string]interface{}); ok {
-			statusBytes, _ := json.Marshal(status)
-			return nil, fmt.Errorf("reminder creation failed: %s", string(statusBytes))
-		}
-	} else {
-		// If command UUID is missing from sync_status, it might be a silent failure or partial sync
-		// But usually it should be there.
+	if err := result.CommandError(cmd.UUID); err != nil {
+		return nil, fmt.Errorf("reminder creation failed: %w", err)
 	}
 
-	realID := result.TempIDMapping[tempID]
+	realID := result.TempIDMapping[cmd.TempID]
 
 	// Construct returned reminder (approximation since sync doesn't return full object)
 	reminder := &Reminder{
@@ -201,56 +113,20 @@ func (c *Client) CreateReminder(req CreateReminderRequest) (*Reminder, error) {
 }
 
 // DeleteReminder deletes a reminder via Sync API.
-func (c *Client) DeleteReminder(id string) error {
-	syncURL := c.baseURL + "/sync"
-
-	cmdUUID := uuid.New().String()
-
-	command := map[string]interface{}{
-		"type": "reminder_delete",
-		"uuid": cmdUUID,
-		"args": map[string]string{
-			"id": id,
-		},
-	}
-
-	commands, err := json.Marshal([]interface{}{command})
-	if err != nil {
-		return fmt.Errorf("failed to marshal sync commands: %w", err)
-	}
-
-	formData := url.Values{}
-	formData.Set("commands", string(commands))
+func (c *Client) DeleteReminder(ctx context.Context, id string) error {
+	cmd := NewSyncCommand("reminder_delete", map[string]string{
+		"id": id,
+	})
 
-	req, err := http.NewRequest("POST", syncURL, bytes.NewBufferString(formData.Encode()))
+	result, err := c.ExecuteCommands(ctx, []SyncCommand{cmd})
 	if err != nil {
-		return fmt.Errorf("failed to create sync request: %w", err)
+		return err
 	}
-
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("sync request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("sync API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	return nil
+	return result.CommandError(cmd.UUID)
 }
 
 // UpdateReminder updates a reminder via Sync API.
-func (c *Client) UpdateReminder(req UpdateReminderRequest) (*Reminder, error) {
-	syncURL := c.baseURL + "/sync"
-
-	cmdUUID := uuid.New().String()
-
+func (c *Client) UpdateReminder(ctx context.Context, req UpdateReminderRequest) (*Reminder, error) {
 	args := map[string]interface{}{
 		"id": req.ID,
 	}
@@ -261,38 +137,13 @@ func (c *Client) UpdateReminder(req UpdateReminderRequest) (*Reminder, error) {
 		args["due"] = req.Due
 	}
 
-	command := map[string]interface{}{
-		"type": "reminder_update",
-		"uuid": cmdUUID,
-		"args": args,
-	}
-
-	commands, err := json.Marshal([]interface{}{command})
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal sync commands: %w", err)
-	}
-
-	formData := url.Values{}
-	formData.Set("commands", string(commands))
-
-	httpReq, err := http.NewRequest("POST", syncURL, bytes.NewBufferString(formData.Encode()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create sync request: %w", err)
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+c.accessToken)
-	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.httpClient.Do(httpReq)
+	cmd := NewSyncCommand("reminder_update", args)
+	result, err := c.ExecuteCommands(ctx, []SyncCommand{cmd})
 	if err != nil {
-		return nil, fmt.Errorf("sync request failed: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("sync API error %d: %s", resp.StatusCode, string(body))
+	if err := result.CommandError(cmd.UUID); err != nil {
+		return nil, err
 	}
 
 	return &Reminder{
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -37,7 +38,7 @@ func TestGetReminders(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	reminders, err := client.GetReminders()
+	reminders, err := client.GetReminders(context.Background())
 	if err != nil {
 		t.Errorf("GetReminders returned error: %v", err)
 	}
@@ -68,7 +69,7 @@ func TestGetRemindersForTask(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	reminders, err := client.GetRemindersForTask("task1")
+	reminders, err := client.GetRemindersForTask(context.Background(), "task1")
 	if err != nil {
 		t.Errorf("GetRemindersForTask returned error: %v", err)
 	}
@@ -137,7 +138,7 @@ func TestCreateReminder(t *testing.T) {
 		MinuteOffset: 30,
 	}
 
-	reminder, err := client.CreateReminder(req)
+	reminder, err := client.CreateReminder(context.Background(), req)
 	if err != nil {
 		t.Errorf("CreateReminder returned error: %v", err)
 	}
@@ -163,7 +164,7 @@ func TestDeleteReminder(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	err := client.DeleteReminder("rem123")
+	err := client.DeleteReminder(context.Background(), "rem123")
 	if err != nil {
 		t.Errorf("DeleteReminder returned error: %v", err)
 	}
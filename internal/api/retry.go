@@ -1,10 +1,19 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"math"
+	"net/http"
 	"time"
+
+	"github.com/google/uuid"
 )
 
+// maxRetryAfter is the longest Retry-After period a request waits out.
+// Beyond it the 429 is returned, rather than leaving the caller hanging.
+const maxRetryAfter = time.Minute
+
 // doWithRetry performs an HTTP request with exponential backoff retry logic.
 //
 // Retries are performed for:
@@ -15,8 +24,26 @@ import (
 // Client errors (4xx except 429) are NOT retried — they are application bugs
 // that won't resolve on their own.
 //
+// POST requests carry an X-Request-Id that stays the same across attempts,
+// so the server ignores a retry of a request it has already applied.
+func (c *Client) doWithRetry(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	var requestID string
+	if method == http.MethodPost {
+		requestID = uuid.NewString()
+	}
+	return c.retry(ctx, []*RateLimiter{c.Limiter}, func() error {
+		return c.do(ctx, method, path, body, result, requestID)
+	})
+}
+
+// retry calls attempt until it succeeds or fails with an error that is not
+// transient, waiting for each of limiters before every call.
+//
 // Backoff schedule: 500ms → 1s → 2s (doubles each attempt, capped for UX).
-func (c *Client) doWithRetry(method, path string, body interface{}, result interface{}) error {
+// A 429 response waits for its Retry-After period instead when that is
+// longer, and holds back other requests through Limiter for as long.
+// Waiting stops as soon as ctx is done.
+func (c *Client) retry(ctx context.Context, limiters []*RateLimiter, attempt func() error) error {
 	maxRetries := c.MaxRetries
 	if maxRetries <= 0 {
 		maxRetries = 3
@@ -27,14 +54,28 @@ func (c *Client) doWithRetry(method, path string, body interface{}, result inter
 	}
 
 	var lastErr error
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		if attempt > 0 {
+	for i := 0; i <= maxRetries; i++ {
+		if i > 0 {
 			// Exponential backoff: 500ms, 1s, 2s, ...
-			delay := time.Duration(float64(baseDelay) * math.Pow(2, float64(attempt-1)))
-			time.Sleep(delay)
+			delay := time.Duration(float64(baseDelay) * math.Pow(2, float64(i-1)))
+			if apiErr, ok := IsAPIError(lastErr); ok {
+				delay = max(delay, apiErr.RetryAfter)
+			}
+			if err := sleep(ctx, delay); err != nil {
+				return err
+			}
+		}
+
+		for _, l := range limiters {
+			if l == nil {
+				continue
+			}
+			if err := l.Wait(ctx); err != nil {
+				return err
+			}
 		}
 
-		err := c.do(method, path, body, result)
+		err := attempt()
 		if err == nil {
 			return nil
 		}
@@ -45,11 +86,32 @@ func (c *Client) doWithRetry(method, path string, body interface{}, result inter
 		if !isRetryable(err) {
 			return err
 		}
+
+		if apiErr, ok := IsAPIError(err); ok && apiErr.RetryAfter > 0 {
+			if apiErr.RetryAfter > maxRetryAfter {
+				return err
+			}
+			if c.Limiter != nil {
+				c.Limiter.Pause(apiErr.RetryAfter)
+			}
+		}
 	}
 
 	return lastErr
 }
 
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // isRetryable returns true if the error warrants a retry.
 // Network errors are always retryable; API errors retry only on 429 and 5xx.
 func isRetryable(err error) bool {
@@ -57,6 +119,11 @@ func isRetryable(err error) bool {
 		return false
 	}
 
+	// A cancelled or expired context will not get better
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
 	// Check for API errors (HTTP status codes)
 	apiErr, ok := IsAPIError(err)
 	if !ok {
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -53,7 +55,7 @@ func TestRetry_SucceedsAfterTransient429(t *testing.T) {
 	c := newTestClient(transport)
 
 	// GET should retry and succeed on the 2nd attempt
-	err := c.Get("/tasks", nil)
+	err := c.Get(context.Background(), "/tasks", nil)
 	if err != nil {
 		t.Fatalf("expected success after retry, got error: %v", err)
 	}
@@ -72,7 +74,7 @@ func TestRetry_SucceedsAfterTransient500(t *testing.T) {
 	}
 	c := newTestClient(transport)
 
-	err := c.Get("/tasks", nil)
+	err := c.Get(context.Background(), "/tasks", nil)
 	if err != nil {
 		t.Fatalf("expected success after 2 retries, got error: %v", err)
 	}
@@ -92,7 +94,7 @@ func TestRetry_NoRetryOn4xx(t *testing.T) {
 			}
 			c := newTestClient(transport)
 
-			err := c.Get("/tasks", nil)
+			err := c.Get(context.Background(), "/tasks", nil)
 			if err == nil {
 				t.Fatalf("expected error for %d, got nil", code)
 			}
@@ -113,7 +115,7 @@ func TestRetry_ExhaustsMaxRetries(t *testing.T) {
 	transport := &mockTransport{responses: responses}
 	c := newTestClient(transport)
 
-	err := c.Get("/tasks", nil)
+	err := c.Get(context.Background(), "/tasks", nil)
 	if err == nil {
 		t.Fatal("expected error after exhausting retries, got nil")
 	}
@@ -137,7 +139,7 @@ func TestRetry_NetworkErrorIsRetried(t *testing.T) {
 	}
 	c := newTestClient(transport)
 
-	err := c.Get("/tasks", nil)
+	err := c.Get(context.Background(), "/tasks", nil)
 	if err != nil {
 		t.Fatalf("expected success after network error retry, got: %v", err)
 	}
@@ -146,6 +148,32 @@ func TestRetry_NetworkErrorIsRetried(t *testing.T) {
 	}
 }
 
+func TestRetry_StopsWhenContextDone(t *testing.T) {
+	transport := &mockTransport{
+		responses: []mockResponse{
+			{statusCode: 500, body: "server error"},
+			{statusCode: 200, body: ""},
+		},
+	}
+	c := newTestClient(transport)
+	c.RetryBaseDelay = time.Hour // Only the context can end the wait
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := c.Get(ctx, "/tasks", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("backoff was not interrupted, took %v", elapsed)
+	}
+	if transport.index != 1 {
+		t.Errorf("expected 1 HTTP attempt, got %d", transport.index)
+	}
+}
+
 func TestIsRetryable(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -161,6 +189,8 @@ func TestIsRetryable(t *testing.T) {
 		{"500 Server Error", &APIError{StatusCode: 500}, true},
 		{"503 Service Unavailable", &APIError{StatusCode: 503}, true},
 		{"network error", fmt.Errorf("connect: connection refused"), true},
+		{"cancelled", fmt.Errorf("request: %w", context.Canceled), false},
+		{"deadline exceeded", context.DeadlineExceeded, false},
 	}
 
 	for _, tc := range cases {
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -69,7 +71,7 @@ func TestGetSections(t *testing.T) {
 			client := NewClient("test-token")
 			client.baseURL = server.URL
 
-			sections, err := client.GetSections(tt.projectID)
+			sections, err := client.GetSections(context.Background(), tt.projectID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetSections() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -107,8 +109,31 @@ func TestReorderSections(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	err := client.ReorderSections(sections)
+	err := client.ReorderSections(context.Background(), sections)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+func TestReorderSections_ReportsCommandFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		var commands []SyncCommand
+		json.Unmarshal([]byte(r.FormValue("commands")), &commands)
+		status := map[string]interface{}{}
+		for _, cmd := range commands {
+			status[cmd.UUID] = map[string]interface{}{"error": "Section not found", "error_code": 20, "http_code": 404}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"sync_status": status})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	err := client.ReorderSections(context.Background(), []Section{{ID: "1", SectionOrder: 1}})
+	var syncErr *SyncError
+	if !errors.As(err, &syncErr) || !syncErr.IsNotFound() {
+		t.Errorf("expected a not found *SyncError, got %v", err)
+	}
+}
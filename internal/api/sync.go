@@ -0,0 +1,507 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// SyncResourceTypes lists the resources tracked by SyncClient.
+var SyncResourceTypes = []string{"items", "projects", "sections", "labels", "filters", "reminders", "user"}
+
+// MaxCommandsPerSync is the Sync API limit on commands in a single request.
+const MaxCommandsPerSync = 100
+
+// SyncCommand is a single write command sent to the Sync API.
+type SyncCommand struct {
+	Type   string      `json:"type"`
+	UUID   string      `json:"uuid"`
+	TempID string      `json:"temp_id,omitempty"`
+	Args   interface{} `json:"args"`
+}
+
+// NewSyncCommand creates a command with a fresh UUID.
+func NewSyncCommand(cmdType string, args interface{}) SyncCommand {
+	return SyncCommand{
+		Type: cmdType,
+		UUID: uuid.New().String(),
+		Args: args,
+	}
+}
+
+// NewSyncCommandWithTempID creates a command that creates a resource.
+// The temp ID can be referenced by later commands in the same batch.
+func NewSyncCommandWithTempID(cmdType string, args interface{}) SyncCommand {
+	cmd := NewSyncCommand(cmdType, args)
+	cmd.TempID = uuid.New().String()
+	return cmd
+}
+
+// RenewCommands returns copies of cmds with fresh UUIDs and temp IDs, so a
+// previously sent batch can be sent again without being deduplicated.
+// References to the old temp IDs inside the batch are updated. The returned
+// map links each old temp ID to its replacement.
+func RenewCommands(cmds []SyncCommand) ([]SyncCommand, map[string]string) {
+	tempIDs := make(map[string]string)
+	for _, cmd := range cmds {
+		if cmd.TempID != "" {
+			tempIDs[cmd.TempID] = uuid.New().String()
+		}
+	}
+
+	renewed := make([]SyncCommand, len(cmds))
+	for i, cmd := range cmds {
+		cmd = ReplaceCommandIDs(cmd, tempIDs)
+		cmd.UUID = uuid.New().String()
+		if cmd.TempID != "" {
+			cmd.TempID = tempIDs[cmd.TempID]
+		}
+		renewed[i] = cmd
+	}
+	return renewed, tempIDs
+}
+
+// ReplaceCommandIDs returns cmd with every ID in its arguments found in
+// mapping replaced, e.g. to swap temp IDs for the real IDs they resolved to.
+func ReplaceCommandIDs(cmd SyncCommand, mapping map[string]string) SyncCommand {
+	if len(mapping) == 0 {
+		return cmd
+	}
+	cmd.Args = replaceIDs(cmd.Args, mapping)
+	return cmd
+}
+
+// replaceIDs walks decoded JSON values and swaps any string found in mapping.
+func replaceIDs(v interface{}, mapping map[string]string) interface{} {
+	switch val := v.(type) {
+	case string:
+		if newID, ok := mapping[val]; ok {
+			return newID
+		}
+		return val
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[k] = replaceIDs(item, mapping)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = replaceIDs(item, mapping)
+		}
+		return out
+	case []string:
+		out := make([]string, len(val))
+		for i, item := range val {
+			out[i] = replaceIDs(item, mapping).(string)
+		}
+		return out
+	default:
+		return val
+	}
+}
+
+// SyncResponse is the raw response of the Sync endpoint.
+type SyncResponse struct {
+	SyncToken     string                     `json:"sync_token"`
+	FullSync      bool                       `json:"full_sync"`
+	Items         []Task                     `json:"items"`
+	Projects      []Project                  `json:"projects"`
+	Sections      []Section                  `json:"sections"`
+	Labels        []Label                    `json:"labels"`
+	Filters       []Filter                   `json:"filters"`
+	Reminders     []Reminder                 `json:"reminders"`
+	User          *User                      `json:"user"`
+	TempIDMapping map[string]string          `json:"temp_id_mapping"`
+	SyncStatus    map[string]json.RawMessage `json:"sync_status"`
+}
+
+// CommandError returns the failure reported for the command with the given
+// UUID as a *SyncError. Returns nil if the command succeeded or is not part
+// of the response.
+func (r *SyncResponse) CommandError(cmdUUID string) error {
+	raw, ok := r.SyncStatus[cmdUUID]
+	if !ok {
+		return nil
+	}
+
+	var status string
+	if err := json.Unmarshal(raw, &status); err == nil && status == "ok" {
+		return nil
+	}
+	return newSyncError(cmdUUID, raw)
+}
+
+// ReadResources fetches the given resource types from the Sync API.
+// An empty sync token performs a full sync.
+func (c *Client) ReadResources(ctx context.Context, syncToken string, resourceTypes ...string) (*SyncResponse, error) {
+	if syncToken == "" {
+		syncToken = "*"
+	}
+
+	types, err := json.Marshal(resourceTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource types: %w", err)
+	}
+
+	formData := url.Values{}
+	formData.Set("sync_token", syncToken)
+	formData.Set("resource_types", string(types))
+
+	return c.postSync(ctx, formData)
+}
+
+// ExecuteCommands sends a batch of write commands in a single Sync request.
+// Per-command failures are reported in the response's SyncStatus.
+func (c *Client) ExecuteCommands(ctx context.Context, commands []SyncCommand) (*SyncResponse, error) {
+	cmdsJSON, err := json.Marshal(commands)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sync commands: %w", err)
+	}
+
+	formData := url.Values{}
+	formData.Set("commands", string(cmdsJSON))
+
+	return c.postSync(ctx, formData)
+}
+
+// BatchResult is the outcome of ExecuteBatched.
+type BatchResult struct {
+	TempIDMapping map[string]string // Temp ID to real ID of every created resource
+	Errors        map[string]error  // Failure of each command that was not applied, by UUID
+}
+
+// ExecuteBatched sends cmds in chunks of MaxCommandsPerSync. Temp IDs
+// resolved by earlier chunks are substituted into later ones, so a command
+// may reference any resource created before it. If a request fails, every
+// command not yet applied gets that error and nothing more is sent.
+func (c *Client) ExecuteBatched(ctx context.Context, cmds []SyncCommand) *BatchResult {
+	result := &BatchResult{
+		TempIDMapping: make(map[string]string),
+		Errors:        make(map[string]error),
+	}
+	for start := 0; start < len(cmds); start += MaxCommandsPerSync {
+		end := min(start+MaxCommandsPerSync, len(cmds))
+		chunk := make([]SyncCommand, end-start)
+		for i, cmd := range cmds[start:end] {
+			chunk[i] = ReplaceCommandIDs(cmd, result.TempIDMapping)
+		}
+
+		resp, err := c.ExecuteCommands(ctx, chunk)
+		if err != nil {
+			for _, cmd := range cmds[start:] {
+				result.Errors[cmd.UUID] = err
+			}
+			break
+		}
+
+		for _, cmd := range chunk {
+			if err := resp.CommandError(cmd.UUID); err != nil {
+				result.Errors[cmd.UUID] = err
+			}
+		}
+		for tempID, realID := range resp.TempIDMapping {
+			result.TempIDMapping[tempID] = realID
+		}
+	}
+	return result
+}
+
+// postSync sends form-encoded data to the Sync endpoint and decodes the
+// response, retrying on transient failures. Commands carry their own UUID, so
+// the server does not apply a retried command twice.
+func (c *Client) postSync(ctx context.Context, formData url.Values) (*SyncResponse, error) {
+	limiters := []*RateLimiter{c.Limiter}
+	if formData.Get("sync_token") == "*" {
+		limiters = append(limiters, c.FullSyncLimiter)
+	}
+	requestID := uuid.NewString()
+
+	var result *SyncResponse
+	err := c.retry(ctx, limiters, func() error {
+		var err error
+		result, err = c.postSyncOnce(ctx, formData, requestID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// postSyncOnce makes a single Sync request.
+func (c *Client) postSyncOnce(ctx context.Context, formData url.Values, requestID string) (*SyncResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/sync", strings.NewReader(formData.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Request-Id", requestID)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sync request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, newAPIError(resp, body)
+	}
+
+	var result SyncResponse
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode sync response: %w", err)
+		}
+	}
+
+	return &result, nil
+}
+
+// SyncResult is a snapshot of all resources held by a SyncClient.
+type SyncResult struct {
+	// FullSync is true when the server sent a complete data set instead of a delta.
+	FullSync  bool
+	Tasks     []Task
+	Projects  []Project
+	Sections  []Section
+	Labels    []Label
+	Filters   []Filter
+	Reminders []Reminder
+	User      *User // Account of the token; nil if never synced
+}
+
+// SyncClient keeps a local copy of the user's data in sync with Todoist.
+// The first call performs a full sync; later calls send the stored sync token
+// and only apply the returned deltas.
+type SyncClient struct {
+	client *Client
+
+	mu        sync.Mutex
+	token     string
+	tasks     map[string]Task
+	projects  map[string]Project
+	sections  map[string]Section
+	labels    map[string]Label
+	filters   map[string]Filter
+	reminders map[string]Reminder
+	user      *User
+}
+
+// NewSyncClient creates a SyncClient backed by the given API client.
+func NewSyncClient(client *Client) *SyncClient {
+	s := &SyncClient{client: client}
+	s.resetLocked()
+	return s
+}
+
+// Token returns the current sync token, or an empty string before the first sync.
+func (s *SyncClient) Token() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token
+}
+
+// Reset discards the local store so the next Sync performs a full sync.
+func (s *SyncClient) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resetLocked()
+}
+
+// Restore seeds the local store with previously synced data, so the next Sync
+// only fetches changes made after token.
+func (s *SyncClient) Restore(token string, data *SyncResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resetLocked()
+	s.applyLocked(&SyncResponse{
+		SyncToken: token,
+		Items:     data.Tasks,
+		Projects:  data.Projects,
+		Sections:  data.Sections,
+		Labels:    data.Labels,
+		Filters:   data.Filters,
+		Reminders: data.Reminders,
+		User:      data.User,
+	})
+}
+
+func (s *SyncClient) resetLocked() {
+	s.token = ""
+	s.tasks = make(map[string]Task)
+	s.projects = make(map[string]Project)
+	s.sections = make(map[string]Section)
+	s.labels = make(map[string]Label)
+	s.filters = make(map[string]Filter)
+	s.reminders = make(map[string]Reminder)
+	s.user = nil
+}
+
+// Sync fetches all changes since the last sync in a single round trip,
+// applies them to the local store and returns the resulting snapshot.
+func (s *SyncClient) Sync(ctx context.Context) (*SyncResult, error) {
+	resp, err := s.client.ReadResources(ctx, s.Token(), SyncResourceTypes...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.applyLocked(resp)
+	result := s.snapshotLocked()
+	result.FullSync = resp.FullSync
+	return result, nil
+}
+
+// Snapshot returns the current contents of the local store without contacting the server.
+func (s *SyncClient) Snapshot() *SyncResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshotLocked()
+}
+
+// applyLocked merges a sync response into the store. Deleted, archived and
+// completed resources are removed.
+func (s *SyncClient) applyLocked(resp *SyncResponse) {
+	if resp.FullSync {
+		s.resetLocked()
+	}
+	if resp.SyncToken != "" {
+		s.token = resp.SyncToken
+	}
+	// Incremental syncs only send the user when it changed
+	if resp.User != nil {
+		s.user = resp.User
+	}
+
+	for _, t := range resp.Items {
+		if t.IsDeleted || t.Checked {
+			delete(s.tasks, t.ID)
+		} else {
+			s.tasks[t.ID] = t
+		}
+	}
+	for _, p := range resp.Projects {
+		if p.IsDeleted || p.IsArchived {
+			delete(s.projects, p.ID)
+		} else {
+			s.projects[p.ID] = p
+		}
+	}
+	for _, sec := range resp.Sections {
+		if sec.IsDeleted || sec.IsArchived {
+			delete(s.sections, sec.ID)
+		} else {
+			s.sections[sec.ID] = sec
+		}
+	}
+	for _, l := range resp.Labels {
+		if l.IsDeleted {
+			delete(s.labels, l.ID)
+		} else {
+			s.labels[l.ID] = l
+		}
+	}
+	for _, f := range resp.Filters {
+		if f.IsDeleted {
+			delete(s.filters, f.ID)
+		} else {
+			s.filters[f.ID] = f
+		}
+	}
+	for _, r := range resp.Reminders {
+		if r.IsDeleted {
+			delete(s.reminders, r.ID)
+		} else {
+			s.reminders[r.ID] = r
+		}
+	}
+}
+
+// snapshotLocked copies the store into ordered slices.
+func (s *SyncClient) snapshotLocked() *SyncResult {
+	result := &SyncResult{
+		Tasks:     make([]Task, 0, len(s.tasks)),
+		Projects:  make([]Project, 0, len(s.projects)),
+		Sections:  make([]Section, 0, len(s.sections)),
+		Labels:    make([]Label, 0, len(s.labels)),
+		Filters:   make([]Filter, 0, len(s.filters)),
+		Reminders: make([]Reminder, 0, len(s.reminders)),
+		User:      s.user,
+	}
+
+	for _, t := range s.tasks {
+		result.Tasks = append(result.Tasks, t)
+	}
+	for _, p := range s.projects {
+		result.Projects = append(result.Projects, p)
+	}
+	for _, sec := range s.sections {
+		result.Sections = append(result.Sections, sec)
+	}
+	for _, l := range s.labels {
+		result.Labels = append(result.Labels, l)
+	}
+	for _, f := range s.filters {
+		result.Filters = append(result.Filters, f)
+	}
+	for _, r := range s.reminders {
+		result.Reminders = append(result.Reminders, r)
+	}
+
+	// Map iteration is random; sort so views stay stable between syncs
+	sort.Slice(result.Tasks, func(i, j int) bool {
+		if result.Tasks[i].ChildOrder != result.Tasks[j].ChildOrder {
+			return result.Tasks[i].ChildOrder < result.Tasks[j].ChildOrder
+		}
+		return result.Tasks[i].ID < result.Tasks[j].ID
+	})
+	sort.Slice(result.Projects, func(i, j int) bool {
+		if result.Projects[i].ChildOrder != result.Projects[j].ChildOrder {
+			return result.Projects[i].ChildOrder < result.Projects[j].ChildOrder
+		}
+		return result.Projects[i].ID < result.Projects[j].ID
+	})
+	sort.Slice(result.Sections, func(i, j int) bool {
+		if result.Sections[i].SectionOrder != result.Sections[j].SectionOrder {
+			return result.Sections[i].SectionOrder < result.Sections[j].SectionOrder
+		}
+		return result.Sections[i].ID < result.Sections[j].ID
+	})
+	sort.Slice(result.Labels, func(i, j int) bool {
+		if result.Labels[i].ItemOrder != result.Labels[j].ItemOrder {
+			return result.Labels[i].ItemOrder < result.Labels[j].ItemOrder
+		}
+		return result.Labels[i].ID < result.Labels[j].ID
+	})
+	sort.Slice(result.Filters, func(i, j int) bool {
+		if result.Filters[i].ItemOrder != result.Filters[j].ItemOrder {
+			return result.Filters[i].ItemOrder < result.Filters[j].ItemOrder
+		}
+		return result.Filters[i].ID < result.Filters[j].ID
+	})
+	sort.Slice(result.Reminders, func(i, j int) bool {
+		return result.Reminders[i].ID < result.Reminders[j].ID
+	})
+
+	return result
+}
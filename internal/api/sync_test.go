@@ -0,0 +1,263 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSyncClient_IncrementalSync(t *testing.T) {
+	responses := []string{
+		// Full sync
+		`{
+			"sync_token": "token-1",
+			"full_sync": true,
+			"items": [
+				{"id": "t1", "content": "First", "child_order": 2},
+				{"id": "t2", "content": "Second", "child_order": 1}
+			],
+			"projects": [{"id": "p1", "name": "Inbox"}],
+			"sections": [{"id": "s1", "name": "Backlog"}],
+			"labels": [{"id": "l1", "name": "work"}],
+			"filters": [{"id": "f1", "name": "Today", "query": "today"}],
+			"reminders": [{"id": "r1", "item_id": "t1", "type": "relative"}],
+			"user": {"id": "u1", "full_name": "Test User"}
+		}`,
+		// Delta: t1 completed, t2 updated, t3 added, label deleted
+		`{
+			"sync_token": "token-2",
+			"full_sync": false,
+			"items": [
+				{"id": "t1", "content": "First", "checked": true},
+				{"id": "t2", "content": "Second (edited)", "child_order": 1},
+				{"id": "t3", "content": "Third", "child_order": 3}
+			],
+			"labels": [{"id": "l1", "name": "work", "is_deleted": true}]
+		}`,
+	}
+
+	var tokens []string
+	call := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sync" {
+			t.Errorf("expected /sync path, got %s", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		tokens = append(tokens, r.Form.Get("sync_token"))
+
+		var types []string
+		if err := json.Unmarshal([]byte(r.Form.Get("resource_types")), &types); err != nil {
+			t.Errorf("invalid resource_types: %v", err)
+		}
+		if len(types) != len(SyncResourceTypes) {
+			t.Errorf("expected %d resource types, got %d", len(SyncResourceTypes), len(types))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(responses[call]))
+		call++
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+	sc := NewSyncClient(client)
+
+	first, err := sc.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("first sync failed: %v", err)
+	}
+	if !first.FullSync {
+		t.Error("expected first sync to be a full sync")
+	}
+	if len(first.Tasks) != 2 || first.Tasks[0].ID != "t2" {
+		t.Errorf("expected tasks ordered by child_order, got %+v", first.Tasks)
+	}
+	if len(first.Projects) != 1 || len(first.Sections) != 1 || len(first.Labels) != 1 ||
+		len(first.Filters) != 1 || len(first.Reminders) != 1 {
+		t.Errorf("unexpected resource counts: %+v", first)
+	}
+
+	second, err := sc.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("second sync failed: %v", err)
+	}
+	if second.FullSync {
+		t.Error("expected incremental sync")
+	}
+	if len(second.Tasks) != 2 {
+		t.Fatalf("expected 2 active tasks, got %d", len(second.Tasks))
+	}
+	if second.Tasks[0].Content != "Second (edited)" || second.Tasks[1].ID != "t3" {
+		t.Errorf("delta not applied: %+v", second.Tasks)
+	}
+	if len(second.Labels) != 0 {
+		t.Errorf("expected deleted label to be removed, got %+v", second.Labels)
+	}
+	if second.User == nil || second.User.ID != "u1" {
+		t.Errorf("expected the user of the full sync to be kept, got %+v", second.User)
+	}
+	if len(second.Projects) != 1 {
+		t.Errorf("expected untouched projects to be kept, got %d", len(second.Projects))
+	}
+
+	if len(tokens) != 2 || tokens[0] != "*" || tokens[1] != "token-1" {
+		t.Errorf("unexpected sync tokens sent: %v", tokens)
+	}
+	if sc.Token() != "token-2" {
+		t.Errorf("expected token-2, got %s", sc.Token())
+	}
+
+	sc.Reset()
+	if sc.Token() != "" || len(sc.Snapshot().Tasks) != 0 {
+		t.Error("expected Reset to clear the store")
+	}
+}
+
+func TestExecuteCommands(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		var commands []SyncCommand
+		if err := json.Unmarshal([]byte(r.Form.Get("commands")), &commands); err != nil {
+			t.Fatalf("failed to unmarshal commands: %v", err)
+		}
+		if len(commands) != 2 {
+			t.Fatalf("expected 2 commands, got %d", len(commands))
+		}
+
+		resp := map[string]interface{}{
+			"temp_id_mapping": map[string]string{commands[0].TempID: "real-1"},
+			"sync_status": map[string]interface{}{
+				commands[0].UUID: "ok",
+				commands[1].UUID: map[string]interface{}{"error_code": 20, "error": "Task not found"},
+			},
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	add := NewSyncCommandWithTempID("item_add", map[string]string{"content": "New"})
+	del := NewSyncCommand("item_delete", map[string]string{"id": "missing"})
+
+	result, err := client.ExecuteCommands(context.Background(), []SyncCommand{add, del})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TempIDMapping[add.TempID] != "real-1" {
+		t.Errorf("expected temp id to map to real-1, got %q", result.TempIDMapping[add.TempID])
+	}
+	if err := result.CommandError(add.UUID); err != nil {
+		t.Errorf("expected add to succeed, got %v", err)
+	}
+	err = result.CommandError(del.UUID)
+	var syncErr *SyncError
+	if !errors.As(err, &syncErr) {
+		t.Fatalf("expected delete to report a *SyncError, got %v", err)
+	}
+	if syncErr.CommandUUID != del.UUID || syncErr.Details.Code != 20 || syncErr.Details.Message != "Task not found" {
+		t.Errorf("unexpected sync error %+v", syncErr)
+	}
+}
+
+func TestRenewCommands(t *testing.T) {
+	parent := RestoreTaskCommand(Task{ID: "old-parent", Content: "Parent", ProjectID: "p1"})
+	childParent := parent.TempID
+	child := RestoreTaskCommand(Task{ID: "old-child", Content: "Child", ProjectID: "p1", ParentID: &childParent})
+	closeCmd := CloseTaskCommand("t1")
+
+	renewed, tempIDs := RenewCommands([]SyncCommand{parent, child, closeCmd})
+
+	if len(renewed) != 3 {
+		t.Fatalf("expected 3 commands, got %d", len(renewed))
+	}
+	for i, cmd := range []SyncCommand{parent, child, closeCmd} {
+		if renewed[i].UUID == cmd.UUID {
+			t.Errorf("command %d kept its UUID", i)
+		}
+	}
+	if renewed[0].TempID == parent.TempID || tempIDs[parent.TempID] != renewed[0].TempID {
+		t.Errorf("expected a new temp ID for the parent, got %q (mapping %v)", renewed[0].TempID, tempIDs)
+	}
+	if got := renewed[1].Args.(map[string]interface{})["parent_id"]; got != renewed[0].TempID {
+		t.Errorf("expected child to reference the renewed parent temp ID, got %v", got)
+	}
+	if got := child.Args.(map[string]interface{})["parent_id"]; got != parent.TempID {
+		t.Errorf("original command was modified: parent_id = %v", got)
+	}
+}
+
+func TestExecuteBatched_ChainsTempIDs(t *testing.T) {
+	var requests [][]SyncCommand
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		var commands []SyncCommand
+		if err := json.Unmarshal([]byte(r.Form.Get("commands")), &commands); err != nil {
+			t.Fatalf("failed to unmarshal commands: %v", err)
+		}
+		requests = append(requests, commands)
+
+		mapping := map[string]string{}
+		status := map[string]interface{}{}
+		for _, cmd := range commands {
+			status[cmd.UUID] = "ok"
+			if cmd.TempID != "" {
+				mapping[cmd.TempID] = "real-" + cmd.TempID
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"temp_id_mapping": mapping,
+			"sync_status":     status,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	project := AddProjectCommand("Work", "")
+	cmds := []SyncCommand{project}
+	for i := 0; i < MaxCommandsPerSync; i++ {
+		cmds = append(cmds, AddTaskCommand(CreateTaskRequest{Content: "Task", ProjectID: project.TempID}))
+	}
+
+	result := client.ExecuteBatched(context.Background(), cmds)
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	last := requests[1][0].Args.(map[string]interface{})
+	if last["project_id"] != "real-"+project.TempID {
+		t.Errorf("expected the second chunk to use the real project ID, got %v", last["project_id"])
+	}
+	if result.TempIDMapping[project.TempID] == "" {
+		t.Error("expected the project temp ID in the mapping")
+	}
+}
+
+func TestExecuteBatched_RequestErrorFailsRest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	cmds := []SyncCommand{CloseTaskCommand("1"), CloseTaskCommand("2")}
+	result := client.ExecuteBatched(context.Background(), cmds)
+	for _, cmd := range cmds {
+		if result.Errors[cmd.UUID] == nil {
+			t.Errorf("expected command %s to fail", cmd.UUID)
+		}
+	}
+}
@@ -1,106 +1,76 @@
 package api
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"strconv"
-
-	"github.com/google/uuid"
+	"time"
 )
 
 // GetTasks returns all active tasks, optionally filtered by project/section/label.
 // Note: The Filter field is NOT supported in v1 API on /tasks endpoint.
 // Use GetTasksByFilter for filter-based queries (e.g., "today | overdue").
-func (c *Client) GetTasks(filter TaskFilter) ([]Task, error) {
-	var allTasks []Task
-	query := buildFilterQuery(filter)
-
-	for {
-		var response PaginatedResponse[Task]
-		if err := c.GetWithQuery("/tasks", query, &response); err != nil {
-			return nil, fmt.Errorf("failed to get tasks: %w", err)
-		}
-
-		allTasks = append(allTasks, response.Results...)
-
-		if response.NextCursor == nil || *response.NextCursor == "" {
-			break
-		}
-		query.Set("cursor", *response.NextCursor)
-	}
-
-	return allTasks, nil
+func (c *Client) GetTasks(ctx context.Context, filter TaskFilter) ([]Task, error) {
+	return collect(c.Tasks(ctx, filter), "tasks")
 }
 
 // GetTasksByFilter returns tasks matching a Todoist filter query.
 // This uses the v1 API /tasks/filter endpoint.
 // Examples: "today", "today | overdue", "@labelname", "2024-01-22"
-func (c *Client) GetTasksByFilter(filterQuery string) ([]Task, error) {
+func (c *Client) GetTasksByFilter(ctx context.Context, filterQuery string) ([]Task, error) {
 	if filterQuery == "" {
 		return nil, fmt.Errorf("filter query cannot be empty")
 	}
-
-	query := url.Values{}
-	query.Set("query", filterQuery)
-
-	var response PaginatedResponse[Task]
-	if err := c.GetWithQuery("/tasks/filter", query, &response); err != nil {
-		return nil, fmt.Errorf("failed to get filtered tasks: %w", err)
-	}
-
-	return response.Results, nil
+	return collect(c.FilterTasks(ctx, filterQuery), "filtered tasks")
 }
 
 // GetTask returns a single task by ID.
-func (c *Client) GetTask(id string) (*Task, error) {
+func (c *Client) GetTask(ctx context.Context, id string) (*Task, error) {
 	var task Task
-	if err := c.Get("/tasks/"+id, &task); err != nil {
+	if err := c.Get(ctx, "/tasks/"+id, &task); err != nil {
 		return nil, fmt.Errorf("failed to get task %s: %w", id, err)
 	}
 	return &task, nil
 }
 
 // CreateTask creates a new task.
-func (c *Client) CreateTask(req CreateTaskRequest) (*Task, error) {
+func (c *Client) CreateTask(ctx context.Context, req CreateTaskRequest) (*Task, error) {
 	var task Task
-	if err := c.Post("/tasks", req, &task); err != nil {
+	if err := c.Post(ctx, "/tasks", req, &task); err != nil {
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 	return &task, nil
 }
 
 // UpdateTask updates an existing task.
-func (c *Client) UpdateTask(id string, req UpdateTaskRequest) (*Task, error) {
+func (c *Client) UpdateTask(ctx context.Context, id string, req UpdateTaskRequest) (*Task, error) {
 	var task Task
-	if err := c.Post("/tasks/"+id, req, &task); err != nil {
+	if err := c.Post(ctx, "/tasks/"+id, req, &task); err != nil {
 		return nil, fmt.Errorf("failed to update task %s: %w", id, err)
 	}
 	return &task, nil
 }
 
 // CloseTask marks a task as completed.
-func (c *Client) CloseTask(id string) error {
-	if err := c.Post("/tasks/"+id+"/close", nil, nil); err != nil {
+func (c *Client) CloseTask(ctx context.Context, id string) error {
+	if err := c.Post(ctx, "/tasks/"+id+"/close", nil, nil); err != nil {
 		return fmt.Errorf("failed to close task %s: %w", id, err)
 	}
 	return nil
 }
 
 // ReopenTask marks a completed task as not completed.
-func (c *Client) ReopenTask(id string) error {
-	if err := c.Post("/tasks/"+id+"/reopen", nil, nil); err != nil {
+func (c *Client) ReopenTask(ctx context.Context, id string) error {
+	if err := c.Post(ctx, "/tasks/"+id+"/reopen", nil, nil); err != nil {
 		return fmt.Errorf("failed to reopen task %s: %w", id, err)
 	}
 	return nil
 }
 
 // DeleteTask deletes a task.
-func (c *Client) DeleteTask(id string) error {
-	if err := c.Delete("/tasks/" + id); err != nil {
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	if err := c.Delete(ctx, "/tasks/"+id); err != nil {
 		return fmt.Errorf("failed to delete task %s: %w", id, err)
 	}
 	return nil
@@ -110,19 +80,19 @@ func (c *Client) DeleteTask(id string) error {
 // Supports: dates ("tomorrow", "every monday"), priorities (p1-p4),
 // labels @label, projects #project, assignees +name.
 // Example: "Buy milk tomorrow at 3pm @errands #Shopping p1"
-func (c *Client) QuickAddTask(text string) (*Task, error) {
+func (c *Client) QuickAddTask(ctx context.Context, text string) (*Task, error) {
 	var task Task
 	req := map[string]string{"text": text}
-	if err := c.Post("/tasks/quick", req, &task); err != nil {
+	if err := c.Post(ctx, "/tasks/quick", req, &task); err != nil {
 		return nil, fmt.Errorf("quick add failed: %w", err)
 	}
 	return &task, nil
 }
 
 // GetProductivityStats returns the user's productivity statistics including goals.
-func (c *Client) GetProductivityStats() (*ProductivityStats, error) {
+func (c *Client) GetProductivityStats(ctx context.Context) (*ProductivityStats, error) {
 	var stats ProductivityStats
-	if err := c.Get("/tasks/completed/stats", &stats); err != nil {
+	if err := c.Get(ctx, "/tasks/completed/stats", &stats); err != nil {
 		return nil, fmt.Errorf("failed to get stats: %w", err)
 	}
 	return &stats, nil
@@ -139,15 +109,59 @@ type CompletedTaskParams struct {
 	Page          int
 	Since         string // ISO 8601 date string
 	Until         string // ISO 8601 date string
+	Cursor        string // next_cursor of a previous page
 	AnnotateItems bool
 	AnnotateNotes bool
 }
 
 // GetCompletedTasks returns a list of completed tasks based on the provided parameters.
 // This uses the /tasks/completed/by_completion_date Unified v1 endpoint.
-func (c *Client) GetCompletedTasks(params CompletedTaskParams) ([]Task, error) {
+func (c *Client) GetCompletedTasks(ctx context.Context, params CompletedTaskParams) ([]Task, error) {
+	tasks, _, err := c.getCompletedTasksPage(ctx, params)
+	return tasks, err
+}
+
+// completedWindow is the longest since/until range the completed tasks
+// endpoint accepts in one query.
+const completedWindow = 90 * 24 * time.Hour
+
+// GetAllCompletedTasks returns every task completed between since and until.
+// The range is split into windows the API accepts and each window is read
+// page by page.
+func (c *Client) GetAllCompletedTasks(ctx context.Context, since, until time.Time) ([]Task, error) {
+	var all []Task
+	for start := since; start.Before(until); start = start.Add(completedWindow) {
+		end := start.Add(completedWindow)
+		if end.After(until) {
+			end = until
+		}
+		params := CompletedTaskParams{
+			Since:         start.UTC().Format(time.RFC3339),
+			Until:         end.UTC().Format(time.RFC3339),
+			Limit:         200,
+			AnnotateItems: true,
+		}
+		for {
+			tasks, next, err := c.getCompletedTasksPage(ctx, params)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, tasks...)
+			if next == "" {
+				break
+			}
+			params.Cursor = next
+		}
+	}
+	return all, nil
+}
+
+// getCompletedTasksPage fetches one page of completed tasks and returns the
+// cursor of the next page, or "" on the last one.
+func (c *Client) getCompletedTasksPage(ctx context.Context, params CompletedTaskParams) ([]Task, string, error) {
 	type CompletedTasksResponse struct {
-		Items []Task `json:"items"`
+		Items      []Task  `json:"items"`
+		NextCursor *string `json:"next_cursor"`
 	}
 
 	query := url.Values{}
@@ -178,6 +192,9 @@ func (c *Client) GetCompletedTasks(params CompletedTaskParams) ([]Task, error) {
 	if params.Until != "" {
 		query.Set("until", params.Until)
 	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
 	if params.AnnotateItems {
 		query.Set("annotate_items", "true")
 	}
@@ -186,15 +203,19 @@ func (c *Client) GetCompletedTasks(params CompletedTaskParams) ([]Task, error) {
 	}
 
 	var response CompletedTasksResponse
-	if err := c.GetWithQuery("/tasks/completed/by_completion_date", query, &response); err != nil {
-		return nil, fmt.Errorf("failed to get completed tasks: %w", err)
+	if err := c.GetWithQuery(ctx, "/tasks/completed/by_completion_date", query, &response); err != nil {
+		return nil, "", fmt.Errorf("failed to get completed tasks: %w", err)
 	}
 
-	return response.Items, nil
+	var next string
+	if response.NextCursor != nil {
+		next = *response.NextCursor
+	}
+	return response.Items, next, nil
 }
 
 // MoveTask moves a task to a different section, parent, or project using V1 REST API.
-func (c *Client) MoveTask(id string, sectionID *string, projectID *string, parentID *string) error {
+func (c *Client) MoveTask(ctx context.Context, id string, sectionID *string, projectID *string, parentID *string) error {
 	req := map[string]interface{}{}
 	if sectionID != nil {
 		req["section_id"] = *sectionID
@@ -210,19 +231,19 @@ func (c *Client) MoveTask(id string, sectionID *string, projectID *string, paren
 		}
 	}
 
-	if err := c.Post("/tasks/"+id+"/move", req, nil); err != nil {
+	if err := c.Post(ctx, "/tasks/"+id+"/move", req, nil); err != nil {
 		return fmt.Errorf("failed to move task %s: %w", id, err)
 	}
 	return nil
 }
 
 // MoveTasksBatch moves multiple tasks to a different project or section using Sync API batching.
-func (c *Client) MoveTasksBatch(ids []string, targetProjectID string, targetSectionID string) error {
+func (c *Client) MoveTasksBatch(ctx context.Context, ids []string, targetProjectID string, targetSectionID string) error {
 	if len(ids) == 0 {
 		return nil
 	}
 
-	commands := make([]interface{}, len(ids))
+	commands := make([]SyncCommand, len(ids))
 	for i, id := range ids {
 		args := map[string]interface{}{
 			"id": id,
@@ -234,39 +255,17 @@ func (c *Client) MoveTasksBatch(ids []string, targetProjectID string, targetSect
 			args["project_id"] = targetProjectID
 		}
 
-		commands[i] = map[string]interface{}{
-			"type": "item_move",
-			"uuid": uuid.New().String(),
-			"args": args,
-		}
+		commands[i] = NewSyncCommand("item_move", args)
 	}
 
-	cmdsJSON, err := json.Marshal(commands)
+	result, err := c.ExecuteCommands(ctx, commands)
 	if err != nil {
-		return fmt.Errorf("failed to marshal sync commands: %w", err)
+		return err
 	}
-	formData := url.Values{}
-	formData.Set("commands", string(cmdsJSON))
-
-	syncURL := c.baseURL + "/sync"
-	req, err := http.NewRequest("POST", syncURL, bytes.NewBufferString(formData.Encode()))
-	if err != nil {
-		return fmt.Errorf("failed to create sync request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("sync request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("sync API error %d: %s", resp.StatusCode, string(body))
+	for _, cmd := range commands {
+		if err := result.CommandError(cmd.UUID); err != nil {
+			return err
+		}
 	}
-
 	return nil
 }
@@ -1,11 +1,13 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 // mockServer creates a test HTTP server for mocking API responses.
@@ -108,7 +110,7 @@ func TestGetTasks(t *testing.T) {
 			client := NewClient("test-token")
 			client.baseURL = server.URL
 
-			tasks, err := client.GetTasks(tt.filter)
+			tasks, err := client.GetTasks(context.Background(), tt.filter)
 
 			if tt.wantErr {
 				if err == nil {
@@ -188,7 +190,7 @@ func TestGetTasksByFilter(t *testing.T) {
 			client := NewClient("test-token")
 			client.baseURL = server.URL
 
-			tasks, err := client.GetTasksByFilter(tt.filterQuery)
+			tasks, err := client.GetTasksByFilter(context.Background(), tt.filterQuery)
 
 			if tt.wantErr {
 				if err == nil {
@@ -209,6 +211,32 @@ func TestGetTasksByFilter(t *testing.T) {
 	}
 }
 
+func TestGetTasksByFilter_FollowsCursor(t *testing.T) {
+	server := mockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") != "today" {
+			t.Errorf("query lost on page %q", r.URL.Query().Get("cursor"))
+		}
+		next := "page-2"
+		resp := PaginatedResponse[Task]{Results: []Task{{ID: "1"}}, NextCursor: &next}
+		if r.URL.Query().Get("cursor") == "page-2" {
+			resp = PaginatedResponse[Task]{Results: []Task{{ID: "2"}}}
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	tasks, err := client.GetTasksByFilter(context.Background(), "today")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 2 || tasks[1].ID != "2" {
+		t.Errorf("expected both pages, got %v", tasks)
+	}
+}
+
 func TestCreateTask(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -293,7 +321,7 @@ func TestCreateTask(t *testing.T) {
 			client := NewClient("test-token")
 			client.baseURL = server.URL
 
-			task, err := client.CreateTask(tt.request)
+			task, err := client.CreateTask(context.Background(), tt.request)
 
 			if tt.wantErr {
 				if err == nil {
@@ -385,7 +413,7 @@ func TestUpdateTask(t *testing.T) {
 			client := NewClient("test-token")
 			client.baseURL = server.URL
 
-			task, err := client.UpdateTask(taskID, tt.request)
+			task, err := client.UpdateTask(context.Background(), taskID, tt.request)
 
 			if tt.wantErr {
 				if err == nil {
@@ -406,6 +434,37 @@ func TestUpdateTask(t *testing.T) {
 	}
 }
 
+func TestUpdateTaskRequest_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		req  UpdateTaskRequest
+		want string
+	}{
+		{
+			name: "set duration",
+			req:  UpdateTaskRequest{Duration: IntPtr(45), DurationUnit: StringPtr("minute")},
+			want: `{"duration":45,"duration_unit":"minute"}`,
+		},
+		{
+			name: "remove duration",
+			req:  UpdateTaskRequest{Content: StringPtr("x"), Duration: IntPtr(0), DurationUnit: StringPtr("minute")},
+			want: `{"content":"x","duration":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("got %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
 func TestCloseTask(t *testing.T) {
 	taskID := "123"
 
@@ -426,7 +485,7 @@ func TestCloseTask(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	err := client.CloseTask(taskID)
+	err := client.CloseTask(context.Background(), taskID)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -452,7 +511,7 @@ func TestReopenTask(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	err := client.ReopenTask(taskID)
+	err := client.ReopenTask(context.Background(), taskID)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -478,7 +537,7 @@ func TestDeleteTask(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	err := client.DeleteTask(taskID)
+	err := client.DeleteTask(context.Background(), taskID)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -539,7 +598,7 @@ func TestQuickAddTask(t *testing.T) {
 			client := NewClient("test-token")
 			client.baseURL = server.URL
 
-			task, err := client.QuickAddTask(tt.text)
+			task, err := client.QuickAddTask(context.Background(), tt.text)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("QuickAddTask() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -574,7 +633,7 @@ func TestGetProductivityStats(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	stats, err := client.GetProductivityStats()
+	stats, err := client.GetProductivityStats(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -603,7 +662,7 @@ func TestGetProductivityStats_NotFound(t *testing.T) {
 	// So set client.baseURL = server.URL.
 	client.baseURL = server.URL
 
-	_, err := client.GetProductivityStats()
+	_, err := client.GetProductivityStats(context.Background())
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -647,7 +706,7 @@ func TestMoveTask(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	err := client.MoveTask(taskID, nil, &projectID, nil)
+	err := client.MoveTask(context.Background(), taskID, nil, &projectID, nil)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -694,8 +753,47 @@ func TestMoveTasksBatch(t *testing.T) {
 	client := NewClient("test-token")
 	client.baseURL = server.URL
 
-	err := client.MoveTasksBatch([]string{"task1", "task2"}, "proj1", "sec1")
+	err := client.MoveTasksBatch(context.Background(), []string{"task1", "task2"}, "proj1", "sec1")
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+func TestGetAllCompletedTasks_WindowsAndCursors(t *testing.T) {
+	var windows []string
+	server := mockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tasks/completed/by_completion_date" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("cursor") == "" {
+			windows = append(windows, q.Get("since"))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"items":       []Task{{ID: "a-" + q.Get("since")}},
+				"next_cursor": "page-2",
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items":       []Task{{ID: "b-" + q.Get("since")}},
+			"next_cursor": nil,
+		})
+	})
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.SetBaseURL(server.URL)
+
+	until := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	since := until.AddDate(0, -6, 0)
+	tasks, err := client.GetAllCompletedTasks(context.Background(), since, until)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(windows) != 3 {
+		t.Fatalf("expected 3 windows for 6 months, got %d: %v", len(windows), windows)
+	}
+	if len(tasks) != 6 {
+		t.Errorf("expected 2 pages per window, got %d tasks", len(tasks))
+	}
+}
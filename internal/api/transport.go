@@ -0,0 +1,226 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// Exchange is one request and its response, as written to a debug log.
+type Exchange struct {
+	Time           time.Time   `json:"time"`
+	Method         string      `json:"method"`
+	URL            string      `json:"url"`
+	RequestHeader  http.Header `json:"request_header,omitempty"`
+	RequestBody    string      `json:"request_body,omitempty"`
+	Status         int         `json:"status,omitempty"`
+	ResponseHeader http.Header `json:"response_header,omitempty"`
+	ResponseBody   string      `json:"response_body,omitempty"`
+	DurationMS     int64       `json:"duration_ms"`
+	// Error is set when no response was received.
+	Error string `json:"error,omitempty"`
+}
+
+// redacted replaces secrets in debug logs.
+const redacted = "REDACTED"
+
+// Secrets in JSON and form encoded bodies. sync_token is not a secret.
+var (
+	jsonSecretRe = regexp.MustCompile(`"(access_token|token|client_secret|refresh_token)"(\s*:\s*)"[^"]*"`)
+	formSecretRe = regexp.MustCompile(`(^|&)(access_token|token|client_secret|refresh_token)=[^&]*`)
+)
+
+// redactBody strips secrets from a request or response body.
+func redactBody(body string) string {
+	body = jsonSecretRe.ReplaceAllString(body, `"$1"$2"`+redacted+`"`)
+	return formSecretRe.ReplaceAllString(body, "${1}${2}="+redacted)
+}
+
+// redactHeader returns a copy of h without credentials.
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, key := range []string{"Authorization", "Cookie", "Set-Cookie"} {
+		if h.Get(key) != "" {
+			h.Set(key, redacted)
+		}
+	}
+	return h
+}
+
+// LoggingTransport is an http.RoundTripper that writes every request and its
+// response to Out as a line of JSON, with the token and other secrets
+// removed. The log can be served back with ReplayTransport.
+type LoggingTransport struct {
+	// Base performs the requests; nil means http.DefaultTransport.
+	Base http.RoundTripper
+	Out  io.Writer
+
+	mu sync.Mutex
+}
+
+// NewLoggingTransport returns a LoggingTransport sending requests through base.
+func NewLoggingTransport(base http.RoundTripper, out io.Writer) *LoggingTransport {
+	return &LoggingTransport{Base: base, Out: out}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ex := Exchange{
+		Time:          time.Now(),
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		RequestHeader: redactHeader(req.Header),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		ex.RequestBody = redactBody(string(body))
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	ex.DurationMS = time.Since(ex.Time).Milliseconds()
+	if err != nil {
+		ex.Error = err.Error()
+		t.write(ex)
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		ex.Error = err.Error()
+	}
+	ex.Status = resp.StatusCode
+	ex.ResponseHeader = redactHeader(resp.Header)
+	ex.ResponseBody = redactBody(string(body))
+	t.write(ex)
+
+	return resp, err
+}
+
+// write appends ex to the log. Logging never fails a request.
+func (t *LoggingTransport) write(ex Exchange) {
+	line, err := json.Marshal(ex)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Out.Write(append(line, '\n'))
+}
+
+// ReplayTransport is an http.RoundTripper that answers requests with the
+// responses of a LoggingTransport log instead of going to the network.
+//
+// Requests are matched by method and URL. Repeated requests for the same URL
+// get the recorded responses in order; once they run out the last one is
+// served again.
+type ReplayTransport struct {
+	mu        sync.Mutex
+	exchanges map[string][]Exchange
+	served    map[string]int
+}
+
+// NewReplayTransport reads a JSONL log written by LoggingTransport.
+func NewReplayTransport(r io.Reader) (*ReplayTransport, error) {
+	t := &ReplayTransport{
+		exchanges: make(map[string][]Exchange),
+		served:    make(map[string]int),
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var ex Exchange
+		if err := json.Unmarshal(scanner.Bytes(), &ex); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		// Requests that never got a response cannot be replayed
+		if ex.Status == 0 {
+			continue
+		}
+		key := replayKey(ex.Method, ex.URL)
+		t.exchanges[key] = append(t.exchanges[key], ex)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// LoadReplayTransport reads the JSONL log at path.
+func LoadReplayTransport(path string) (*ReplayTransport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	t, err := NewReplayTransport(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	key := replayKey(req.Method, req.URL.String())
+	t.mu.Lock()
+	recorded := t.exchanges[key]
+	i := t.served[key]
+	if i < len(recorded)-1 {
+		t.served[key]++
+	}
+	t.mu.Unlock()
+
+	if len(recorded) == 0 {
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL)
+	}
+
+	ex := recorded[i]
+	header := ex.ResponseHeader.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", ex.Status, http.StatusText(ex.Status)),
+		StatusCode:    ex.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(ex.ResponseBody))),
+		ContentLength: int64(len(ex.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+// replayKey identifies the recorded responses for a request.
+func replayKey(method, url string) string {
+	return method + " " + url
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoggingTransport_RedactsSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "secret-value") {
+			t.Errorf("request body did not reach the server intact: %s", body)
+		}
+		w.Write([]byte(`{"access_token": "secret-value", "sync_token": "abc"}`))
+	}))
+	defer server.Close()
+
+	var log bytes.Buffer
+	c := NewClient("secret-token")
+	c.baseURL = server.URL
+	c.SetTransport(NewLoggingTransport(c.Transport(), &log))
+
+	var result map[string]string
+	if err := c.Post(context.Background(), "/access_tokens/migrate_personal_token", map[string]string{"client_secret": "secret-value"}, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["access_token"] != "secret-value" {
+		t.Errorf("the caller should get the unredacted response, got %v", result)
+	}
+
+	if strings.Contains(log.String(), "secret-") {
+		t.Errorf("secrets leaked into the log: %s", log.String())
+	}
+	var ex Exchange
+	if err := json.Unmarshal(log.Bytes(), &ex); err != nil {
+		t.Fatalf("expected one JSON line, got %q: %v", log.String(), err)
+	}
+	if ex.Method != http.MethodPost || ex.Status != http.StatusOK || ex.URL != server.URL+"/access_tokens/migrate_personal_token" {
+		t.Errorf("unexpected exchange %+v", ex)
+	}
+	if !strings.Contains(ex.ResponseBody, `"sync_token": "abc"`) {
+		t.Errorf("sync_token should be kept, got %s", ex.ResponseBody)
+	}
+}
+
+func TestRedactBody_Form(t *testing.T) {
+	got := redactBody("sync_token=abc&token=xyz&client_secret=s3")
+	want := "sync_token=abc&token=REDACTED&client_secret=REDACTED"
+	if got != want {
+		t.Errorf("redactBody = %q, want %q", got, want)
+	}
+}
+
+func TestReplayTransport(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "Task not found", "error_code": 20}`))
+			return
+		}
+		w.Write([]byte(`{"id": "t1", "content": "Recorded"}`))
+	}))
+
+	var log bytes.Buffer
+	c := NewClient("test-token")
+	c.baseURL = server.URL
+	c.SetTransport(NewLoggingTransport(c.Transport(), &log))
+	c.GetTask(context.Background(), "t1")
+	c.GetTask(context.Background(), "t1")
+	server.Close()
+
+	replay, err := NewReplayTransport(&log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetTransport(replay)
+
+	// Responses come back in the recorded order
+	_, err = c.GetTask(context.Background(), "t1")
+	if apiErr, ok := IsAPIError(err); !ok || !apiErr.IsNotFound() || apiErr.Message != "Task not found" {
+		t.Errorf("expected the recorded 404, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		task, err := c.GetTask(context.Background(), "t1")
+		if err != nil || task.Content != "Recorded" {
+			t.Errorf("expected the recorded task, got %+v (%v)", task, err)
+		}
+	}
+
+	if _, err := c.GetTask(context.Background(), "t2"); err == nil {
+		t.Error("expected an error for a request that was not recorded")
+	}
+}
@@ -2,6 +2,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -144,6 +145,18 @@ type Collaborator struct {
 	Email string `json:"email"`
 }
 
+// User is the account an access token belongs to.
+type User struct {
+	ID             string `json:"id"`
+	Email          string `json:"email"`
+	FullName       string `json:"full_name"`
+	InboxProjectID string `json:"inbox_project_id"`
+	IsPremium      bool   `json:"is_premium"`
+	TZInfo         struct {
+		Timezone string `json:"timezone"`
+	} `json:"tz_info"`
+}
+
 // CreateTaskRequest represents the request body for creating a task.
 type CreateTaskRequest struct {
 	Content      string   `json:"content"`
@@ -161,6 +174,7 @@ type CreateTaskRequest struct {
 	AssigneeID   string   `json:"assignee_id,omitempty"`
 	Duration     int      `json:"duration,omitempty"`
 	DurationUnit string   `json:"duration_unit,omitempty"`
+	DeadlineDate string   `json:"deadline_date,omitempty"` // YYYY-MM-DD
 }
 
 // UpdateTaskRequest represents the request body for updating a task.
@@ -176,8 +190,22 @@ type UpdateTaskRequest struct {
 	DueDatetime  *string  `json:"due_datetime,omitempty"`
 	DueLang      *string  `json:"due_lang,omitempty"`
 	AssigneeID   *string  `json:"assignee_id,omitempty"`
-	Duration     *int     `json:"duration,omitempty"`
+	Duration     *int     `json:"duration,omitempty"` // 0 removes the duration
 	DurationUnit *string  `json:"duration_unit,omitempty"`
+	DeadlineDate *string  `json:"deadline_date,omitempty"` // YYYY-MM-DD; "" removes the deadline
+}
+
+// MarshalJSON sends a Duration of 0 as null, which removes the duration.
+func (r UpdateTaskRequest) MarshalJSON() ([]byte, error) {
+	type plain UpdateTaskRequest
+	if r.Duration == nil || *r.Duration != 0 {
+		return json.Marshal(plain(r))
+	}
+	return json.Marshal(struct {
+		plain
+		Duration     *int    `json:"duration"`
+		DurationUnit *string `json:"duration_unit,omitempty"`
+	}{plain: plain(r)})
 }
 
 // CreateProjectRequest represents the request body for creating a project.
@@ -232,6 +260,9 @@ type CreateCommentRequest struct {
 	TaskID    string `json:"task_id,omitempty"`
 	ProjectID string `json:"project_id,omitempty"`
 	Content   string `json:"content"`
+	// UIDsToNotify are the collaborators notified of the comment, usually
+	// the ones it @mentions.
+	UIDsToNotify []string `json:"uids_to_notify,omitempty"`
 }
 
 // UpdateCommentRequest represents the request body for updating a comment.
@@ -341,8 +372,21 @@ func (t *Task) IsDueToday() bool {
 	return dueDate.Year() == today.Year() && dueDate.Month() == today.Month() && dueDate.Day() == today.Day()
 }
 
-// DueDisplay returns a human-readable due date string, including time if available.
+// DueDisplay returns a human-readable due date string, including time if
+// available. Recurring dates end with "↻" and their rule, e.g. "Monday ↻
+// every monday".
 func (t *Task) DueDisplay() string {
+	display := t.DueDateDisplay()
+	// Recurring dates show their rule after the next occurrence
+	if t.Due != nil && t.Due.IsRecurring && t.Due.String != "" {
+		display += " ↻ " + t.Due.String
+	}
+	return display
+}
+
+// DueDateDisplay returns the human-readable due date of DueDisplay without
+// the recurrence rule, e.g. "Monday" or "tomorrow 3:00pm".
+func (t *Task) DueDateDisplay() string {
 	if t.Due == nil {
 		return ""
 	}
@@ -414,7 +458,7 @@ func (t *Task) DueDisplay() string {
 	// Append time if available
 	if hasTime {
 		display += " " + dueDate.Format("3:04pm")
-	} else if t.Due.String != "" {
+	} else if t.Due.String != "" && !t.Due.IsRecurring {
 		// Fallback: If raw string contains a time but API didn't provide datetime (rare but possible),
 		// or if the user wants to see the specific string they typed which includes a time.
 		lowerStr := strings.ToLower(t.Due.String)
@@ -434,6 +478,50 @@ func (t *Task) DueDisplay() string {
 	return display
 }
 
+// DaysUntilDeadline returns the number of days from the day of now until
+// the deadline, negative once it has passed. It reports false if the task
+// has no deadline.
+func (t *Task) DaysUntilDeadline(now time.Time) (int, bool) {
+	if t.Deadline == nil {
+		return 0, false
+	}
+	// Deadlines are dates without a time zone; compare them as UTC days so
+	// daylight saving changes don't matter
+	deadline, err := time.Parse("2006-01-02", t.Deadline.Date)
+	if err != nil {
+		return 0, false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return int(deadline.Sub(today).Hours() / 24), true
+}
+
+// DeadlineDisplay returns a human-readable deadline, worded like DueDisplay.
+func (t *Task) DeadlineDisplay() string {
+	days, ok := t.DaysUntilDeadline(time.Now())
+	if !ok {
+		if t.Deadline != nil {
+			return t.Deadline.Date
+		}
+		return ""
+	}
+
+	switch {
+	case days < -1:
+		return fmt.Sprintf("%d days ago", -days)
+	case days == -1:
+		return "yesterday"
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	case days < 7:
+		deadline, _ := time.Parse("2006-01-02", t.Deadline.Date)
+		return deadline.Weekday().String()
+	}
+	deadline, _ := time.Parse("2006-01-02", t.Deadline.Date)
+	return deadline.Format("Jan 2")
+}
+
 // ProductivityStats represents the user's productivity statistics.
 type ProductivityStats struct {
 	Goals      ProductivityGoals `json:"goals"`
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"context"
+	"errors"
+)
+
+// GetUser returns the account the access token belongs to.
+func (c *Client) GetUser(ctx context.Context) (*User, error) {
+	resp, err := c.ReadResources(ctx, "*", "user")
+	if err != nil {
+		return nil, err
+	}
+	if resp.User == nil {
+		return nil, errors.New("sync response has no user")
+	}
+	return resp.User, nil
+}
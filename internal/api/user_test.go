@@ -0,0 +1,30 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if got := r.PostForm.Get("resource_types"); got != `["user"]` {
+			t.Errorf("resource_types = %s", got)
+		}
+		w.Write([]byte(`{"sync_token": "t", "full_sync": true, "user": {"id": "42", "email": "ada@example.com", "full_name": "Ada", "tz_info": {"timezone": "Europe/London"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	user, err := client.GetUser(context.Background())
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if user.ID != "42" || user.FullName != "Ada" || user.TZInfo.Timezone != "Europe/London" {
+		t.Errorf("unexpected user: %+v", user)
+	}
+}
@@ -3,8 +3,15 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -13,84 +20,134 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hy4ri/todoist-tui/internal/api"
 	"github.com/hy4ri/todoist-tui/internal/config"
 )
 
 const (
 	// Todoist OAuth2 endpoints
 	authorizationURL = "https://todoist.com/oauth/authorize"
-	tokenURL         = "https://todoist.com/oauth/access_token"
 
 	// OAuth2 configuration
 	redirectURI = "http://localhost:8585/callback"
 	scope       = "data:read_write,data:delete"
 
 	// Server configuration
-	callbackPort    = 8585
+	callbackAddr    = "localhost:8585"
 	callbackTimeout = 5 * time.Minute
 )
 
+// tokenURL is the token endpoint, a variable so tests can replace it.
+var tokenURL = "https://todoist.com/oauth/access_token"
+
+// ErrNoOAuthApp is returned when no OAuth client ID and secret are configured.
+var ErrNoOAuthApp = errors.New("no OAuth app configured (set TODOIST_CLIENT_ID and TODOIST_CLIENT_SECRET)")
+
 // TokenResponse represents the OAuth2 token response from Todoist.
 type TokenResponse struct {
 	AccessToken string `json:"access_token"`
 	TokenType   string `json:"token_type"`
 }
 
-// GetAccessToken retrieves a valid access token.
-// It checks for existing tokens in the config, and if not found or invalid,
-// initiates the OAuth2 flow.
-func GetAccessToken(cfg *config.Config) (string, error) {
-	// First, check for existing valid token
-	if cfg.Auth.AccessToken != "" {
-		return cfg.Auth.AccessToken, nil
-	}
-
-	// Fall back to secure token storage if available
-	if token, _ := config.GetToken(); token != "" {
-		return token, nil
-	}
-
-	// Check for OAuth credentials from environment
-	clientID := os.Getenv("TODOIST_CLIENT_ID")
-	clientSecret := os.Getenv("TODOIST_CLIENT_SECRET")
-
-	// If not in env, check config
+// Credentials returns the OAuth client ID and secret from the environment,
+// falling back to the config.
+func Credentials(cfg *config.Config) (clientID, clientSecret string) {
+	clientID = os.Getenv("TODOIST_CLIENT_ID")
+	clientSecret = os.Getenv("TODOIST_CLIENT_SECRET")
 	if clientID == "" {
 		clientID = cfg.Auth.ClientID
 	}
 	if clientSecret == "" {
 		clientSecret = cfg.Auth.ClientSecret
 	}
+	return clientID, clientSecret
+}
 
-	// If we have OAuth credentials, start the flow
-	if clientID != "" && clientSecret != "" {
-		token, err := performOAuthFlow(clientID, clientSecret)
-		if err != nil {
-			return "", fmt.Errorf("OAuth flow failed: %w", err)
-		}
+// HasOAuthApp reports whether an OAuth client ID and secret are configured.
+func HasOAuthApp(cfg *config.Config) bool {
+	clientID, clientSecret := Credentials(cfg)
+	return clientID != "" && clientSecret != ""
+}
 
-		// Save the token to config
-		cfg.Auth.AccessToken = token
+// GetAccessToken retrieves a valid access token.
+// It checks secure token storage first, and if no token is found,
+// initiates the OAuth2 flow and stores the new token there. Returns
+// ErrNoOAuthApp if there is neither a token nor an OAuth app.
+func GetAccessToken(ctx context.Context, cfg *config.Config) (string, error) {
+	// Move a token saved in config.yaml by older versions to secure storage
+	if cfg.Auth.AccessToken != "" {
+		token := cfg.Auth.AccessToken
+		if err := config.SaveToken(token); err != nil {
+			return "", fmt.Errorf("failed to save token: %w", err)
+		}
+		cfg.Auth.AccessToken = ""
 		if err := config.Save(cfg); err != nil {
 			// Non-fatal warning
-			fmt.Fprintf(os.Stderr, "Warning: failed to save token to config: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove token from config: %v\n", err)
 		}
+		return token, nil
+	}
 
+	if token, _ := config.GetToken(); token != "" {
 		return token, nil
 	}
 
-	// No authentication method available
-	return "", fmt.Errorf("no authentication configured")
+	if !HasOAuthApp(cfg) {
+		return "", ErrNoOAuthApp
+	}
+
+	token, err := Login(ctx, cfg)
+	if err != nil {
+		return "", err
+	}
+	if err := config.SaveToken(token); err != nil {
+		return "", fmt.Errorf("failed to save token: %w", err)
+	}
+	return token, nil
+}
+
+// Login signs in through the browser with the OAuth app of cfg and returns
+// the access token. It does not store the token.
+func Login(ctx context.Context, cfg *config.Config) (string, error) {
+	clientID, clientSecret := Credentials(cfg)
+	if clientID == "" || clientSecret == "" {
+		return "", ErrNoOAuthApp
+	}
+
+	// The token exchange goes through the same proxy as API requests
+	httpClient, err := api.NewHTTPClient(cfg.Network.API())
+	if err != nil {
+		return "", fmt.Errorf("invalid network settings: %w", err)
+	}
+
+	token, err := performOAuthFlow(ctx, httpClient, clientID, clientSecret)
+	if err != nil {
+		return "", fmt.Errorf("OAuth flow failed: %w", err)
+	}
+	return token, nil
 }
 
-// performOAuthFlow initiates the OAuth2 authorization flow.
-func performOAuthFlow(clientID, clientSecret string) (string, error) {
-	// Create a channel to receive the authorization code
-	codeChan := make(chan string, 1)
-	errChan := make(chan error, 1)
+// performOAuthFlow initiates the OAuth2 authorization flow. The code is
+// exchanged for a token with httpClient.
+func performOAuthFlow(ctx context.Context, httpClient *http.Client, clientID, clientSecret string) (string, error) {
+	state, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return "", err
+	}
 
-	// Start the callback server
-	server := startCallbackServer(codeChan, errChan)
+	// Start the callback server before sending the user to the browser, so a
+	// port conflict is reported right away
+	listener, err := net.Listen("tcp", callbackAddr)
+	if err != nil {
+		return "", fmt.Errorf("failed to start callback server: %w", err)
+	}
+	results := make(chan callbackResult, 1)
+	server := &http.Server{Handler: callbackHandler(state, results)}
+	go server.Serve(listener)
 	defer func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -98,7 +155,7 @@ func performOAuthFlow(clientID, clientSecret string) (string, error) {
 	}()
 
 	// Build the authorization URL
-	authURL := buildAuthorizationURL(clientID)
+	authURL := buildAuthorizationURL(clientID, state, codeChallenge(verifier))
 
 	// Open the browser for authorization
 	fmt.Println("Opening browser for Todoist authorization...")
@@ -112,37 +169,64 @@ func performOAuthFlow(clientID, clientSecret string) (string, error) {
 
 	// Wait for the callback with timeout
 	select {
-	case code := <-codeChan:
+	case result := <-results:
+		if result.err != nil {
+			return "", result.err
+		}
 		// Exchange the code for a token
-		return exchangeCodeForToken(clientID, clientSecret, code)
-	case err := <-errChan:
-		return "", err
+		return exchangeCodeForToken(ctx, httpClient, clientID, clientSecret, result.code, verifier)
+	case <-ctx.Done():
+		return "", ctx.Err()
 	case <-time.After(callbackTimeout):
 		return "", fmt.Errorf("authorization timed out after %v", callbackTimeout)
 	}
 }
 
-// startCallbackServer starts an HTTP server to receive the OAuth2 callback.
-func startCallbackServer(codeChan chan<- string, errChan chan<- error) *http.Server {
+// callbackResult is the outcome of the OAuth2 callback.
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// callbackHandler receives the OAuth2 callback. Only the first request
+// carrying the expected state is reported; others are rejected, since they
+// cannot come from the authorization we started.
+func callbackHandler(state string, results chan<- callbackResult) http.Handler {
 	mux := http.NewServeMux()
 
+	fail := func(w http.ResponseWriter, message string) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `<html><body><h1>Authorization Failed</h1><p>%s</p><p>You can close this window.</p></body></html>`, html.EscapeString(message))
+	}
+	report := func(result callbackResult) {
+		select {
+		case results <- result:
+		default:
+		}
+	}
+
 	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		// A forged or stale callback must not end the flow
+		if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+			fail(w, "Invalid state parameter.")
+			return
+		}
+
 		// Check for error
-		if errMsg := r.URL.Query().Get("error"); errMsg != "" {
-			errChan <- fmt.Errorf("authorization denied: %s", errMsg)
-			w.Header().Set("Content-Type", "text/html")
-			w.WriteHeader(http.StatusBadRequest)
-			fmt.Fprintf(w, `<html><body><h1>Authorization Failed</h1><p>%s</p><p>You can close this window.</p></body></html>`, errMsg)
+		if errMsg := query.Get("error"); errMsg != "" {
+			report(callbackResult{err: fmt.Errorf("authorization denied: %s", errMsg)})
+			fail(w, errMsg)
 			return
 		}
 
 		// Get the authorization code
-		code := r.URL.Query().Get("code")
+		code := query.Get("code")
 		if code == "" {
-			errChan <- fmt.Errorf("no authorization code received")
-			w.Header().Set("Content-Type", "text/html")
-			w.WriteHeader(http.StatusBadRequest)
-			fmt.Fprint(w, `<html><body><h1>Authorization Failed</h1><p>No authorization code received.</p><p>You can close this window.</p></body></html>`)
+			report(callbackResult{err: fmt.Errorf("no authorization code received")})
+			fail(w, "No authorization code received.")
 			return
 		}
 
@@ -151,54 +235,60 @@ func startCallbackServer(codeChan chan<- string, errChan chan<- error) *http.Ser
 		fmt.Fprint(w, `<html><body><h1>Authorization Successful!</h1><p>You can close this window and return to the terminal.</p></body></html>`)
 
 		// Send the code
-		codeChan <- code
+		report(callbackResult{code: code})
 	})
 
-	server := &http.Server{
-		Addr:    fmt.Sprintf(":%d", callbackPort),
-		Handler: mux,
-	}
-
-	go func() {
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			errChan <- fmt.Errorf("callback server error: %w", err)
-		}
-	}()
-
-	// Give the server a moment to start
-	time.Sleep(100 * time.Millisecond)
-
-	return server
+	return mux
 }
 
-// buildAuthorizationURL constructs the OAuth2 authorization URL.
-func buildAuthorizationURL(clientID string) string {
+// buildAuthorizationURL constructs the OAuth2 authorization URL with a PKCE
+// code challenge.
+func buildAuthorizationURL(clientID, state, challenge string) string {
 	params := url.Values{
-		"client_id":     {clientID},
-		"scope":         {scope},
-		"state":         {generateState()},
-		"redirect_uri":  {redirectURI},
-		"response_type": {"code"},
+		"client_id":             {clientID},
+		"scope":                 {scope},
+		"state":                 {state},
+		"redirect_uri":          {redirectURI},
+		"response_type":         {"code"},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
 	}
 	return authorizationURL + "?" + params.Encode()
 }
 
-// generateState generates a random state string for CSRF protection.
-func generateState() string {
-	// Simple state generation - in production, use crypto/rand
-	return fmt.Sprintf("%d", time.Now().UnixNano())
+// randomString returns 32 random bytes, base64url-encoded, for use as a
+// state or PKCE code verifier.
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random string: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// codeChallenge returns the S256 PKCE code challenge of verifier.
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
 }
 
 // exchangeCodeForToken exchanges the authorization code for an access token.
-func exchangeCodeForToken(clientID, clientSecret, code string) (string, error) {
+func exchangeCodeForToken(ctx context.Context, httpClient *http.Client, clientID, clientSecret, code, verifier string) (string, error) {
 	data := url.Values{
 		"client_id":     {clientID},
 		"client_secret": {clientSecret},
 		"code":          {code},
+		"code_verifier": {verifier},
 		"redirect_uri":  {redirectURI},
 	}
 
-	resp, err := http.Post(tokenURL, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to exchange code for token: %w", err)
 	}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCallbackHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		status   int
+		reported bool
+		wantCode string
+		wantErr  string
+	}{
+		{"success", "state=s1&code=abc", http.StatusOK, true, "abc", ""},
+		{"wrong state", "state=forged&code=abc", http.StatusBadRequest, false, "", ""},
+		{"missing state", "code=abc", http.StatusBadRequest, false, "", ""},
+		{"denied", "state=s1&error=access_denied", http.StatusBadRequest, true, "", "access_denied"},
+		{"no code", "state=s1", http.StatusBadRequest, true, "", "no authorization code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := make(chan callbackResult, 1)
+			rec := httptest.NewRecorder()
+			callbackHandler("s1", results).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?"+tt.query, nil))
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			select {
+			case result := <-results:
+				if !tt.reported {
+					t.Fatalf("unexpected result %+v", result)
+				}
+				if result.code != tt.wantCode {
+					t.Errorf("code = %q, want %q", result.code, tt.wantCode)
+				}
+				if tt.wantErr != "" && (result.err == nil || !strings.Contains(result.err.Error(), tt.wantErr)) {
+					t.Errorf("err = %v, want %q", result.err, tt.wantErr)
+				}
+			default:
+				if tt.reported {
+					t.Error("expected a result")
+				}
+			}
+		})
+	}
+}
+
+func TestCallbackHandler_EscapesError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	callbackHandler("s1", make(chan callbackResult, 1)).ServeHTTP(rec,
+		httptest.NewRequest(http.MethodGet, "/callback?state=s1&error="+url.QueryEscape("<script>"), nil))
+	if strings.Contains(rec.Body.String(), "<script>") {
+		t.Errorf("error was not escaped: %s", rec.Body.String())
+	}
+}
+
+func TestBuildAuthorizationURL(t *testing.T) {
+	u, err := url.Parse(buildAuthorizationURL("client", "state", codeChallenge("verifier")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("state") != "state" || q.Get("code_challenge_method") != "S256" {
+		t.Errorf("unexpected query: %v", q)
+	}
+	// RFC 7636 appendix B
+	if got := codeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"); got != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Errorf("codeChallenge() = %q", got)
+	}
+}
+
+func TestExchangeCodeForToken(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.PostForm
+		w.Write([]byte(`{"access_token": "new-token", "token_type": "Bearer"}`))
+	}))
+	defer server.Close()
+
+	defer func(orig string) { tokenURL = orig }(tokenURL)
+	tokenURL = server.URL
+
+	token, err := exchangeCodeForToken(context.Background(), server.Client(), "client", "secret", "code", "verifier")
+	if err != nil {
+		t.Fatalf("exchangeCodeForToken() error = %v", err)
+	}
+	if token != "new-token" {
+		t.Errorf("token = %q", token)
+	}
+	if form.Get("code_verifier") != "verifier" || form.Get("code") != "code" {
+		t.Errorf("unexpected form: %v", form)
+	}
+}
@@ -0,0 +1,177 @@
+// Package backup saves a Todoist account to a single versioned archive and
+// recreates projects from it.
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// Version identifies the layout of the archive. Archives written by a newer
+// version are rejected by Read.
+const Version = 1
+
+// Archive is a snapshot of an account.
+type Archive struct {
+	Version         int                      `json:"version"`
+	CreatedAt       time.Time                `json:"created_at"`
+	Projects        []api.Project            `json:"projects"`
+	Sections        []api.Section            `json:"sections"`
+	Tasks           []api.Task               `json:"tasks"`
+	CompletedTasks  []api.Task               `json:"completed_tasks"`
+	Labels          []api.Label              `json:"labels"`
+	Filters         []api.Filter             `json:"filters"`
+	Reminders       []api.Reminder           `json:"reminders"`
+	Comments        map[string][]api.Comment `json:"comments"`         // By task ID
+	ProjectComments map[string][]api.Comment `json:"project_comments"` // By project ID
+}
+
+// Options controls what Create fetches.
+type Options struct {
+	// CompletedSince is the start of the completed task history to include.
+	// The zero value leaves completed tasks out.
+	CompletedSince time.Time
+}
+
+// Create fetches everything in the account into a new archive.
+func Create(ctx context.Context, client *api.Client, opts Options) (*Archive, error) {
+	a := &Archive{Version: Version, CreatedAt: time.Now().UTC()}
+
+	var err error
+	if a.Projects, err = client.GetProjects(ctx); err != nil {
+		return nil, err
+	}
+	if a.Sections, err = client.GetSections(ctx, ""); err != nil {
+		return nil, err
+	}
+	if a.Tasks, err = client.GetTasks(ctx, api.TaskFilter{}); err != nil {
+		return nil, err
+	}
+	if !opts.CompletedSince.IsZero() {
+		if a.CompletedTasks, err = client.GetAllCompletedTasks(ctx, opts.CompletedSince, a.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	if a.Labels, err = client.GetLabels(ctx); err != nil {
+		return nil, err
+	}
+	if a.Filters, err = client.GetFilters(ctx); err != nil {
+		return nil, err
+	}
+	if a.Reminders, err = client.GetReminders(ctx); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, list := range [][]api.Task{a.Tasks, a.CompletedTasks} {
+		for _, t := range list {
+			if t.NoteCount > 0 {
+				ids = append(ids, t.ID)
+			}
+		}
+	}
+	if a.Comments, err = client.GetCommentsForTasks(ctx, ids); err != nil {
+		return nil, err
+	}
+
+	a.ProjectComments = make(map[string][]api.Comment)
+	for _, p := range a.Projects {
+		comments, err := client.GetComments(ctx, "", p.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(comments) > 0 {
+			a.ProjectComments[p.ID] = comments
+		}
+	}
+	return a, nil
+}
+
+// Write writes a to w as gzip-compressed JSON.
+func Write(w io.Writer, a *Archive) error {
+	out := *a
+	out.Version = Version
+	if out.Comments == nil {
+		out.Comments = map[string][]api.Comment{}
+	}
+	if out.ProjectComments == nil {
+		out.ProjectComments = map[string][]api.Comment{}
+	}
+
+	zw := gzip.NewWriter(w)
+	enc := json.NewEncoder(zw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		zw.Close()
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return nil
+}
+
+// Read reads an archive written by Write. Uncompressed JSON is accepted too,
+// so an archive can be edited by hand after gunzip.
+func Read(r io.Reader) (*Archive, error) {
+	br := bufio.NewReader(r)
+	var in io.Reader = br
+	if magic, err := br.Peek(2); err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup: %w", err)
+		}
+		defer zr.Close()
+		in = zr
+	}
+
+	var a Archive
+	if err := json.NewDecoder(in).Decode(&a); err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	if a.Version < 1 || a.Version > Version {
+		return nil, fmt.Errorf("unsupported backup version %d (want 1-%d)", a.Version, Version)
+	}
+	return &a, nil
+}
+
+// WriteFile writes a to path.
+func WriteFile(path string, a *Archive) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	if err := Write(f, a); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+	return nil
+}
+
+// ReadFile reads the archive at path.
+func ReadFile(path string) (*Archive, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// DefaultFileName returns the file name used when no output path is given,
+// e.g. "todoist-backup-2024-01-22.json.gz".
+func DefaultFileName(now time.Time) string {
+	return "todoist-backup-" + now.Format("2006-01-02") + ".json.gz"
+}
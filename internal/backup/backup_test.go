@@ -0,0 +1,236 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+func strPtr(s string) *string { return &s }
+
+// testArchive has a project tree, a section, nested tasks, comments and a
+// reminder.
+func testArchive() *Archive {
+	return &Archive{
+		Version: Version,
+		Projects: []api.Project{
+			{ID: "inbox", Name: "Inbox", InboxProject: true},
+			{ID: "p-backend", Name: "Backend", ParentID: strPtr("p-work"), ChildOrder: 1},
+			{ID: "p-work", Name: "Work", ChildOrder: 2, Color: "blue"},
+			{ID: "p-home", Name: "Home", ChildOrder: 1},
+		},
+		Sections: []api.Section{
+			{ID: "s-later", Name: "Later", ProjectID: "p-work", SectionOrder: 2},
+			{ID: "s-now", Name: "Now", ProjectID: "p-work", SectionOrder: 1},
+		},
+		Tasks: []api.Task{
+			{ID: "t-child", Content: "Child", ProjectID: "p-work", ParentID: strPtr("t-parent"), SectionID: strPtr("s-now"), ChildOrder: 1},
+			{ID: "t-parent", Content: "Parent", ProjectID: "p-work", SectionID: strPtr("s-now"), ChildOrder: 2, Labels: []string{"deep"}},
+			{ID: "t-api", Content: "API", ProjectID: "p-backend", ChildOrder: 1},
+			{ID: "t-inbox", Content: "Inbox task", ProjectID: "inbox", ChildOrder: 1},
+		},
+		CompletedTasks: []api.Task{
+			{ID: "t-done", Content: "Done", ProjectID: "p-work", ChildOrder: 3, CompletedAt: strPtr("2024-01-20T10:00:00Z"),
+				Due: &api.Due{Date: "2024-01-20", String: "every day", IsRecurring: true}},
+		},
+		Labels:    []api.Label{{ID: "l1", Name: "deep"}, {ID: "l2", Name: "errand"}},
+		Filters:   []api.Filter{{ID: "f1", Name: "Focus", Query: "@deep"}},
+		Reminders: []api.Reminder{{ID: "r1", ItemID: "t-parent", Type: "relative", MinuteOffset: 30}},
+		Comments: map[string][]api.Comment{
+			"t-parent": {{ID: "c1", Content: "See notes"}},
+		},
+		ProjectComments: map[string][]api.Comment{
+			"p-work": {{ID: "c2", Content: "Project note"}},
+		},
+	}
+}
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testArchive()); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte{0x1f, 0x8b}) {
+		t.Fatal("expected gzip output")
+	}
+
+	a, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if a.Version != Version || len(a.Projects) != 4 || len(a.CompletedTasks) != 1 {
+		t.Errorf("unexpected archive: version %d, %d projects, %d completed", a.Version, len(a.Projects), len(a.CompletedTasks))
+	}
+	if a.Comments["t-parent"][0].Content != "See notes" {
+		t.Errorf("comments not preserved: %v", a.Comments)
+	}
+}
+
+func TestRead_PlainJSONAndVersion(t *testing.T) {
+	if _, err := Read(strings.NewReader(`{"version": 1, "projects": []}`)); err != nil {
+		t.Errorf("expected plain JSON to be accepted, got %v", err)
+	}
+	if _, err := Read(strings.NewReader(`{"version": 99}`)); err == nil {
+		t.Error("expected a newer version to be rejected")
+	}
+}
+
+// commandArgs returns the arguments of cmd as a map.
+func commandArgs(t *testing.T, cmd api.SyncCommand) map[string]interface{} {
+	t.Helper()
+	args, ok := cmd.Args.(map[string]interface{})
+	if !ok {
+		t.Fatalf("unexpected args type %T", cmd.Args)
+	}
+	return args
+}
+
+func TestNewPlan_KeepsOrderAndNesting(t *testing.T) {
+	plan, err := NewPlan(testArchive(), RestoreOptions{InboxID: "new-inbox", Completed: true})
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+
+	tempIDs := make(map[string]string) // Name or content to temp ID
+	var order []string
+	for _, cmd := range plan.Commands {
+		args, _ := cmd.Args.(map[string]interface{})
+		switch cmd.Type {
+		case "project_add", "section_add":
+			tempIDs[args["name"].(string)] = cmd.TempID
+			order = append(order, args["name"].(string))
+		case "item_add":
+			tempIDs[args["content"].(string)] = cmd.TempID
+			order = append(order, args["content"].(string))
+		}
+	}
+
+	want := []string{"Home", "Work", "Backend", "Now", "Later", "Inbox task", "Parent", "Child", "Done", "API"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+
+	for _, cmd := range plan.Commands {
+		args, _ := cmd.Args.(map[string]interface{})
+		switch {
+		case cmd.Type == "project_add" && args["name"] == "Backend":
+			if args["parent_id"] != tempIDs["Work"] {
+				t.Errorf("Backend parent = %v, want Work's temp ID", args["parent_id"])
+			}
+		case cmd.Type == "item_add" && args["content"] == "Child":
+			if args["parent_id"] != tempIDs["Parent"] || args["section_id"] != tempIDs["Now"] {
+				t.Errorf("Child parent/section = %v/%v", args["parent_id"], args["section_id"])
+			}
+		case cmd.Type == "item_add" && args["content"] == "Inbox task":
+			if args["project_id"] != "new-inbox" {
+				t.Errorf("Inbox task project = %v, want the current Inbox", args["project_id"])
+			}
+		case cmd.Type == "item_add" && args["content"] == "Done":
+			if _, ok := args["due"]; ok {
+				t.Error("expected the recurring due date of a completed task to be dropped")
+			}
+		case cmd.Type == "item_complete":
+			if args["id"] != tempIDs["Done"] || args["completed_at"] != "2024-01-20T10:00:00Z" {
+				t.Errorf("unexpected completion %v", args)
+			}
+		case cmd.Type == "reminder_add":
+			if args["item_id"] != tempIDs["Parent"] {
+				t.Errorf("reminder item = %v, want Parent's temp ID", args["item_id"])
+			}
+		}
+	}
+
+	if plan.Projects != 3 || plan.Sections != 2 || plan.Tasks != 4 || plan.CompletedTasks != 1 ||
+		plan.Comments != 2 || plan.Reminders != 1 || plan.Labels != 2 || plan.Filters != 1 {
+		t.Errorf("unexpected counts: %s", plan.Summary())
+	}
+}
+
+func TestNewPlan_SingleProject(t *testing.T) {
+	plan, err := NewPlan(testArchive(), RestoreOptions{
+		Project: "work",
+		Labels:  []api.Label{{Name: "Errand"}},
+	})
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+
+	for _, cmd := range plan.Commands {
+		args := commandArgs(t, cmd)
+		if cmd.Type == "project_add" && args["name"] == "Work" {
+			if _, ok := args["parent_id"]; ok {
+				t.Error("expected the restored root to be top-level")
+			}
+		}
+		if cmd.Type == "filter_add" {
+			t.Error("filters should only be restored with everything")
+		}
+	}
+	// Work and Backend; only the label used by the restored tasks
+	if plan.Projects != 2 || plan.Tasks != 3 || plan.CompletedTasks != 0 || plan.Labels != 1 {
+		t.Errorf("unexpected counts: %s", plan.Summary())
+	}
+
+	if _, err := NewPlan(testArchive(), RestoreOptions{Project: "Missing"}); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+}
+
+func TestPlanExecute(t *testing.T) {
+	var sent int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		var commands []api.SyncCommand
+		if err := json.Unmarshal([]byte(r.Form.Get("commands")), &commands); err != nil {
+			t.Fatalf("failed to unmarshal commands: %v", err)
+		}
+		sent += len(commands)
+
+		status := map[string]interface{}{}
+		mapping := map[string]string{}
+		for _, cmd := range commands {
+			status[cmd.UUID] = "ok"
+			if cmd.Type == "filter_add" {
+				status[cmd.UUID] = map[string]interface{}{"error": "Invalid query"}
+			}
+			if cmd.TempID != "" {
+				mapping[cmd.TempID] = "id-" + cmd.TempID
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"sync_status": status, "temp_id_mapping": mapping})
+	}))
+	defer server.Close()
+
+	client := api.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+
+	plan, err := NewPlan(testArchive(), RestoreOptions{})
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	report := plan.Execute(context.Background(), client)
+	if sent != len(plan.Commands) {
+		t.Errorf("sent %d of %d commands", sent, len(plan.Commands))
+	}
+	if len(report.Failures) != 1 || !strings.Contains(report.Failures[0].Error(), `filter "Focus"`) {
+		t.Errorf("unexpected failures: %v", report.Failures)
+	}
+	if report.Applied != len(plan.Commands)-1 {
+		t.Errorf("applied %d, want %d", report.Applied, len(plan.Commands)-1)
+	}
+}
+
+func TestDefaultFileName(t *testing.T) {
+	got := DefaultFileName(time.Date(2024, 1, 22, 15, 0, 0, 0, time.UTC))
+	if got != "todoist-backup-2024-01-22.json.gz" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,342 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// ErrProjectNotFound is returned by NewPlan when the project to restore is
+// not in the archive.
+var ErrProjectNotFound = errors.New("project not found in backup")
+
+// RestoreOptions controls what Plan recreates.
+type RestoreOptions struct {
+	// Project limits the restore to one project (name or ID in the archive)
+	// and its subprojects. Empty restores every project, plus labels and
+	// filters.
+	Project string
+	// InboxID is the current Inbox. Tasks and sections of the archived Inbox
+	// are restored into it instead of a new project named "Inbox".
+	InboxID string
+	// Completed also recreates completed tasks and completes them again.
+	Completed bool
+	// Labels and Filters already in the account; those with the same name
+	// are not created again.
+	Labels  []api.Label
+	Filters []api.Filter
+}
+
+// Plan is the list of Sync commands that recreate part of an archive.
+type Plan struct {
+	Commands []api.SyncCommand
+
+	Projects, Sections, Tasks, CompletedTasks int
+	Comments, Reminders, Labels, Filters      int
+
+	describe map[string]string // Command UUID to what it creates, for errors
+}
+
+// Report is the outcome of Plan.Execute.
+type Report struct {
+	Applied  int
+	Failures []error
+}
+
+// NewPlan builds the commands that recreate the projects selected by opts.
+// Every object gets a new ID; parents, sections and positions are kept by
+// referring to the temp IDs of the commands that create them.
+func NewPlan(a *Archive, opts RestoreOptions) (*Plan, error) {
+	projects, err := selectProjects(a.Projects, opts.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Plan{describe: make(map[string]string)}
+	ids := make(map[string]string) // Archived ID to temp ID
+
+	selected := make(map[string]int, len(projects)) // Project ID to position
+	for i, proj := range projects {
+		selected[proj.ID] = i
+		if proj.InboxProject && opts.InboxID != "" {
+			ids[proj.ID] = opts.InboxID
+			continue
+		}
+		if proj.ParentID != nil && ids[*proj.ParentID] != "" {
+			proj.ParentID = ptr(ids[*proj.ParentID])
+		} else {
+			proj.ParentID = nil
+		}
+		cmd := api.RestoreProjectCommand(proj)
+		ids[proj.ID] = cmd.TempID
+		p.add(cmd, fmt.Sprintf("project %q", proj.Name))
+		p.Projects++
+	}
+
+	sections := append([]api.Section(nil), a.Sections...)
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].SectionOrder < sections[j].SectionOrder
+	})
+	for _, s := range sections {
+		if _, ok := selected[s.ProjectID]; !ok {
+			continue
+		}
+		s.ProjectID = ids[s.ProjectID]
+		cmd := api.RestoreSectionCommand(s)
+		ids[s.ID] = cmd.TempID
+		p.add(cmd, fmt.Sprintf("section %q", s.Name))
+		p.Sections++
+	}
+
+	tasks := selectTasks(a, selected, opts.Completed)
+	usedLabels := make(map[string]bool)
+	var completed []api.SyncCommand
+	for _, t := range tasks {
+		for _, l := range t.Labels {
+			usedLabels[strings.ToLower(l)] = true
+		}
+		archivedID := t.ID
+		t.ProjectID = ids[t.ProjectID]
+		if t.SectionID != nil {
+			if id, ok := ids[*t.SectionID]; ok {
+				t.SectionID = ptr(id)
+			} else {
+				t.SectionID = nil
+			}
+		}
+		if t.ParentID != nil {
+			if id, ok := ids[*t.ParentID]; ok {
+				t.ParentID = ptr(id)
+			} else {
+				t.ParentID = nil
+			}
+		}
+		// Restored projects are not shared, so nobody can be assigned
+		t.ResponsibleUID = nil
+		if t.Checked && t.Due != nil && t.Due.IsRecurring {
+			// A completed recurring task would come back as a new series
+			t.Due = nil
+		}
+
+		cmd := api.RestoreTaskCommand(t)
+		ids[archivedID] = cmd.TempID
+		p.add(cmd, fmt.Sprintf("task %q", t.Content))
+		if t.Checked {
+			p.CompletedTasks++
+			completed = append(completed, api.CompleteTaskCommand(cmd.TempID, deref(t.CompletedAt)))
+		} else {
+			p.Tasks++
+		}
+
+		for _, c := range a.Comments[archivedID] {
+			p.add(commentCommand(api.AddCommentCommand(cmd.TempID, c.Content), c),
+				fmt.Sprintf("comment on task %q", t.Content))
+			p.Comments++
+		}
+	}
+
+	for _, proj := range projects {
+		for _, c := range a.ProjectComments[proj.ID] {
+			p.add(commentCommand(api.AddProjectCommentCommand(ids[proj.ID], c.Content), c),
+				fmt.Sprintf("comment on project %q", proj.Name))
+			p.Comments++
+		}
+	}
+
+	for _, r := range a.Reminders {
+		id, ok := ids[r.ItemID]
+		if !ok || r.IsDeleted {
+			continue
+		}
+		r.ItemID = id
+		p.add(api.RestoreReminderCommand(r), "reminder")
+		p.Reminders++
+	}
+
+	// Complete tasks last, children before parents, so completing a parent
+	// does not touch subtasks that are still to be completed with their own
+	// date
+	for i := len(completed) - 1; i >= 0; i-- {
+		p.add(completed[i], "completion")
+	}
+
+	existingLabels := make(map[string]bool, len(opts.Labels))
+	for _, l := range opts.Labels {
+		existingLabels[strings.ToLower(l.Name)] = true
+	}
+	for _, l := range a.Labels {
+		name := strings.ToLower(l.Name)
+		if existingLabels[name] || l.IsDeleted || (opts.Project != "" && !usedLabels[name]) {
+			continue
+		}
+		p.add(api.RestoreLabelCommand(l), fmt.Sprintf("label %q", l.Name))
+		p.Labels++
+	}
+
+	if opts.Project == "" {
+		existingFilters := make(map[string]bool, len(opts.Filters))
+		for _, f := range opts.Filters {
+			existingFilters[strings.ToLower(f.Name)] = true
+		}
+		for _, f := range a.Filters {
+			if existingFilters[strings.ToLower(f.Name)] || f.IsDeleted {
+				continue
+			}
+			p.add(api.RestoreFilterCommand(f), fmt.Sprintf("filter %q", f.Name))
+			p.Filters++
+		}
+	}
+
+	return p, nil
+}
+
+// Summary describes what the plan creates, e.g. for a dry run.
+func (p *Plan) Summary() string {
+	return fmt.Sprintf("%d projects, %d sections, %d tasks, %d completed tasks, %d comments, %d reminders, %d labels, %d filters",
+		p.Projects, p.Sections, p.Tasks, p.CompletedTasks, p.Comments, p.Reminders, p.Labels, p.Filters)
+}
+
+// Execute sends the plan in batches. Failed commands are reported with what
+// they would have created; a request failure stops the restore.
+func (p *Plan) Execute(ctx context.Context, client *api.Client) *Report {
+	result := client.ExecuteBatched(ctx, p.Commands)
+	report := &Report{}
+	for _, cmd := range p.Commands {
+		if err := result.Errors[cmd.UUID]; err != nil {
+			report.Failures = append(report.Failures, fmt.Errorf("%s: %w", p.describe[cmd.UUID], err))
+		} else {
+			report.Applied++
+		}
+	}
+	return report
+}
+
+// add appends cmd to the plan.
+func (p *Plan) add(cmd api.SyncCommand, what string) {
+	p.Commands = append(p.Commands, cmd)
+	p.describe[cmd.UUID] = what
+}
+
+// selectProjects returns the projects to restore, parents before children
+// and siblings in their original order. An empty name selects all.
+func selectProjects(all []api.Project, name string) ([]api.Project, error) {
+	children := make(map[string][]api.Project)
+	present := make(map[string]bool, len(all))
+	for _, p := range all {
+		present[p.ID] = true
+	}
+	var roots []api.Project
+	var root *api.Project
+	for i, p := range all {
+		if p.ParentID != nil && present[*p.ParentID] {
+			children[*p.ParentID] = append(children[*p.ParentID], p)
+		} else {
+			roots = append(roots, p)
+		}
+		if name != "" && root == nil && (p.ID == name || strings.EqualFold(p.Name, name)) {
+			root = &all[i]
+		}
+	}
+	if name != "" {
+		if root == nil {
+			return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, name)
+		}
+		roots = []api.Project{*root}
+	}
+
+	var result []api.Project
+	var walk func(list []api.Project)
+	walk = func(list []api.Project) {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].InboxProject != list[j].InboxProject {
+				return list[i].InboxProject
+			}
+			return list[i].ChildOrder < list[j].ChildOrder
+		})
+		for _, p := range list {
+			result = append(result, p)
+			walk(children[p.ID])
+		}
+	}
+	walk(roots)
+	return result, nil
+}
+
+// selectTasks returns the tasks of the selected projects, in project order,
+// parents before subtasks and siblings by child order. projects maps the
+// selected project IDs to their position.
+func selectTasks(a *Archive, projects map[string]int, withCompleted bool) []api.Task {
+	var tasks []api.Task
+	seen := make(map[string]bool)
+	for _, t := range a.Tasks {
+		if _, ok := projects[t.ProjectID]; ok {
+			tasks = append(tasks, t)
+			seen[t.ID] = true
+		}
+	}
+	if withCompleted {
+		for _, t := range a.CompletedTasks {
+			// A recurring task shows up once per completion; keep the
+			// active copy, or the latest completion
+			if _, ok := projects[t.ProjectID]; ok && !seen[t.ID] {
+				t.Checked = true
+				tasks = append(tasks, t)
+				seen[t.ID] = true
+			}
+		}
+	}
+
+	present := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		present[t.ID] = true
+	}
+	children := make(map[string][]api.Task)
+	var roots []api.Task
+	for _, t := range tasks {
+		if t.ParentID != nil && present[*t.ParentID] {
+			children[*t.ParentID] = append(children[*t.ParentID], t)
+		} else {
+			roots = append(roots, t)
+		}
+	}
+
+	var result []api.Task
+	var walk func(list []api.Task)
+	walk = func(list []api.Task) {
+		sort.SliceStable(list, func(i, j int) bool {
+			if pi, pj := projects[list[i].ProjectID], projects[list[j].ProjectID]; pi != pj {
+				return pi < pj
+			}
+			return list[i].ChildOrder < list[j].ChildOrder
+		})
+		for _, t := range list {
+			result = append(result, t)
+			walk(children[t.ID])
+		}
+	}
+	walk(roots)
+	return result
+}
+
+// commentCommand adds the file attachment of c, if any, to a note command.
+func commentCommand(cmd api.SyncCommand, c api.Comment) api.SyncCommand {
+	if c.FileAttachment != nil {
+		cmd.Args.(map[string]interface{})["file_attachment"] = c.FileAttachment
+	}
+	return cmd
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,150 @@
+// Package cache persists the last known Todoist data on disk so the UI can
+// render immediately on startup while a fresh sync runs in the background.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// SchemaVersion identifies the layout of the cache file.
+// Bump it whenever the cached API types change incompatibly; caches written
+// with a different version are discarded instead of being decoded.
+const SchemaVersion = 1
+
+const fileName = "cache.json"
+
+// Snapshot is the cached copy of the user's data.
+type Snapshot struct {
+	Version   int            `json:"version"`
+	SavedAt   time.Time      `json:"saved_at"`
+	SyncToken string         `json:"sync_token"`
+	Tasks     []api.Task     `json:"tasks"`
+	Projects  []api.Project  `json:"projects"`
+	Sections  []api.Section  `json:"sections"`
+	Labels    []api.Label    `json:"labels"`
+	Filters   []api.Filter   `json:"filters"`
+	Reminders []api.Reminder `json:"reminders"`
+	User      *api.User      `json:"user,omitempty"`
+}
+
+// NewSnapshot builds a snapshot from a sync result and the token it was synced at.
+func NewSnapshot(token string, result *api.SyncResult) *Snapshot {
+	return &Snapshot{
+		Version:   SchemaVersion,
+		SavedAt:   time.Now(),
+		SyncToken: token,
+		Tasks:     result.Tasks,
+		Projects:  result.Projects,
+		Sections:  result.Sections,
+		Labels:    result.Labels,
+		Filters:   result.Filters,
+		Reminders: result.Reminders,
+		User:      result.User,
+	}
+}
+
+// SyncResult converts the snapshot back into the shape returned by the Sync API client.
+func (s *Snapshot) SyncResult() *api.SyncResult {
+	return &api.SyncResult{
+		Tasks:     s.Tasks,
+		Projects:  s.Projects,
+		Sections:  s.Sections,
+		Labels:    s.Labels,
+		Filters:   s.Filters,
+		Reminders: s.Reminders,
+		User:      s.User,
+	}
+}
+
+// Store reads and writes snapshots in a directory.
+type Store struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewStore creates a store that keeps its file in dir.
+func NewStore(dir string) *Store {
+	return &Store{path: filepath.Join(dir, fileName)}
+}
+
+// Path returns the location of the cache file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads the cached snapshot.
+// Returns nil without error if there is no cache, it was written by another
+// schema version, or it cannot be decoded.
+func (s *Store) Load() (*Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	// Check the version before decoding the payload so incompatible layouts are never parsed
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil || header.Version != SchemaVersion {
+		return nil, nil
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, nil
+	}
+
+	return &snap, nil
+}
+
+// Save writes the snapshot atomically.
+func (s *Store) Save(snap *Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap.Version = SchemaVersion
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write to a temp file and rename so a crash never leaves a truncated cache
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+
+	return nil
+}
+
+// Clear removes the cache file.
+func (s *Store) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+func TestStore_SaveLoad(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	snap := NewSnapshot("token-1", &api.SyncResult{
+		Tasks:     []api.Task{{ID: "t1", Content: "Buy milk", Due: &api.Due{Date: "2026-01-02"}}},
+		Projects:  []api.Project{{ID: "p1", Name: "Inbox", InboxProject: true}},
+		Sections:  []api.Section{{ID: "s1", Name: "Backlog"}},
+		Labels:    []api.Label{{ID: "l1", Name: "work"}},
+		Filters:   []api.Filter{{ID: "f1", Query: "today"}},
+		Reminders: []api.Reminder{{ID: "r1", ItemID: "t1"}},
+	})
+
+	if err := store.Save(snap); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded == nil {
+		t.Fatal("expected snapshot, got nil")
+	}
+	if loaded.SyncToken != "token-1" {
+		t.Errorf("expected token-1, got %s", loaded.SyncToken)
+	}
+	if len(loaded.Tasks) != 1 || loaded.Tasks[0].Due == nil || loaded.Tasks[0].Due.Date != "2026-01-02" {
+		t.Errorf("tasks not restored: %+v", loaded.Tasks)
+	}
+
+	result := loaded.SyncResult()
+	if len(result.Projects) != 1 || len(result.Sections) != 1 || len(result.Labels) != 1 ||
+		len(result.Filters) != 1 || len(result.Reminders) != 1 {
+		t.Errorf("unexpected resource counts: %+v", result)
+	}
+}
+
+func TestStore_Load(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "missing file"},
+		{name: "older schema version", content: `{"version": 0, "tasks": [{"id": "t1"}]}`},
+		{name: "newer schema version", content: `{"version": 99, "tasks": "not-a-list"}`},
+		{name: "corrupt file", content: `{"version": 1, "tasks": [`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore(t.TempDir())
+			if tt.content != "" {
+				if err := os.WriteFile(store.Path(), []byte(tt.content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			snap, err := store.Load()
+			if err != nil {
+				t.Errorf("Load() error = %v", err)
+			}
+			if snap != nil {
+				t.Errorf("expected cache to be discarded, got %+v", snap)
+			}
+		})
+	}
+}
+
+func TestStore_Clear(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if err := store.Save(&Snapshot{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if _, err := os.Stat(store.Path()); !os.IsNotExist(err) {
+		t.Error("expected cache file to be removed")
+	}
+	// Clearing twice is not an error
+	if err := store.Clear(); err != nil {
+		t.Errorf("second Clear() error = %v", err)
+	}
+}
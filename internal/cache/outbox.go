@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+const outboxFileName = "outbox.json"
+
+// maxCommandsPerSync is the number of queued commands sent per request.
+const maxCommandsPerSync = api.MaxCommandsPerSync
+
+// Outbox is a persistent, ordered queue of Sync API commands that could not
+// be sent because the network was unavailable.
+type Outbox struct {
+	mu       sync.Mutex
+	path     string
+	commands []api.SyncCommand
+}
+
+// outboxFile is the on-disk layout of the outbox.
+type outboxFile struct {
+	Version  int               `json:"version"`
+	Commands []api.SyncCommand `json:"commands"`
+}
+
+// FailedCommand is a queued command the server rejected during a flush.
+type FailedCommand struct {
+	Command api.SyncCommand
+	Err     error
+}
+
+// FlushResult describes the outcome of sending the outbox.
+type FlushResult struct {
+	// Sent is the number of commands accepted by the server.
+	Sent int
+	// Failed lists commands rejected by the server; they are dropped from the queue.
+	Failed []FailedCommand
+	// TempIDMapping maps temp IDs of created resources to their real IDs.
+	TempIDMapping map[string]string
+}
+
+// NewOutbox opens the outbox stored in dir, loading any pending commands.
+// An unreadable or incompatible file is treated as empty.
+func NewOutbox(dir string) *Outbox {
+	o := &Outbox{path: filepath.Join(dir, outboxFileName)}
+
+	data, err := os.ReadFile(o.path)
+	if err != nil {
+		return o
+	}
+
+	var f outboxFile
+	if err := json.Unmarshal(data, &f); err == nil && f.Version == SchemaVersion {
+		o.commands = f.Commands
+	}
+
+	return o
+}
+
+// Add appends commands to the queue and persists it.
+func (o *Outbox) Add(cmds ...api.SyncCommand) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.commands = append(o.commands, cmds...)
+	return o.saveLocked()
+}
+
+// Len returns the number of pending commands.
+func (o *Outbox) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.commands)
+}
+
+// Commands returns a copy of the pending commands in queue order.
+func (o *Outbox) Commands() []api.SyncCommand {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]api.SyncCommand(nil), o.commands...)
+}
+
+// Flush replays pending commands in order.
+// Commands are removed once the server has processed them, whether they
+// succeeded or were rejected, alone or as a whole batch. A network failure,
+// rate limit, server error or revoked token stops the flush and keeps the
+// remaining commands queued.
+func (o *Outbox) Flush(ctx context.Context, client *api.Client) (*FlushResult, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	result := &FlushResult{TempIDMapping: make(map[string]string)}
+
+	for len(o.commands) > 0 {
+		n := min(len(o.commands), maxCommandsPerSync)
+		batch := make([]api.SyncCommand, n)
+		for i, cmd := range o.commands[:n] {
+			// Temp IDs created by an earlier batch are only known by their real ID now
+			batch[i] = api.ReplaceCommandIDs(cmd, result.TempIDMapping)
+		}
+
+		resp, err := client.ExecuteCommands(ctx, batch)
+		if err != nil && !batchRejected(err) {
+			return result, err
+		}
+		if err != nil {
+			// Sending the batch again would be refused again and block the queue
+			for _, cmd := range batch {
+				result.Failed = append(result.Failed, FailedCommand{Command: cmd, Err: err})
+			}
+			o.commands = o.commands[n:]
+			if err := o.saveLocked(); err != nil {
+				return result, err
+			}
+			continue
+		}
+
+		for tempID, realID := range resp.TempIDMapping {
+			result.TempIDMapping[tempID] = realID
+		}
+		for _, cmd := range batch {
+			if cmdErr := resp.CommandError(cmd.UUID); cmdErr != nil {
+				result.Failed = append(result.Failed, FailedCommand{Command: cmd, Err: cmdErr})
+			} else {
+				result.Sent++
+			}
+		}
+
+		o.commands = o.commands[n:]
+		if err := o.saveLocked(); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// batchRejected reports whether the server refused a whole batch for what it
+// contains, so retrying it can't succeed.
+func batchRejected(err error) bool {
+	apiErr, ok := api.IsAPIError(err)
+	return ok && !apiErr.IsUnauthorized() && !apiErr.IsRateLimited() && !apiErr.IsServerError()
+}
+
+// saveLocked writes the queue to disk, removing the file once it is empty.
+func (o *Outbox) saveLocked() error {
+	if len(o.commands) == 0 {
+		if err := os.Remove(o.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove outbox: %w", err)
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(outboxFile{Version: SchemaVersion, Commands: o.commands})
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(o.path), 0700); err != nil {
+		return fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	tmp := o.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+	if err := os.Rename(tmp, o.path); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+	return nil
+}
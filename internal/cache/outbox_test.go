@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// newSyncServer returns a server that accepts every command, assigning real IDs to temp IDs.
+func newSyncServer(t *testing.T, received *[][]api.SyncCommand, reject map[string]bool) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		var commands []api.SyncCommand
+		if err := json.Unmarshal([]byte(r.Form.Get("commands")), &commands); err != nil {
+			t.Fatalf("failed to unmarshal commands: %v", err)
+		}
+		*received = append(*received, commands)
+
+		mapping := map[string]string{}
+		status := map[string]interface{}{}
+		for i, cmd := range commands {
+			if cmd.TempID != "" {
+				mapping[cmd.TempID] = fmt.Sprintf("real-%d-%d", len(*received), i)
+			}
+			if reject[cmd.Type] {
+				status[cmd.UUID] = map[string]interface{}{"error_code": 20, "error": "rejected"}
+			} else {
+				status[cmd.UUID] = "ok"
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"temp_id_mapping": mapping,
+			"sync_status":     status,
+		})
+	}))
+}
+
+func TestOutbox_PersistsAcrossRestarts(t *testing.T) {
+	dir := t.TempDir()
+
+	outbox := NewOutbox(dir)
+	add := api.AddTaskCommand(api.CreateTaskRequest{Content: "Offline task"})
+	if err := outbox.Add(add, api.CloseTaskCommand("t1")); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	reopened := NewOutbox(dir)
+	if reopened.Len() != 2 {
+		t.Fatalf("expected 2 pending commands, got %d", reopened.Len())
+	}
+	cmds := reopened.Commands()
+	if cmds[0].UUID != add.UUID || cmds[0].TempID != add.TempID || cmds[1].Type != "item_close" {
+		t.Errorf("commands not restored in order: %+v", cmds)
+	}
+}
+
+func TestOutbox_Flush(t *testing.T) {
+	var received [][]api.SyncCommand
+	server := newSyncServer(t, &received, map[string]bool{"item_delete": true})
+	defer server.Close()
+
+	client := api.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+
+	outbox := NewOutbox(t.TempDir())
+
+	// Fill more than one batch; the last command refers to the first task's temp ID
+	add := api.AddTaskCommand(api.CreateTaskRequest{Content: "Parent"})
+	cmds := []api.SyncCommand{add}
+	for i := 0; i < maxCommandsPerSync; i++ {
+		cmds = append(cmds, api.CloseTaskCommand(fmt.Sprintf("t%d", i)))
+	}
+	cmds = append(cmds, api.DeleteTaskCommand(add.TempID))
+	if err := outbox.Add(cmds...); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := outbox.Flush(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 batches, got %d", len(received))
+	}
+	realID := result.TempIDMapping[add.TempID]
+	if realID == "" {
+		t.Fatal("expected temp ID to be resolved")
+	}
+	last := received[1][len(received[1])-1]
+	args := last.Args.(map[string]interface{})
+	if args["id"] != realID {
+		t.Errorf("expected later batch to use real ID %s, got %v", realID, args["id"])
+	}
+
+	if result.Sent != maxCommandsPerSync+1 {
+		t.Errorf("expected %d sent, got %d", maxCommandsPerSync+1, result.Sent)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Command.Type != "item_delete" {
+		t.Errorf("expected the delete to be reported as failed, got %+v", result.Failed)
+	}
+	if outbox.Len() != 0 {
+		t.Errorf("expected empty outbox, got %d", outbox.Len())
+	}
+}
+
+func TestOutbox_FlushKeepsCommandsWhenOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	client := api.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+	server.Close() // Simulate lost connectivity
+
+	outbox := NewOutbox(t.TempDir())
+	outbox.Add(api.CloseTaskCommand("t1"))
+
+	_, err := outbox.Flush(context.Background(), client)
+	if err == nil {
+		t.Fatal("expected an error while offline")
+	}
+	if !api.IsNetworkError(err) {
+		t.Errorf("expected a network error, got %v", err)
+	}
+	if outbox.Len() != 1 {
+		t.Errorf("expected the command to stay queued, got %d", outbox.Len())
+	}
+}
+
+func TestOutbox_FlushDropsRejectedBatch(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "Invalid argument value", "error_code": 20}`))
+	}))
+	defer server.Close()
+	client := api.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+
+	outbox := NewOutbox(t.TempDir())
+	outbox.Add(api.CloseTaskCommand("t1"), api.CloseTaskCommand("t2"))
+
+	result, err := outbox.Flush(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(result.Failed) != 2 || result.Failed[0].Err == nil {
+		t.Errorf("expected both commands to be reported as failed, got %+v", result.Failed)
+	}
+	if outbox.Len() != 0 {
+		t.Errorf("expected the rejected batch to be dropped, got %d", outbox.Len())
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// runLogin signs in and stores the token. The token is checked against the
+// API before it replaces the stored one.
+func (r *Runner) runLogin(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("login")
+	withToken := fs.Bool("with-token", false, "read an API token from stdin instead of signing in through the browser")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("unexpected argument %q", positional[0])
+	}
+
+	var token string
+	if !*withToken && r.OAuthLogin != nil {
+		token, err = r.OAuthLogin(ctx)
+		if err != nil {
+			return err
+		}
+	} else {
+		if !*withToken {
+			fmt.Fprintln(r.Stderr, "Paste your API token from Todoist Settings > Integrations > Developer.")
+			fmt.Fprint(r.Stderr, "API token: ")
+		}
+		token, err = readToken(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := r.NewClientWithToken(token)
+	if err != nil {
+		return err
+	}
+	user, err := client.GetUser(ctx)
+	if err != nil {
+		return fmt.Errorf("token rejected: %w", err)
+	}
+
+	if err := r.SaveToken(token); err != nil {
+		return fmt.Errorf("failed to save token: %w", err)
+	}
+	fmt.Fprintf(r.Stdout, "Logged in as %s\n", formatUser(user.FullName, user.Email))
+	return nil
+}
+
+// readToken reads a token from the first line of stdin.
+func readToken(r *Runner) (string, error) {
+	if r.Stdin == nil {
+		return "", errors.New("no input to read the token from")
+	}
+	line, err := bufio.NewReader(r.Stdin).ReadString('\n')
+	token := strings.TrimSpace(line)
+	if token == "" {
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("failed to read token: %w", err)
+		}
+		return "", usagef("no token provided")
+	}
+	return token, nil
+}
+
+// runLogout removes the stored token.
+func (r *Runner) runLogout(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("logout")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("unexpected argument %q", positional[0])
+	}
+
+	if err := r.ClearToken(); err != nil {
+		return err
+	}
+	fmt.Fprintln(r.Stdout, "Logged out")
+	return nil
+}
+
+// userRecord is the scripting-friendly representation of the account.
+type userRecord struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Timezone string `json:"timezone"`
+	Premium  bool   `json:"premium"`
+}
+
+// runWhoami prints the account the stored token belongs to.
+func (r *Runner) runWhoami(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("whoami")
+	format := fs.String("format", formatTable, "output format: table or json")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("unexpected argument %q", positional[0])
+	}
+	if *format != formatTable && *format != formatJSON {
+		return usagef("unknown format %q (want table or json)", *format)
+	}
+
+	client, err := r.apiClient()
+	if err != nil {
+		return err
+	}
+	user, err := client.GetUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	rec := userRecord{
+		ID:       user.ID,
+		Name:     user.FullName,
+		Email:    user.Email,
+		Timezone: user.TZInfo.Timezone,
+		Premium:  user.IsPremium,
+	}
+	if *format == formatJSON {
+		return writeJSON(r.Stdout, rec)
+	}
+	fmt.Fprintln(r.Stdout, formatUser(rec.Name, rec.Email))
+	fmt.Fprintf(r.Stdout, "ID:       %s\n", rec.ID)
+	if rec.Timezone != "" {
+		fmt.Fprintf(r.Stdout, "Timezone: %s\n", rec.Timezone)
+	}
+	plan := "Free"
+	if rec.Premium {
+		plan = "Pro"
+	}
+	fmt.Fprintf(r.Stdout, "Plan:     %s\n", plan)
+	return nil
+}
+
+// formatUser returns "Name <email>", or whichever of the two is set.
+func formatUser(name, email string) string {
+	switch {
+	case name == "":
+		return email
+	case email == "":
+		return name
+	}
+	return fmt.Sprintf("%s <%s>", name, email)
+}
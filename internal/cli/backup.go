@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/backup"
+)
+
+// runBackup saves the whole account to a single archive file.
+func (r *Runner) runBackup(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("backup")
+	output := fs.String("output", "", "archive to write (default: todoist-backup-DATE.json.gz)")
+	completedDays := fs.Int("completed-days", 365, "days of completed tasks to include (0 to leave them out)")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("unexpected argument %q", positional[0])
+	}
+	if *completedDays < 0 {
+		return usagef("--completed-days must not be negative")
+	}
+
+	client, err := r.apiClient()
+	if err != nil {
+		return err
+	}
+
+	var opts backup.Options
+	if *completedDays > 0 {
+		opts.CompletedSince = time.Now().AddDate(0, 0, -*completedDays)
+	}
+	archive, err := backup.Create(ctx, client, opts)
+	if err != nil {
+		return err
+	}
+
+	path := *output
+	if path == "" {
+		path = backup.DefaultFileName(archive.CreatedAt)
+	}
+	if err := backup.WriteFile(path, archive); err != nil {
+		return err
+	}
+	fmt.Fprintf(r.Stdout, "Saved %d projects, %d tasks and %d completed tasks to %s\n",
+		len(archive.Projects), len(archive.Tasks), len(archive.CompletedTasks), path)
+	return nil
+}
+
+// runRestore recreates projects from an archive written by runBackup.
+func (r *Runner) runRestore(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("restore")
+	project := fs.String("project", "", "only restore this project and its subprojects (name or ID in the backup)")
+	completed := fs.Bool("completed", false, "also restore completed tasks")
+	dryRun := fs.Bool("dry-run", false, "show what would be created without changing anything")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("exactly one backup file is required")
+	}
+
+	archive, err := backup.ReadFile(positional[0])
+	if err != nil {
+		return err
+	}
+
+	opts := backup.RestoreOptions{Project: *project, Completed: *completed}
+	if !*dryRun {
+		client, err := r.apiClient()
+		if err != nil {
+			return err
+		}
+		projects, err := client.GetProjects(ctx)
+		if err != nil {
+			return err
+		}
+		for _, p := range projects {
+			if p.InboxProject {
+				opts.InboxID = p.ID
+			}
+		}
+		if opts.Labels, err = client.GetLabels(ctx); err != nil {
+			return err
+		}
+		if opts.Filters, err = client.GetFilters(ctx); err != nil {
+			return err
+		}
+	}
+
+	plan, err := backup.NewPlan(archive, opts)
+	if errors.Is(err, backup.ErrProjectNotFound) {
+		return &notFoundError{kind: "project", name: *project}
+	} else if err != nil {
+		return err
+	}
+	if *dryRun {
+		fmt.Fprintf(r.Stdout, "Would create %s\n", plan.Summary())
+		return nil
+	}
+
+	client, err := r.apiClient()
+	if err != nil {
+		return err
+	}
+	report := plan.Execute(ctx, client)
+	fmt.Fprintf(r.Stdout, "Restored %s\n", plan.Summary())
+	fmt.Fprintf(r.Stdout, "Applied %d of %d changes\n", report.Applied, len(plan.Commands))
+	for _, e := range report.Failures {
+		fmt.Fprintf(r.Stderr, "Failed %v\n", e)
+	}
+	if len(report.Failures) > 0 {
+		return fmt.Errorf("restore finished with %d failures", len(report.Failures))
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+// Package cli implements the non-interactive subcommands of todoist-tui,
+// such as "add" and "list", for use from shell scripts and cron jobs.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// Exit codes returned by Run. They are part of the command-line interface
+// and must not change.
+const (
+	ExitOK          = 0 // Success
+	ExitError       = 1 // Unexpected failure
+	ExitUsage       = 2 // Invalid arguments or flags
+	ExitAuth        = 3 // Missing or rejected API token
+	ExitNotFound    = 4 // Task, project or label does not exist
+	ExitUnavailable = 5 // Network failure, rate limit or server error
+)
+
+// ErrNoToken is returned by Runner.NewClient when no API token is configured.
+var ErrNoToken = errors.New("no token configured. Run 'todoist-tui login' first")
+
+// usageError reports invalid command-line usage.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string { return e.msg }
+
+// usagef returns a usageError with a formatted message.
+func usagef(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// notFoundError reports a name or ID that matched nothing.
+type notFoundError struct {
+	kind, name string
+}
+
+func (e *notFoundError) Error() string {
+	return fmt.Sprintf("%s not found: %s", e.kind, e.name)
+}
+
+// command is a single subcommand.
+type command struct {
+	usage string
+	desc  string
+	run   func(r *Runner, ctx context.Context, args []string) error
+}
+
+// commands maps subcommand names to their implementation.
+var commands = map[string]command{
+	"add": {
+		usage: `add "text"`,
+		desc:  "Add a task using Quick Add syntax (dates, #project, @label, p1)",
+		run:   (*Runner).runAdd,
+	},
+	"list": {
+		usage: "list [--filter QUERY] [--project NAME] [--format table|json|csv]",
+		desc:  "List active tasks",
+		run:   (*Runner).runList,
+	},
+	"done": {
+		usage: "done ID...",
+		desc:  "Complete one or more tasks",
+		run:   (*Runner).runDone,
+	},
+	"edit": {
+		usage: "edit ID [--content TEXT] [--due DATE] [--priority 1-4]",
+		desc:  "Update a task",
+		run:   (*Runner).runEdit,
+	},
+	"projects": {
+		usage: "projects [--format table|json|csv]",
+		desc:  "List projects",
+		run:   (*Runner).runProjects,
+	},
+	"labels": {
+		usage: "labels [--format table|json|csv]",
+		desc:  "List labels",
+		run:   (*Runner).runLabels,
+	},
+	"export": {
+		usage: "export [--format md|csv|json|todo.txt] [--project NAME] [--filter QUERY] [--output PATH]",
+		desc:  "Export tasks with sections, subtasks and comments (all projects by default)",
+		run:   (*Runner).runExport,
+	},
+	"import": {
+		usage: "import [--format md|csv|todo.txt] [--project NAME] [--map FIELD=COLUMN,...] [--dry-run] FILE",
+		desc:  "Create tasks from a Markdown checklist, CSV or todo.txt file",
+		run:   (*Runner).runImport,
+	},
+	"backup": {
+		usage: "backup [--output PATH] [--completed-days N]",
+		desc:  "Save projects, sections, tasks, labels, filters, reminders and comments to one archive",
+		run:   (*Runner).runBackup,
+	},
+	"restore": {
+		usage: "restore [--project NAME] [--completed] [--dry-run] FILE",
+		desc:  "Recreate projects from a backup with new IDs, keeping order and nesting",
+		run:   (*Runner).runRestore,
+	},
+	"login": {
+		usage: "login [--with-token]",
+		desc:  "Sign in through the browser (needs an OAuth app) or paste an API token, and store it in the keyring",
+		run:   (*Runner).runLogin,
+	},
+	"logout": {
+		usage: "logout",
+		desc:  "Remove the stored API token",
+		run:   (*Runner).runLogout,
+	},
+	"whoami": {
+		usage: "whoami [--format table|json]",
+		desc:  "Show the account the stored token belongs to",
+		run:   (*Runner).runWhoami,
+	},
+	"remote": {
+		usage: "remote add TEXT | project NAME | pomodoro ID | refresh",
+		desc:  "Control a running instance (requires control.enabled in the config)",
+		run:   (*Runner).runRemote,
+	},
+}
+
+// IsCommand reports whether name is a known subcommand.
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+// Usage returns the help text listing all subcommands.
+func Usage() string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		cmd := commands[name]
+		fmt.Fprintf(&b, "    %s\n        %s\n", cmd.usage, cmd.desc)
+	}
+	return b.String()
+}
+
+// Runner executes subcommands.
+type Runner struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	// NewClient returns an authenticated API client. It is only called by
+	// commands that talk to Todoist.
+	NewClient func() (*api.Client, error)
+	// NewClientWithToken returns an API client for token, which login checks
+	// before storing it.
+	NewClientWithToken func(token string) (*api.Client, error)
+	// OAuthLogin signs in through the browser and returns the new token. nil
+	// when no OAuth app is configured; login then asks for a token.
+	OAuthLogin func(ctx context.Context) (string, error)
+	// SaveToken and ClearToken store and remove the token used by NewClient.
+	SaveToken  func(token string) error
+	ClearToken func() error
+	// ControlSocket is the path of a running instance's control socket.
+	ControlSocket string
+
+	client *api.Client
+}
+
+// Run executes the subcommand in args[0] and returns the process exit code.
+// Requests to Todoist are abandoned when ctx is cancelled.
+func (r *Runner) Run(ctx context.Context, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(r.Stderr, "Error: missing command")
+		return ExitUsage
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(r.Stderr, "Error: unknown command %q\n", args[0])
+		return ExitUsage
+	}
+
+	if err := cmd.run(r, ctx, args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		fmt.Fprintf(r.Stderr, "Error: %v\n", err)
+		var uErr *usageError
+		if errors.As(err, &uErr) {
+			fmt.Fprintf(r.Stderr, "Usage: todoist-tui %s\n", cmd.usage)
+		}
+		return ExitCode(err)
+	}
+	return ExitOK
+}
+
+// ExitCode maps an error to the exit code reported for it.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var uErr *usageError
+	if errors.As(err, &uErr) {
+		return ExitUsage
+	}
+	var nfErr *notFoundError
+	if errors.As(err, &nfErr) {
+		return ExitNotFound
+	}
+	if errors.Is(err, ErrNoToken) {
+		return ExitAuth
+	}
+
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.IsUnauthorized(), apiErr.IsForbidden():
+			return ExitAuth
+		case apiErr.IsNotFound():
+			return ExitNotFound
+		case apiErr.IsRateLimited(), apiErr.IsServerError():
+			return ExitUnavailable
+		}
+		return ExitError
+	}
+
+	var syncErr *api.SyncError
+	if errors.As(err, &syncErr) && syncErr.IsNotFound() {
+		return ExitNotFound
+	}
+
+	if api.IsNetworkError(err) {
+		return ExitUnavailable
+	}
+	return ExitError
+}
+
+// apiClient returns the API client, creating it on first use.
+func (r *Runner) apiClient() (*api.Client, error) {
+	if r.client == nil {
+		client, err := r.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		r.client = client
+	}
+	return r.client, nil
+}
+
+// newFlagSet creates a flag set for a subcommand that reports errors instead
+// of exiting.
+func (r *Runner) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(r.Stderr)
+	return fs
+}
+
+// parseFlags parses args allowing flags before and after positional
+// arguments, e.g. "edit 123 --priority 1". Returns the positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &usageError{msg: err.Error()}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
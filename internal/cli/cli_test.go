@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/fake"
+)
+
+// newTestRunner returns a Runner talking to server, with captured output.
+func newTestRunner(server *httptest.Server) (*Runner, *bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	r := &Runner{
+		Stdout: stdout,
+		Stderr: stderr,
+		NewClient: func() (*api.Client, error) {
+			client := api.NewClient("test-token")
+			client.SetBaseURL(server.URL)
+			return client, nil
+		},
+	}
+	return r, stdout, stderr
+}
+
+func writeResults(w http.ResponseWriter, results interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+}
+
+func TestRunList(t *testing.T) {
+	var gotProjectID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects":
+			writeResults(w, []api.Project{{ID: "p1", Name: "Inbox"}, {ID: "p2", Name: "Work"}})
+		case "/tasks":
+			gotProjectID = r.URL.Query().Get("project_id")
+			writeResults(w, []api.Task{{
+				ID:        "t1",
+				Content:   "Write report",
+				ProjectID: "p2",
+				Priority:  4,
+				Labels:    []string{"office"},
+				Due:       &api.Due{Date: "2026-01-02"},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("json", func(t *testing.T) {
+		r, stdout, stderr := newTestRunner(server)
+		if code := r.Run(context.Background(), []string{"list", "--project", "work", "--format", "json"}); code != ExitOK {
+			t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+		}
+		if gotProjectID != "p2" {
+			t.Errorf("project_id = %q, want p2", gotProjectID)
+		}
+
+		var records []taskRecord
+		if err := json.Unmarshal(stdout.Bytes(), &records); err != nil {
+			t.Fatalf("invalid JSON output: %v", err)
+		}
+		if len(records) != 1 {
+			t.Fatalf("got %d records, want 1", len(records))
+		}
+		rec := records[0]
+		if rec.Project != "Work" || rec.Priority != 1 || rec.Due != "2026-01-02" {
+			t.Errorf("unexpected record: %+v", rec)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		r, stdout, _ := newTestRunner(server)
+		if code := r.Run(context.Background(), []string{"list", "--format=csv"}); code != ExitOK {
+			t.Fatalf("exit code = %d", code)
+		}
+		rows, err := csv.NewReader(stdout).ReadAll()
+		if err != nil {
+			t.Fatalf("invalid CSV output: %v", err)
+		}
+		if len(rows) != 2 || rows[1][0] != "t1" || rows[1][5] != "office" {
+			t.Errorf("unexpected rows: %v", rows)
+		}
+	})
+
+	t.Run("unknown project", func(t *testing.T) {
+		r, _, _ := newTestRunner(server)
+		if code := r.Run(context.Background(), []string{"list", "--project", "Missing"}); code != ExitNotFound {
+			t.Errorf("exit code = %d, want %d", code, ExitNotFound)
+		}
+	})
+}
+
+func TestRunEdit_SendsOnlyGivenFields(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tasks/t1" {
+			http.NotFound(w, r)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		json.NewEncoder(w).Encode(api.Task{ID: "t1"})
+	}))
+	defer server.Close()
+
+	r, _, stderr := newTestRunner(server)
+	if code := r.Run(context.Background(), []string{"edit", "t1", "--priority", "1", "--due", "none"}); code != ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	if len(body) != 2 {
+		t.Errorf("expected 2 fields, got %v", body)
+	}
+	if body["priority"] != float64(4) {
+		t.Errorf("priority = %v, want 4", body["priority"])
+	}
+	if body["due_string"] != "no date" {
+		t.Errorf("due_string = %v, want \"no date\"", body["due_string"])
+	}
+}
+
+func TestRunDone_AttemptsEveryTask(t *testing.T) {
+	var closed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tasks/"), "/close")
+		closed = append(closed, id)
+		if id == "missing" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	r, _, _ := newTestRunner(server)
+	code := r.Run(context.Background(), []string{"done", "a", "missing", "b"})
+	if code != ExitNotFound {
+		t.Errorf("exit code = %d, want %d", code, ExitNotFound)
+	}
+	if strings.Join(closed, ",") != "a,missing,b" {
+		t.Errorf("closed = %v, want every task attempted", closed)
+	}
+}
+
+func TestRun_ExitCodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"unknown command", []string{"frobnicate"}, ExitUsage},
+		{"missing text", []string{"add"}, ExitUsage},
+		{"bad flag", []string{"list", "--nope"}, ExitUsage},
+		{"bad format", []string{"projects", "--format", "xml"}, ExitUsage},
+		{"bad priority", []string{"edit", "t1", "--priority", "7"}, ExitUsage},
+		{"nothing to edit", []string{"edit", "t1"}, ExitUsage},
+		{"rejected token", []string{"labels"}, ExitAuth},
+		{"help", []string{"list", "-h"}, ExitOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _, _ := newTestRunner(server)
+			if got := r.Run(context.Background(), tt.args); got != tt.want {
+				t.Errorf("Run(%v) = %d, want %d", tt.args, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("no token", func(t *testing.T) {
+		r := &Runner{
+			Stdout:    io.Discard,
+			Stderr:    io.Discard,
+			NewClient: func() (*api.Client, error) { return nil, ErrNoToken },
+		}
+		if got := r.Run(context.Background(), []string{"projects"}); got != ExitAuth {
+			t.Errorf("exit code = %d, want %d", got, ExitAuth)
+		}
+	})
+}
+
+func TestRunLogin(t *testing.T) {
+	srv := fake.New()
+	srv.Token = "good-token"
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	newRunner := func(stdin string) (*Runner, *bytes.Buffer, *string) {
+		stored := new(string)
+		r, stdout, _ := newTestRunner(server)
+		r.Stdin = strings.NewReader(stdin)
+		r.NewClientWithToken = func(token string) (*api.Client, error) {
+			client := api.NewClient(token)
+			client.SetBaseURL(server.URL)
+			return client, nil
+		}
+		r.SaveToken = func(token string) error {
+			*stored = token
+			return nil
+		}
+		return r, stdout, stored
+	}
+
+	t.Run("with token", func(t *testing.T) {
+		r, stdout, stored := newRunner("good-token\n")
+		if code := r.Run(context.Background(), []string{"login", "--with-token"}); code != ExitOK {
+			t.Fatalf("exit code = %d", code)
+		}
+		if *stored != "good-token" {
+			t.Errorf("stored token = %q", *stored)
+		}
+		if !strings.Contains(stdout.String(), "Logged in as Test User <user@example.com>") {
+			t.Errorf("unexpected output: %q", stdout.String())
+		}
+	})
+
+	t.Run("rejected token is not stored", func(t *testing.T) {
+		r, _, stored := newRunner("bad-token\n")
+		if code := r.Run(context.Background(), []string{"login", "--with-token"}); code != ExitAuth {
+			t.Errorf("exit code = %d, want %d", code, ExitAuth)
+		}
+		if *stored != "" {
+			t.Errorf("rejected token was stored: %q", *stored)
+		}
+	})
+
+	t.Run("oauth", func(t *testing.T) {
+		r, _, stored := newRunner("")
+		r.OAuthLogin = func(ctx context.Context) (string, error) { return "good-token", nil }
+		if code := r.Run(context.Background(), []string{"login"}); code != ExitOK {
+			t.Fatalf("exit code = %d", code)
+		}
+		if *stored != "good-token" {
+			t.Errorf("stored token = %q", *stored)
+		}
+	})
+}
+
+func TestRunLogout(t *testing.T) {
+	cleared := false
+	r := &Runner{Stdout: io.Discard, Stderr: io.Discard, ClearToken: func() error {
+		cleared = true
+		return nil
+	}}
+	if code := r.Run(context.Background(), []string{"logout"}); code != ExitOK || !cleared {
+		t.Errorf("exit code = %d, cleared = %v", code, cleared)
+	}
+}
+
+func TestRunWhoami(t *testing.T) {
+	server := httptest.NewServer(fake.New())
+	defer server.Close()
+
+	r, stdout, _ := newTestRunner(server)
+	if code := r.Run(context.Background(), []string{"whoami", "--format", "json"}); code != ExitOK {
+		t.Fatalf("exit code = %d", code)
+	}
+	var rec userRecord
+	if err := json.Unmarshal(stdout.Bytes(), &rec); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if rec.ID != "1" || rec.Name != "Test User" || rec.Email != "user@example.com" {
+		t.Errorf("unexpected user: %+v", rec)
+	}
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/export"
+)
+
+// runExport writes tasks of one project, a filter, or everything to a file
+// or standard output.
+func (r *Runner) runExport(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("export")
+	format := fs.String("format", export.FormatMarkdown, "output format: md, csv, json or todo.txt")
+	project := fs.String("project", "", "only export this project (name or ID)")
+	filter := fs.String("filter", "", "only export tasks matching this Todoist filter query")
+	output := fs.String("output", "", "file to write instead of standard output")
+	withComments := fs.Bool("comments", true, "include comments (one request per task with comments)")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("unexpected argument %q", positional[0])
+	}
+	exportFormat, err := export.ParseFormat(*format)
+	if err != nil {
+		return &usageError{msg: err.Error()}
+	}
+
+	client, err := r.apiClient()
+	if err != nil {
+		return err
+	}
+
+	projects, err := client.GetProjects(ctx)
+	if err != nil {
+		return err
+	}
+	sections, err := client.GetSections(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	var projectID string
+	if *project != "" {
+		p, err := findProject(projects, *project)
+		if err != nil {
+			return err
+		}
+		projectID = p.ID
+	}
+
+	var tasks []api.Task
+	if *filter != "" {
+		tasks, err = client.GetTasksByFilter(ctx, *filter)
+	} else {
+		tasks, err = client.GetTasks(ctx, api.TaskFilter{ProjectID: projectID})
+	}
+	if err != nil {
+		return err
+	}
+
+	var data *export.Data
+	switch {
+	case *filter != "" || projectID != "":
+		if projectID != "" {
+			filtered := tasks[:0]
+			for _, t := range tasks {
+				if t.ProjectID == projectID {
+					filtered = append(filtered, t)
+				}
+			}
+			tasks = filtered
+		}
+		data = export.Select(projects, sections, tasks)
+		// Export the project even when it has no tasks
+		if projectID != "" && len(data.Projects) == 0 {
+			p, _ := findProject(projects, projectID)
+			data.Projects = []api.Project{*p}
+		}
+	default:
+		data = &export.Data{Projects: projects, Sections: sections, Tasks: tasks}
+	}
+
+	if *withComments {
+		data.Comments, err = export.LoadComments(ctx, client, data.Tasks)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *output != "" {
+		return export.WriteFile(*output, exportFormat, data)
+	}
+	return export.Write(r.Stdout, exportFormat, data)
+}
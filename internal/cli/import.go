@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hy4ri/todoist-tui/internal/importer"
+)
+
+// runImport creates tasks from a Markdown, CSV or todo.txt file.
+func (r *Runner) runImport(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("import")
+	format := fs.String("format", "", "input format: md, csv or todo.txt (default: from the file extension)")
+	project := fs.String("project", "", "project for tasks that do not name one (default: Inbox)")
+	mapSpec := fs.String("map", "", "CSV column mapping, e.g. \"content=Title,due=Due Date\"")
+	dryRun := fs.Bool("dry-run", false, "show what would be created without changing anything")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("exactly one file is required (use - for standard input)")
+	}
+	path := positional[0]
+
+	if *format == "" {
+		*format = formatFromExtension(path)
+		if *format == "" {
+			return usagef("cannot tell the format of %q; use --format", path)
+		}
+	}
+	importFormat, err := importer.ParseFormat(*format)
+	if err != nil {
+		return &usageError{msg: err.Error()}
+	}
+	mapping, err := importer.ParseMapping(*mapSpec)
+	if err != nil {
+		return &usageError{msg: err.Error()}
+	}
+
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	plan, err := importer.Parse(in, importFormat, mapping)
+	if err != nil {
+		return err
+	}
+
+	client, err := r.apiClient()
+	if err != nil {
+		return err
+	}
+	projects, err := client.GetProjects(ctx)
+	if err != nil {
+		return err
+	}
+	sections, err := client.GetSections(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	var projectID string
+	if *project != "" {
+		p, err := findProject(projects, *project)
+		if err != nil {
+			return err
+		}
+		projectID = p.ID
+	}
+
+	batch := importer.Build(plan, projects, sections, projectID)
+	if *dryRun {
+		batch.Preview(r.Stdout)
+		for _, e := range batch.Errors {
+			fmt.Fprintf(r.Stderr, "Skipped %v\n", e)
+		}
+		return nil
+	}
+
+	report := batch.Execute(ctx, client)
+	fmt.Fprintf(r.Stdout, "Imported %d tasks\n", report.Created)
+	for _, e := range report.Failures {
+		fmt.Fprintf(r.Stderr, "Failed %v\n", e)
+	}
+	if len(report.Failures) > 0 {
+		return fmt.Errorf("import finished with %d failures", len(report.Failures))
+	}
+	return nil
+}
+
+// formatFromExtension guesses the import format from a file name.
+func formatFromExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return importer.FormatMarkdown
+	case ".csv":
+		return importer.FormatCSV
+	case ".txt":
+		return importer.FormatTodoTxt
+	}
+	return ""
+}
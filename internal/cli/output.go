@@ -0,0 +1,236 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// Output formats accepted by --format.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// checkFormat validates a --format value.
+func checkFormat(format string) error {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return nil
+	}
+	return usagef("unknown format %q (want table, json or csv)", format)
+}
+
+// taskRecord is the scripting-friendly representation of a task.
+// Priority uses the numbering shown in Todoist: 1 is the highest.
+type taskRecord struct {
+	ID          string   `json:"id"`
+	Content     string   `json:"content"`
+	Description string   `json:"description"`
+	Project     string   `json:"project"`
+	ProjectID   string   `json:"project_id"`
+	Priority    int      `json:"priority"`
+	Due         string   `json:"due"`
+	Recurring   bool     `json:"recurring"`
+	Labels      []string `json:"labels"`
+	ParentID    string   `json:"parent_id,omitempty"`
+	URL         string   `json:"url"`
+}
+
+// newTaskRecord converts t, resolving its project name from projects.
+func newTaskRecord(t api.Task, projects map[string]string) taskRecord {
+	rec := taskRecord{
+		ID:          t.ID,
+		Content:     t.Content,
+		Description: t.Description,
+		Project:     projects[t.ProjectID],
+		ProjectID:   t.ProjectID,
+		Priority:    displayPriority(t.Priority),
+		Labels:      t.Labels,
+		URL:         t.URL,
+	}
+	if rec.Labels == nil {
+		rec.Labels = []string{}
+	}
+	if t.Due != nil {
+		rec.Due = t.Due.Date
+		if t.Due.Datetime != nil && *t.Due.Datetime != "" {
+			rec.Due = *t.Due.Datetime
+		}
+		rec.Recurring = t.Due.IsRecurring
+	}
+	if t.ParentID != nil {
+		rec.ParentID = *t.ParentID
+	}
+	return rec
+}
+
+// displayPriority converts an API priority (4 is urgent) to the p1-p4
+// numbering shown in Todoist (1 is urgent).
+func displayPriority(p int) int {
+	if p < 1 || p > 4 {
+		return 4
+	}
+	return 5 - p
+}
+
+// apiPriority converts a p1-p4 priority to the API's numbering.
+func apiPriority(p int) int {
+	return 5 - p
+}
+
+// writeTasks writes tasks to w in the given format.
+func writeTasks(w io.Writer, format string, tasks []api.Task, projects map[string]string) error {
+	records := make([]taskRecord, len(tasks))
+	for i, t := range tasks {
+		records[i] = newTaskRecord(t, projects)
+	}
+
+	switch format {
+	case formatJSON:
+		return writeJSON(w, records)
+	case formatCSV:
+		rows := [][]string{{"id", "content", "project", "priority", "due", "labels", "description"}}
+		for _, rec := range records {
+			rows = append(rows, []string{
+				rec.ID, rec.Content, rec.Project, strconv.Itoa(rec.Priority),
+				rec.Due, strings.Join(rec.Labels, ","), rec.Description,
+			})
+		}
+		return writeCSV(w, rows)
+	default:
+		rows := [][]string{{"ID", "PRI", "DUE", "PROJECT", "CONTENT"}}
+		for _, rec := range records {
+			content := rec.Content
+			for _, l := range rec.Labels {
+				content += " @" + l
+			}
+			rows = append(rows, []string{
+				rec.ID, fmt.Sprintf("p%d", rec.Priority), rec.Due, rec.Project, content,
+			})
+		}
+		return writeTable(w, rows)
+	}
+}
+
+// projectRecord is the scripting-friendly representation of a project.
+type projectRecord struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	ParentID   string `json:"parent_id,omitempty"`
+	Color      string `json:"color"`
+	IsFavorite bool   `json:"is_favorite"`
+	IsInbox    bool   `json:"is_inbox"`
+	Shared     bool   `json:"shared"`
+}
+
+// writeProjects writes projects to w in the given format.
+func writeProjects(w io.Writer, format string, projects []api.Project) error {
+	records := make([]projectRecord, len(projects))
+	for i, p := range projects {
+		records[i] = projectRecord{
+			ID:         p.ID,
+			Name:       p.Name,
+			Color:      p.Color,
+			IsFavorite: p.IsFavorite,
+			IsInbox:    p.InboxProject,
+			Shared:     p.Shared,
+		}
+		if p.ParentID != nil {
+			records[i].ParentID = *p.ParentID
+		}
+	}
+
+	switch format {
+	case formatJSON:
+		return writeJSON(w, records)
+	case formatCSV:
+		rows := [][]string{{"id", "name", "parent_id", "color", "is_favorite", "is_inbox", "shared"}}
+		for _, rec := range records {
+			rows = append(rows, []string{
+				rec.ID, rec.Name, rec.ParentID, rec.Color,
+				strconv.FormatBool(rec.IsFavorite), strconv.FormatBool(rec.IsInbox), strconv.FormatBool(rec.Shared),
+			})
+		}
+		return writeCSV(w, rows)
+	default:
+		names := make(map[string]string, len(records))
+		for _, rec := range records {
+			names[rec.ID] = rec.Name
+		}
+		rows := [][]string{{"ID", "NAME", "PARENT", "FAVORITE"}}
+		for _, rec := range records {
+			favorite := ""
+			if rec.IsFavorite {
+				favorite = "★"
+			}
+			rows = append(rows, []string{rec.ID, rec.Name, names[rec.ParentID], favorite})
+		}
+		return writeTable(w, rows)
+	}
+}
+
+// labelRecord is the scripting-friendly representation of a label.
+type labelRecord struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Color      string `json:"color"`
+	IsFavorite bool   `json:"is_favorite"`
+}
+
+// writeLabels writes labels to w in the given format.
+func writeLabels(w io.Writer, format string, labels []api.Label) error {
+	records := make([]labelRecord, len(labels))
+	for i, l := range labels {
+		records[i] = labelRecord{ID: l.ID, Name: l.Name, Color: l.Color, IsFavorite: l.IsFavorite}
+	}
+
+	switch format {
+	case formatJSON:
+		return writeJSON(w, records)
+	case formatCSV:
+		rows := [][]string{{"id", "name", "color", "is_favorite"}}
+		for _, rec := range records {
+			rows = append(rows, []string{rec.ID, rec.Name, rec.Color, strconv.FormatBool(rec.IsFavorite)})
+		}
+		return writeCSV(w, rows)
+	default:
+		rows := [][]string{{"ID", "NAME", "COLOR"}}
+		for _, rec := range records {
+			rows = append(rows, []string{rec.ID, "@" + rec.Name, rec.Color})
+		}
+		return writeTable(w, rows)
+	}
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeCSV writes rows as CSV.
+func writeCSV(w io.Writer, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// writeTable writes rows as aligned columns.
+func writeTable(w io.Writer, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
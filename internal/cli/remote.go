@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hy4ri/todoist-tui/internal/control"
+)
+
+// runRemote sends a command to a running TUI over its control socket.
+func (r *Runner) runRemote(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("remote")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return usagef("a remote command is required")
+	}
+
+	req := control.Request{Command: positional[0]}
+	rest := strings.TrimSpace(strings.Join(positional[1:], " "))
+	switch req.Command {
+	case control.CommandAdd:
+		req.Text = rest
+	case control.CommandProject:
+		req.Project = rest
+	case control.CommandPomodoro:
+		req.TaskID = rest
+	case control.CommandRefresh:
+		if rest != "" {
+			return usagef("refresh takes no arguments")
+		}
+	}
+	if err := req.Validate(); err != nil {
+		return &usageError{msg: err.Error()}
+	}
+
+	if r.ControlSocket == "" {
+		return errors.New("control socket path is unknown")
+	}
+	resp, err := control.Send(r.ControlSocket, req)
+	if err != nil {
+		return err
+	}
+	if !resp.OK {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package cli
+
+import "context"
+
+// runProjects prints all projects.
+func (r *Runner) runProjects(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("projects")
+	format := fs.String("format", formatTable, "output format: table, json or csv")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("unexpected argument %q", positional[0])
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+
+	client, err := r.apiClient()
+	if err != nil {
+		return err
+	}
+
+	projects, err := client.GetProjects(ctx)
+	if err != nil {
+		return err
+	}
+	return writeProjects(r.Stdout, *format, projects)
+}
+
+// runLabels prints all personal labels.
+func (r *Runner) runLabels(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("labels")
+	format := fs.String("format", formatTable, "output format: table, json or csv")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("unexpected argument %q", positional[0])
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+
+	client, err := r.apiClient()
+	if err != nil {
+		return err
+	}
+
+	labels, err := client.GetLabels(ctx)
+	if err != nil {
+		return err
+	}
+	return writeLabels(r.Stdout, *format, labels)
+}
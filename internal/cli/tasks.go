@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// runAdd creates a task with Quick Add, which parses dates, #project,
+// @label and priority from the text.
+func (r *Runner) runAdd(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("add")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	text := strings.TrimSpace(strings.Join(positional, " "))
+	if text == "" {
+		return usagef("task text is required")
+	}
+
+	client, err := r.apiClient()
+	if err != nil {
+		return err
+	}
+
+	task, err := client.QuickAddTask(ctx, text)
+	if err != nil {
+		return err
+	}
+
+	// Print the ID so scripts can refer to the new task
+	fmt.Fprintln(r.Stdout, task.ID)
+	return nil
+}
+
+// runList prints active tasks, optionally narrowed by a filter query and project.
+func (r *Runner) runList(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("list")
+	filter := fs.String("filter", "", "Todoist filter query, e.g. \"today | overdue\"")
+	project := fs.String("project", "", "project name or ID")
+	format := fs.String("format", formatTable, "output format: table, json or csv")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("unexpected argument %q", positional[0])
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+
+	client, err := r.apiClient()
+	if err != nil {
+		return err
+	}
+
+	projects, err := client.GetProjects(ctx)
+	if err != nil {
+		return err
+	}
+	projectNames := make(map[string]string, len(projects))
+	for _, p := range projects {
+		projectNames[p.ID] = p.Name
+	}
+
+	var projectID string
+	if *project != "" {
+		p, err := findProject(projects, *project)
+		if err != nil {
+			return err
+		}
+		projectID = p.ID
+	}
+
+	var tasks []api.Task
+	if *filter != "" {
+		tasks, err = client.GetTasksByFilter(ctx, *filter)
+		if err != nil {
+			return err
+		}
+		if projectID != "" {
+			filtered := tasks[:0]
+			for _, t := range tasks {
+				if t.ProjectID == projectID {
+					filtered = append(filtered, t)
+				}
+			}
+			tasks = filtered
+		}
+	} else {
+		tasks, err = client.GetTasks(ctx, api.TaskFilter{ProjectID: projectID})
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeTasks(r.Stdout, *format, tasks, projectNames)
+}
+
+// runDone completes the given tasks. Every ID is attempted; the exit code
+// reflects the first failure.
+func (r *Runner) runDone(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("done")
+	ids, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return usagef("at least one task ID is required")
+	}
+
+	client, err := r.apiClient()
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, id := range ids {
+		if err := client.CloseTask(ctx, id); err != nil {
+			fmt.Fprintf(r.Stderr, "Error: %v\n", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if firstErr != nil {
+		return fmt.Errorf("failed to complete task(s): %w", firstErr)
+	}
+	return nil
+}
+
+// runEdit updates a task's content, due date or priority.
+func (r *Runner) runEdit(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("edit")
+	content := fs.String("content", "", "new task content")
+	description := fs.String("description", "", "new task description")
+	due := fs.String("due", "", "new due date in natural language, or \"none\" to remove it")
+	priority := fs.Int("priority", 0, "new priority, 1 (highest) to 4")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("exactly one task ID is required")
+	}
+
+	// Only fields whose flag was given are sent; the rest stay untouched
+	var req api.UpdateTaskRequest
+	changed := false
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		changed = true
+		switch f.Name {
+		case "content":
+			if strings.TrimSpace(*content) == "" {
+				flagErr = usagef("--content cannot be empty")
+			}
+			req.Content = content
+		case "description":
+			req.Description = description
+		case "due":
+			dueString := *due
+			if dueString == "" || strings.EqualFold(dueString, "none") {
+				dueString = "no date"
+			}
+			req.DueString = &dueString
+		case "priority":
+			if *priority < 1 || *priority > 4 {
+				flagErr = usagef("--priority must be between 1 and 4")
+			}
+			req.Priority = api.IntPtr(apiPriority(*priority))
+		}
+	})
+	if flagErr != nil {
+		return flagErr
+	}
+	if !changed {
+		return usagef("nothing to change; use --content, --description, --due or --priority")
+	}
+
+	client, err := r.apiClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.UpdateTask(ctx, positional[0], req)
+	return err
+}
+
+// findProject looks up a project by ID or case-insensitive name.
+func findProject(projects []api.Project, nameOrID string) (*api.Project, error) {
+	for i := range projects {
+		if projects[i].ID == nameOrID {
+			return &projects[i], nil
+		}
+	}
+	for i := range projects {
+		if strings.EqualFold(projects[i].Name, nameOrID) {
+			return &projects[i], nil
+		}
+	}
+	return nil, &notFoundError{kind: "project", name: nameOrID}
+}
@@ -5,15 +5,25 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
+	"strings"
+	"time"
 
+	"github.com/hy4ri/todoist-tui/internal/api"
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the application configuration.
 type Config struct {
-	Auth AuthConfig `yaml:"auth"`
-	UI   UIConfig   `yaml:"ui"`
+	Auth      AuthConfig      `yaml:"auth"`
+	UI        UIConfig        `yaml:"ui"`
+	StatusBar StatusBarConfig `yaml:"status_bar,omitempty"`
+	Control   ControlConfig   `yaml:"control,omitempty"`
+	Network   NetworkConfig   `yaml:"network,omitempty"`
+
+	// Profile is the profile used when --profile is not given.
+	Profile string `yaml:"profile,omitempty"`
+	// Profiles are named accounts, each with its own token and data directory.
+	Profiles map[string]ProfileConfig `yaml:"profiles,omitempty"`
 }
 
 // AuthConfig holds authentication-related settings.
@@ -37,12 +47,86 @@ type UIConfig struct {
 	PomodoroWorkDuration int `yaml:"pomodoro_work_duration,omitempty"`
 	// PomodoroBreakDuration is the preferred short-break duration in minutes (0 = use default 5).
 	PomodoroBreakDuration int `yaml:"pomodoro_break_duration,omitempty"`
+	// WorkdayLength is the time available for timed tasks in a day, e.g. "7h30m"
+	// (default: 8h). Days with longer tasks scheduled are flagged.
+	WorkdayLength time.Duration `yaml:"workday_length,omitempty"`
 	// Keybindings allows overriding default key bindings. Map of action name to key string.
 	// Example: { "add_task": "o", "complete": "c" }
 	// Action names match those in KeymapData (snake_case). An empty or missing map keeps all defaults.
 	Keybindings map[string]string `yaml:"keybindings,omitempty"`
 }
 
+// DefaultWorkdayLength is used when ui.workday_length is not set.
+const DefaultWorkdayLength = 8 * time.Hour
+
+// Workday returns the configured workday length.
+func (u UIConfig) Workday() time.Duration {
+	if u.WorkdayLength > 0 {
+		return u.WorkdayLength
+	}
+	return DefaultWorkdayLength
+}
+
+// StatusBarConfig holds settings for the status bar output (--status).
+type StatusBarConfig struct {
+	// Format is the output format: waybar, polybar, i3blocks, tmux, plain or template (default: waybar).
+	Format string `yaml:"format,omitempty"`
+	// Filter is the Todoist filter query selecting the tasks (default: "today | overdue").
+	Filter string `yaml:"filter,omitempty"`
+	// Fields are the task fields shown per task line: content, due, priority, project, labels.
+	Fields []string `yaml:"fields,omitempty"`
+	// Template is the Go template used by the template format.
+	Template string `yaml:"template,omitempty"`
+}
+
+// ControlConfig holds settings for the control socket of a running instance.
+type ControlConfig struct {
+	// Enabled starts a Unix socket server in the data directory that accepts JSON commands.
+	Enabled bool `yaml:"enabled"`
+}
+
+// NetworkConfig holds settings for connecting to the Todoist API.
+// Empty values use built-in defaults.
+type NetworkConfig struct {
+	// BaseURL replaces the API base URL (default: https://api.todoist.com/api/v1).
+	BaseURL string `yaml:"base_url,omitempty"`
+	// Proxy is a proxy URL, e.g. "http://proxy:3128" or "socks5://localhost:1080" (default: HTTPS_PROXY from the environment).
+	Proxy string `yaml:"proxy,omitempty"`
+	// CAFile is a PEM bundle of extra trusted certificates, e.g. for a corporate proxy.
+	CAFile string `yaml:"ca_file,omitempty"`
+	// Timeout limits each request, e.g. "30s" (default: 30s).
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// MaxRetries is the number of retries of a failed request (default: 3).
+	MaxRetries int `yaml:"max_retries,omitempty"`
+	// RetryBaseDelay is the wait before the first retry, doubled for each further one (default: 500ms).
+	RetryBaseDelay time.Duration `yaml:"retry_base_delay,omitempty"`
+}
+
+// API returns the settings in the form the API client takes.
+func (n NetworkConfig) API() api.Network {
+	return api.Network{
+		BaseURL:        n.BaseURL,
+		Proxy:          n.Proxy,
+		CAFile:         ExpandHome(n.CAFile),
+		Timeout:        n.Timeout,
+		MaxRetries:     n.MaxRetries,
+		RetryBaseDelay: n.RetryBaseDelay,
+	}
+}
+
+// ExpandHome replaces a leading ~/ in path with the home directory.
+func ExpandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, rest)
+}
+
 // ThemeConfig holds color theme settings.
 // All colors should be hex strings (e.g., "#FF6B6B").
 // Empty values use built-in defaults.
@@ -166,39 +250,3 @@ func (c *Config) HasValidAuth() bool {
 func (c *Config) HasOAuthCredentials() bool {
 	return c.Auth.ClientID != "" && c.Auth.ClientSecret != ""
 }
-
-// UpdateDefaultView updates the default_view setting in the config file
-// using textual replacement to preserve comments and formatting.
-func UpdateDefaultView(viewName string) error {
-	path, err := ConfigPath()
-	if err != nil {
-		return err
-	}
-
-	content, err := os.ReadFile(path)
-	if err != nil {
-		return err
-	}
-
-	text := string(content)
-
-	// Try to find existing key
-	// Looks for "default_view:" optionally followed by value
-	re := regexp.MustCompile(`(?m)^(\s*default_view:\s*).*$`)
-
-	if re.MatchString(text) {
-		text = re.ReplaceAllString(text, fmt.Sprintf("${1}%q", viewName))
-	} else {
-		// Key not found, insert it in ui section or append
-		// If "ui:" exists, try to insert after it
-		uiRe := regexp.MustCompile(`(?m)^ui:\s*$`)
-		if uiRe.MatchString(text) {
-			text = uiRe.ReplaceAllString(text, fmt.Sprintf("ui:\n  default_view: %q", viewName))
-		} else {
-			// Just append to end
-			text += fmt.Sprintf("\n# Default view added by app\nui:\n  default_view: %q\n", viewName)
-		}
-	}
-
-	return os.WriteFile(path, []byte(text), 0600)
-}
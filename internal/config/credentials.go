@@ -16,8 +16,9 @@ const (
 	credFileName   = ".credentials"
 )
 
-// DataDir returns the path to the data directory for secure storage.
-// Uses XDG_DATA_HOME or defaults to ~/.local/share/todoist-tui/
+// DataDir returns the path to the data directory of the active profile.
+// Uses XDG_DATA_HOME or defaults to ~/.local/share/todoist-tui/; other
+// profiles than the default one use profiles/NAME below it.
 func DataDir() (string, error) {
 	// Check XDG_DATA_HOME first
 	dataHome := os.Getenv("XDG_DATA_HOME")
@@ -29,7 +30,7 @@ func DataDir() (string, error) {
 		dataHome = filepath.Join(homeDir, ".local", "share")
 	}
 
-	dataDir := filepath.Join(dataHome, "todoist-tui")
+	dataDir := profileDataDir(filepath.Join(dataHome, "todoist-tui"), CurrentProfile())
 	if err := os.MkdirAll(dataDir, 0700); err != nil {
 		return "", fmt.Errorf("failed to create data directory: %w", err)
 	}
@@ -37,7 +38,7 @@ func DataDir() (string, error) {
 	return dataDir, nil
 }
 
-// GetToken retrieves the API token from available sources.
+// GetToken retrieves the API token of the active profile from available sources.
 // Priority: 1. TODOIST_TOKEN env var, 2. System keyring, 3. Credentials file
 func GetToken() (string, error) {
 	// 1. Check environment variable (highest priority, allows override)
@@ -46,7 +47,7 @@ func GetToken() (string, error) {
 	}
 
 	// 2. Try system keyring
-	token, err := keyring.Get(keyringService, keyringUser)
+	token, err := keyring.Get(keyringService, keyringUserFor(CurrentProfile()))
 	if err == nil && token != "" {
 		return strings.TrimSpace(token), nil
 	}
@@ -69,7 +70,7 @@ func GetToken() (string, error) {
 	return strings.TrimSpace(string(data)), nil
 }
 
-// SaveToken stores the API token securely.
+// SaveToken stores the API token of the active profile securely.
 // Tries system keyring first, falls back to credentials file.
 func SaveToken(token string) error {
 	token = strings.TrimSpace(token)
@@ -78,7 +79,7 @@ func SaveToken(token string) error {
 	}
 
 	// Try keyring first
-	err := keyring.Set(keyringService, keyringUser, token)
+	err := keyring.Set(keyringService, keyringUserFor(CurrentProfile()), token)
 	if err == nil {
 		return nil
 	}
@@ -97,10 +98,10 @@ func SaveToken(token string) error {
 	return nil
 }
 
-// ClearToken removes the stored API token from all locations.
+// ClearToken removes the stored API token of the active profile from all locations.
 func ClearToken() error {
 	// Try to delete from keyring (ignore errors)
-	_ = keyring.Delete(keyringService, keyringUser)
+	_ = keyring.Delete(keyringService, keyringUserFor(CurrentProfile()))
 
 	// Delete credentials file if it exists
 	dataDir, err := DataDir()
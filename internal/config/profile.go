@@ -0,0 +1,229 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultProfile is the profile used when none is selected. It keeps the
+// token and data directory of versions without profiles.
+const DefaultProfile = "default"
+
+// ProfileConfig holds the settings of a named account.
+type ProfileConfig struct {
+	// DefaultView replaces ui.default_view while the profile is active.
+	DefaultView string `yaml:"default_view,omitempty"`
+}
+
+var profileNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// The active profile selects the token and data directory used by GetToken,
+// SaveToken, ClearToken and DataDir.
+var (
+	profileMu     sync.RWMutex
+	activeProfile = DefaultProfile
+)
+
+// ValidateProfileName checks that name can be used in keyring entries and
+// directory names.
+func ValidateProfileName(name string) error {
+	if !profileNameRe.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q (use letters, digits, - and _)", name)
+	}
+	return nil
+}
+
+// SetProfile makes name the active profile.
+func SetProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	profileMu.Lock()
+	activeProfile = name
+	profileMu.Unlock()
+	return nil
+}
+
+// CurrentProfile returns the name of the active profile.
+func CurrentProfile() string {
+	profileMu.RLock()
+	defer profileMu.RUnlock()
+	return activeProfile
+}
+
+// keyringUserFor returns the keyring entry holding the token of profile.
+func keyringUserFor(profile string) string {
+	if profile == DefaultProfile {
+		return keyringUser
+	}
+	return keyringUser + ":" + profile
+}
+
+// StartupProfile returns the profile to use when none is given on the
+// command line.
+func (c *Config) StartupProfile() string {
+	if c.Profile != "" {
+		return c.Profile
+	}
+	return DefaultProfile
+}
+
+// HasProfile reports whether name is the default profile or configured
+// under profiles.
+func (c *Config) HasProfile(name string) bool {
+	if name == DefaultProfile {
+		return true
+	}
+	_, ok := c.Profiles[name]
+	return ok
+}
+
+// ProfileNames returns the names of all profiles, sorted.
+func (c *Config) ProfileNames() []string {
+	names := []string{DefaultProfile}
+	for name := range c.Profiles {
+		if name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// DefaultViewFor returns the startup view of profile, falling back to
+// ui.default_view.
+func (c *Config) DefaultViewFor(profile string) string {
+	if p, ok := c.Profiles[profile]; ok && p.DefaultView != "" {
+		return p.DefaultView
+	}
+	return c.UI.DefaultView
+}
+
+// SetDefaultViewFor changes the startup view of profile in memory. Profiles
+// without an entry under profiles use ui.default_view.
+func (c *Config) SetDefaultViewFor(profile, viewName string) {
+	if p, ok := c.Profiles[profile]; ok {
+		p.DefaultView = viewName
+		c.Profiles[profile] = p
+		return
+	}
+	c.UI.DefaultView = viewName
+}
+
+// profileDataDir returns the data directory of profile below dataDir, the
+// data directory of the default profile.
+func profileDataDir(dataDir, profile string) string {
+	if profile == DefaultProfile {
+		return dataDir
+	}
+	return filepath.Join(dataDir, "profiles", profile)
+}
+
+// UpdateDefaultView updates the startup view of profile in the config file
+// using textual replacement to preserve comments and formatting.
+func UpdateDefaultView(cfg *Config, profile, viewName string) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{"ui", "default_view"}
+	if _, ok := cfg.Profiles[profile]; ok {
+		keys = []string{"profiles", profile, "default_view"}
+	}
+	text := setYAMLValue(string(content), keys, fmt.Sprintf("%q", viewName))
+
+	return os.WriteFile(path, []byte(text), 0600)
+}
+
+// setYAMLValue sets the value of the nested mapping key at keys in a YAML
+// document, leaving all other lines untouched. Missing keys are inserted
+// with two-space indentation.
+func setYAMLValue(text string, keys []string, value string) string {
+	lines := strings.Split(text, "\n")
+
+	// The block being searched is lines[start:end]; its keys are indented
+	// by indent spaces
+	start, end, indent := 0, len(lines), 0
+	for depth, key := range keys {
+		found := -1
+		for i := start; i < end; i++ {
+			if lineIndent(lines[i]) == indent && isKeyLine(lines[i], key) {
+				found = i
+				break
+			}
+		}
+
+		if found < 0 {
+			// Insert the rest of the path as the first entry of the block
+			var insert []string
+			for i, k := range keys[depth:] {
+				line := strings.Repeat(" ", indent+2*i) + k + ":"
+				if depth+i == len(keys)-1 {
+					line += " " + value
+				}
+				insert = append(insert, line)
+			}
+			if depth == 0 {
+				// Top-level keys go to the end of the document
+				for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+					lines = lines[:len(lines)-1]
+				}
+				return strings.Join(append(lines, insert...), "\n") + "\n"
+			}
+			return strings.Join(slices.Insert(lines, start, insert...), "\n")
+		}
+
+		if depth == len(keys)-1 {
+			lines[found] = lines[found][:indent] + key + ": " + value
+			return strings.Join(lines, "\n")
+		}
+
+		// An empty flow mapping becomes a block the value can go into
+		if _, rest, _ := strings.Cut(lines[found], ":"); strings.TrimSpace(rest) == "{}" {
+			lines[found] = lines[found][:indent] + key + ":"
+		}
+
+		// Descend into the block of found: the following lines indented
+		// deeper than it
+		start, end = found+1, found+1
+		for end < len(lines) && (isBlankLine(lines[end]) || lineIndent(lines[end]) > indent) {
+			end++
+		}
+		indent += 2
+		for i := start; i < end; i++ {
+			if !isBlankLine(lines[i]) {
+				indent = lineIndent(lines[i])
+				break
+			}
+		}
+	}
+	return text
+}
+
+// lineIndent returns the number of leading spaces of line.
+func lineIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// isBlankLine reports whether line is empty or a comment.
+func isBlankLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+// isKeyLine reports whether line starts the mapping entry key.
+func isKeyLine(line, key string) bool {
+	rest, ok := strings.CutPrefix(strings.TrimLeft(line, " "), key+":")
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSetYAMLValue(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		keys []string
+		want string
+	}{
+		{
+			name: "replace",
+			text: "ui:\n  # Startup view\n  default_view: \"inbox\"\n  vim_mode: true\n",
+			keys: []string{"ui", "default_view"},
+			want: "ui:\n  # Startup view\n  default_view: \"today\"\n  vim_mode: true\n",
+		},
+		{
+			name: "only in its section",
+			text: "profiles:\n  work:\n    default_view: \"inbox\"\nui:\n  default_view: \"inbox\"\n",
+			keys: []string{"ui", "default_view"},
+			want: "profiles:\n  work:\n    default_view: \"inbox\"\nui:\n  default_view: \"today\"\n",
+		},
+		{
+			name: "nested",
+			text: "ui:\n  default_view: \"inbox\"\nprofiles:\n  home:\n    default_view: \"inbox\"\n  work:\n    default_view: \"inbox\"\n",
+			keys: []string{"profiles", "work", "default_view"},
+			want: "ui:\n  default_view: \"inbox\"\nprofiles:\n  home:\n    default_view: \"inbox\"\n  work:\n    default_view: \"today\"\n",
+		},
+		{
+			name: "insert into section",
+			text: "ui:\n    vim_mode: true\nprofiles:\n  work:\n",
+			keys: []string{"profiles", "work", "default_view"},
+			want: "ui:\n    vim_mode: true\nprofiles:\n  work:\n    default_view: \"today\"\n",
+		},
+		{
+			name: "keep indentation",
+			text: "ui:\n    vim_mode: true\n",
+			keys: []string{"ui", "default_view"},
+			want: "ui:\n    default_view: \"today\"\n    vim_mode: true\n",
+		},
+		{
+			name: "empty mapping",
+			text: "profiles:\n  personal: {}\n",
+			keys: []string{"profiles", "personal", "default_view"},
+			want: "profiles:\n  personal:\n    default_view: \"today\"\n",
+		},
+		{
+			name: "append section",
+			text: "ui:\n  vim_mode: true\n\n",
+			keys: []string{"profiles", "work", "default_view"},
+			want: "ui:\n  vim_mode: true\nprofiles:\n  work:\n    default_view: \"today\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := setYAMLValue(tt.text, tt.keys, `"today"`); got != tt.want {
+				t.Errorf("setYAMLValue() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataDir_Profile(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	t.Cleanup(func() { SetProfile(DefaultProfile) })
+
+	dir, err := DataDir()
+	if err != nil || dir != filepath.Join(dataHome, "todoist-tui") {
+		t.Errorf("DataDir() = %q, %v", dir, err)
+	}
+	if keyringUserFor(DefaultProfile) != "api-token" {
+		t.Error("the default profile must keep the existing keyring entry")
+	}
+
+	if err := SetProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	dir, err = DataDir()
+	if err != nil || dir != filepath.Join(dataHome, "todoist-tui", "profiles", "work") {
+		t.Errorf("DataDir() = %q, %v", dir, err)
+	}
+	if keyringUserFor("work") == keyringUserFor(DefaultProfile) {
+		t.Error("profiles share a keyring entry")
+	}
+
+	if err := SetProfile("../etc"); err == nil {
+		t.Error("expected an error for an invalid name")
+	}
+}
@@ -0,0 +1,238 @@
+// Package control implements an optional Unix socket server that lets
+// external tools, such as window manager keybindings and editor plugins,
+// drive a running todoist-tui instance.
+//
+// Clients send one JSON request per line and receive one JSON response per
+// line, e.g.
+//
+//	{"command": "add", "text": "Buy milk tomorrow p1"}
+//	{"ok": true}
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SocketName is the file name of the control socket inside the data directory.
+const SocketName = "control.sock"
+
+// Commands accepted by the server.
+const (
+	CommandAdd      = "add"      // Quick-add Text
+	CommandProject  = "project"  // Open Project (name or ID)
+	CommandPomodoro = "pomodoro" // Start the Pomodoro timer on TaskID
+	CommandRefresh  = "refresh"  // Force a full refresh
+)
+
+// replyTimeout bounds how long a client waits for the TUI to handle a request.
+const replyTimeout = 5 * time.Second
+
+// maxRequestSize bounds a single request line.
+const maxRequestSize = 64 * 1024
+
+// Request is a command sent by a client.
+type Request struct {
+	Command string `json:"command"`
+	Text    string `json:"text,omitempty"`
+	Project string `json:"project,omitempty"`
+	TaskID  string `json:"task_id,omitempty"`
+}
+
+// Validate checks that the command is known and has its required fields.
+func (r Request) Validate() error {
+	switch r.Command {
+	case CommandAdd:
+		if r.Text == "" {
+			return errors.New("add requires text")
+		}
+	case CommandProject:
+		if r.Project == "" {
+			return errors.New("project requires project")
+		}
+	case CommandPomodoro:
+		if r.TaskID == "" {
+			return errors.New("pomodoro requires task_id")
+		}
+	case CommandRefresh:
+	case "":
+		return errors.New("missing command")
+	default:
+		return fmt.Errorf("unknown command %q", r.Command)
+	}
+	return nil
+}
+
+// Response is the server's answer to a request.
+type Response struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// Msg carries a request into the Bubble Tea program. The model must call
+// Reply exactly once.
+type Msg struct {
+	Request Request
+	reply   chan Response
+}
+
+// NewMsg returns a Msg for req and the channel its reply is delivered on.
+func NewMsg(req Request) (Msg, <-chan Response) {
+	reply := make(chan Response, 1)
+	return Msg{Request: req, reply: reply}, reply
+}
+
+// Reply reports the outcome of the request to the client. A nil error means
+// success. Replies after the first are dropped.
+func (m Msg) Reply(err error) {
+	resp := Response{OK: err == nil}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	select {
+	case m.reply <- resp:
+	default:
+	}
+}
+
+// SocketPath returns the control socket path inside dataDir.
+func SocketPath(dataDir string) string {
+	return filepath.Join(dataDir, SocketName)
+}
+
+// Server accepts control connections on a Unix socket.
+type Server struct {
+	path     string
+	listener net.Listener
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Listen creates the control socket at path. A stale socket left behind by a
+// crashed instance is replaced; a live one is reported as an error.
+func Listen(path string) (*Server, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("another instance is already listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	// Only the owner may drive the app
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+
+	return &Server{path: path, listener: listener}, nil
+}
+
+// Serve accepts connections until Close is called, delivering each valid
+// request through send (typically tea.Program.Send).
+func (s *Server) Serve(send func(tea.Msg)) error {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		go s.handleConn(conn, send)
+	}
+}
+
+// Close stops the server and removes the socket file.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	err := s.listener.Close()
+	os.Remove(s.path)
+	return err
+}
+
+// handleConn answers requests on conn, one JSON object per line.
+func (s *Server) handleConn(conn net.Conn, send func(tea.Msg)) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), maxRequestSize)
+	enc := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		resp := s.handleRequest(scanner.Bytes(), send)
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// handleRequest decodes and dispatches a single request.
+func (s *Server) handleRequest(line []byte, send func(tea.Msg)) Response {
+	var req Request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return Response{Error: fmt.Sprintf("invalid request: %v", err)}
+	}
+	if err := req.Validate(); err != nil {
+		return Response{Error: err.Error()}
+	}
+
+	msg, reply := NewMsg(req)
+	send(msg)
+
+	select {
+	case resp := <-reply:
+		return resp
+	case <-time.After(replyTimeout):
+		return Response{Error: "timed out waiting for the app"}
+	}
+}
+
+// Send delivers req to the instance listening on path and returns its response.
+func Send(path string, req Request) (*Response, error) {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", path, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(replyTimeout + time.Second))
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,106 @@
+package control
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func startServer(t *testing.T, send func(tea.Msg)) string {
+	t.Helper()
+	path := SocketPath(t.TempDir())
+	srv, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	go srv.Serve(send)
+	t.Cleanup(func() { srv.Close() })
+	return path
+}
+
+func TestSend_RoundTrip(t *testing.T) {
+	var got []Request
+	path := startServer(t, func(m tea.Msg) {
+		msg := m.(Msg)
+		got = append(got, msg.Request)
+		if msg.Request.TaskID == "missing" {
+			msg.Reply(errors.New("task not found: missing"))
+			return
+		}
+		msg.Reply(nil)
+	})
+
+	resp, err := Send(path, Request{Command: CommandAdd, Text: "Buy milk"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if !resp.OK {
+		t.Errorf("expected OK, got %+v", resp)
+	}
+
+	resp, err = Send(path, Request{Command: CommandPomodoro, TaskID: "missing"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.OK || resp.Error != "task not found: missing" {
+		t.Errorf("expected error response, got %+v", resp)
+	}
+
+	if len(got) != 2 || got[0].Text != "Buy milk" {
+		t.Errorf("unexpected requests delivered: %+v", got)
+	}
+}
+
+func TestSend_InvalidRequestsAreNotDelivered(t *testing.T) {
+	delivered := false
+	path := startServer(t, func(m tea.Msg) {
+		delivered = true
+		m.(Msg).Reply(nil)
+	})
+
+	for _, req := range []Request{
+		{},
+		{Command: "shutdown"},
+		{Command: CommandAdd},
+		{Command: CommandProject},
+	} {
+		resp, err := Send(path, req)
+		if err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+		if resp.OK || resp.Error == "" {
+			t.Errorf("request %+v: expected error response, got %+v", req, resp)
+		}
+	}
+	if delivered {
+		t.Error("invalid requests must not reach the program")
+	}
+}
+
+func TestListen_ReplacesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SocketName)
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen over stale socket: %v", err)
+	}
+	defer srv.Close()
+
+	if _, err := Listen(path); err == nil {
+		t.Error("expected error when another instance is listening")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket permissions = %o, want 600", perm)
+	}
+}
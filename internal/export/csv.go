@@ -0,0 +1,68 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// csvHeader lists the columns written by writeCSV.
+var csvHeader = []string{
+	"id", "parent_id", "depth", "project", "section", "content", "description",
+	"priority", "due", "due_string", "recurring", "deadline", "labels", "completed", "comments", "url",
+}
+
+// writeCSV writes one row per task in display order. Priority uses the
+// p1-p4 numbering and comments are separated by blank lines.
+func writeCSV(w io.Writer, d *Data) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	var writeErr error
+	walkTasks(d.outline(), func(po *projectOutline, section *api.Section, n *node, depth int) {
+		if writeErr != nil {
+			return
+		}
+		t := n.task
+
+		var parentID, sectionName, dueString string
+		recurring := false
+		if t.ParentID != nil {
+			parentID = *t.ParentID
+		}
+		if section != nil {
+			sectionName = section.Name
+		}
+		if t.Due != nil {
+			dueString = t.Due.String
+			recurring = t.Due.IsRecurring
+		}
+
+		comments := make([]string, 0, len(d.Comments[t.ID]))
+		for _, c := range d.Comments[t.ID] {
+			comments = append(comments, c.Content)
+		}
+
+		writeErr = cw.Write([]string{
+			t.ID, parentID, strconv.Itoa(depth), po.path, sectionName, t.Content, t.Description,
+			strconv.Itoa(displayPriority(t.Priority)), dueDate(t), dueString, strconv.FormatBool(recurring),
+			deadlineDate(t), strings.Join(t.Labels, ","), strconv.FormatBool(t.Checked),
+			strings.Join(comments, "\n\n"), t.URL,
+		})
+	})
+	if writeErr != nil {
+		return fmt.Errorf("failed to write CSV: %w", writeErr)
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,338 @@
+// Package export writes Todoist projects, sections and tasks to Markdown,
+// CSV, JSON and todo.txt files.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// Supported formats.
+const (
+	FormatMarkdown = "md"
+	FormatCSV      = "csv"
+	FormatJSON     = "json"
+	FormatTodoTxt  = "todo.txt"
+)
+
+// Version identifies the layout of the JSON export.
+const Version = 1
+
+// ParseFormat normalizes a format name, accepting common aliases such as
+// "markdown" and "todotxt".
+func ParseFormat(name string) (string, error) {
+	switch strings.ToLower(name) {
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "csv":
+		return FormatCSV, nil
+	case "json":
+		return FormatJSON, nil
+	case "todo.txt", "todotxt", "txt":
+		return FormatTodoTxt, nil
+	}
+	return "", fmt.Errorf("unknown export format %q (want md, csv, json or todo.txt)", name)
+}
+
+// Data is the set of objects to export.
+type Data struct {
+	Version    int                      `json:"version"`
+	ExportedAt time.Time                `json:"exported_at"`
+	Projects   []api.Project            `json:"projects"`
+	Sections   []api.Section            `json:"sections"`
+	Tasks      []api.Task               `json:"tasks"`
+	Comments   map[string][]api.Comment `json:"comments"` // By task ID
+}
+
+// Select builds export data for tasks, keeping only the projects and
+// sections they belong to.
+func Select(projects []api.Project, sections []api.Section, tasks []api.Task) *Data {
+	usedProjects := make(map[string]bool)
+	usedSections := make(map[string]bool)
+	for _, t := range tasks {
+		usedProjects[t.ProjectID] = true
+		if t.SectionID != nil {
+			usedSections[*t.SectionID] = true
+		}
+	}
+
+	d := &Data{Tasks: tasks}
+	for _, p := range projects {
+		if usedProjects[p.ID] {
+			d.Projects = append(d.Projects, p)
+		}
+	}
+	for _, s := range sections {
+		if usedSections[s.ID] {
+			d.Sections = append(d.Sections, s)
+		}
+	}
+	return d
+}
+
+// LoadComments fetches the comments of every task that has any.
+func LoadComments(ctx context.Context, client *api.Client, tasks []api.Task) (map[string][]api.Comment, error) {
+	var ids []string
+	for _, t := range tasks {
+		if t.NoteCount > 0 {
+			ids = append(ids, t.ID)
+		}
+	}
+	return client.GetCommentsForTasks(ctx, ids)
+}
+
+// Write writes d to w in the given format.
+func Write(w io.Writer, format string, d *Data) error {
+	switch format {
+	case FormatMarkdown:
+		return writeMarkdown(w, d)
+	case FormatCSV:
+		return writeCSV(w, d)
+	case FormatJSON:
+		return writeJSON(w, d)
+	case FormatTodoTxt:
+		return writeTodoTxt(w, d)
+	}
+	return fmt.Errorf("unknown export format %q", format)
+}
+
+// writeJSON writes d losslessly as indented JSON.
+func writeJSON(w io.Writer, d *Data) error {
+	out := *d
+	out.Version = Version
+	if out.ExportedAt.IsZero() {
+		out.ExportedAt = time.Now().UTC()
+	}
+	if out.Comments == nil {
+		out.Comments = map[string][]api.Comment{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// node is a task with its subtasks.
+type node struct {
+	task     api.Task
+	children []*node
+}
+
+// sectionOutline holds the root tasks of a section.
+type sectionOutline struct {
+	section api.Section
+	tasks   []*node
+}
+
+// projectOutline holds the tasks of a project grouped by section.
+type projectOutline struct {
+	project  api.Project
+	path     string // Project name prefixed by its parents, e.g. "Work / Backend"
+	loose    []*node
+	sections []sectionOutline
+}
+
+// outline arranges d in display order: projects by hierarchy, sections by
+// order and tasks as trees sorted by child order.
+func (d *Data) outline() []projectOutline {
+	// Build task trees; tasks whose parent is not exported become roots
+	nodes := make(map[string]*node, len(d.Tasks))
+	for _, t := range d.Tasks {
+		nodes[t.ID] = &node{task: t}
+	}
+	var roots []*node
+	for _, t := range d.Tasks {
+		n := nodes[t.ID]
+		if t.ParentID != nil {
+			if parent, ok := nodes[*t.ParentID]; ok {
+				parent.children = append(parent.children, n)
+				continue
+			}
+		}
+		roots = append(roots, n)
+	}
+	for _, n := range nodes {
+		sortNodes(n.children)
+	}
+	sortNodes(roots)
+
+	// Group roots by project and section; sections of projects that are not
+	// exported are dropped and their tasks listed with the project
+	projectIDs := make(map[string]bool, len(d.Projects))
+	for _, p := range d.Projects {
+		projectIDs[p.ID] = true
+	}
+	sectionsByID := make(map[string]api.Section, len(d.Sections))
+	for _, s := range d.Sections {
+		if projectIDs[s.ProjectID] {
+			sectionsByID[s.ID] = s
+		}
+	}
+	looseByProject := make(map[string][]*node)
+	bySection := make(map[string][]*node)
+	for _, n := range roots {
+		if n.task.SectionID != nil {
+			if _, ok := sectionsByID[*n.task.SectionID]; ok {
+				bySection[*n.task.SectionID] = append(bySection[*n.task.SectionID], n)
+				continue
+			}
+		}
+		looseByProject[n.task.ProjectID] = append(looseByProject[n.task.ProjectID], n)
+	}
+
+	sections := append([]api.Section(nil), d.Sections...)
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].SectionOrder < sections[j].SectionOrder
+	})
+
+	var result []projectOutline
+	seen := make(map[string]bool)
+	for _, p := range orderProjects(d.Projects) {
+		seen[p.project.ID] = true
+		po := projectOutline{project: p.project, path: p.path, loose: looseByProject[p.project.ID]}
+		for _, s := range sections {
+			if s.ProjectID == p.project.ID {
+				po.sections = append(po.sections, sectionOutline{section: s, tasks: bySection[s.ID]})
+			}
+		}
+		result = append(result, po)
+	}
+
+	// Tasks of projects that were not exported still need a home
+	for _, n := range roots {
+		id := n.task.ProjectID
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, projectOutline{
+			project: api.Project{ID: id, Name: id},
+			path:    id,
+			loose:   looseByProject[id],
+		})
+	}
+	return result
+}
+
+// orderedProject is a project with its display path.
+type orderedProject struct {
+	project api.Project
+	path    string
+}
+
+// orderProjects returns projects depth-first, parents before children.
+func orderProjects(projects []api.Project) []orderedProject {
+	present := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		present[p.ID] = true
+	}
+	children := make(map[string][]api.Project)
+	var roots []api.Project
+	for _, p := range projects {
+		if p.ParentID != nil && present[*p.ParentID] {
+			children[*p.ParentID] = append(children[*p.ParentID], p)
+		} else {
+			roots = append(roots, p)
+		}
+	}
+
+	byOrder := func(list []api.Project) {
+		sort.SliceStable(list, func(i, j int) bool {
+			// Keep the Inbox first, as Todoist does
+			if list[i].InboxProject != list[j].InboxProject {
+				return list[i].InboxProject
+			}
+			return list[i].ChildOrder < list[j].ChildOrder
+		})
+	}
+
+	var result []orderedProject
+	var walk func(list []api.Project, prefix string)
+	walk = func(list []api.Project, prefix string) {
+		byOrder(list)
+		for _, p := range list {
+			path := prefix + p.Name
+			result = append(result, orderedProject{project: p, path: path})
+			walk(children[p.ID], path+" / ")
+		}
+	}
+	walk(roots, "")
+	return result
+}
+
+// sortNodes sorts sibling tasks by child order.
+func sortNodes(nodes []*node) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].task.ChildOrder < nodes[j].task.ChildOrder
+	})
+}
+
+// walkTasks calls fn for every task in the outline in display order.
+func walkTasks(outline []projectOutline, fn func(po *projectOutline, section *api.Section, n *node, depth int)) {
+	var walk func(po *projectOutline, section *api.Section, nodes []*node, depth int)
+	walk = func(po *projectOutline, section *api.Section, nodes []*node, depth int) {
+		for _, n := range nodes {
+			fn(po, section, n, depth)
+			walk(po, section, n.children, depth+1)
+		}
+	}
+	for i := range outline {
+		po := &outline[i]
+		walk(po, nil, po.loose, 0)
+		for j := range po.sections {
+			walk(po, &po.sections[j].section, po.sections[j].tasks, 0)
+		}
+	}
+}
+
+// displayPriority converts an API priority (4 is urgent) to Todoist's
+// p1-p4 numbering (1 is urgent).
+func displayPriority(p int) int {
+	if p < 1 || p > 4 {
+		return 4
+	}
+	return 5 - p
+}
+
+// dueDate returns the due date or datetime of t, or "".
+func dueDate(t api.Task) string {
+	if t.Due == nil {
+		return ""
+	}
+	if t.Due.Datetime != nil && *t.Due.Datetime != "" {
+		return *t.Due.Datetime
+	}
+	return t.Due.Date
+}
+
+// deadlineDate returns the deadline of t, or "".
+func deadlineDate(t api.Task) string {
+	if t.Deadline == nil {
+		return ""
+	}
+	return t.Deadline.Date
+}
+
+// WriteFile writes d to path in the given format.
+func WriteFile(path, format string, d *Data) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	if err := Write(f, format, d); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+func strPtr(s string) *string { return &s }
+
+func testData() *Data {
+	return &Data{
+		Projects: []api.Project{
+			{ID: "p2", Name: "Backend", ParentID: strPtr("p1"), ChildOrder: 1},
+			{ID: "p1", Name: "Work", ChildOrder: 2},
+			{ID: "in", Name: "Inbox", InboxProject: true, ChildOrder: 3},
+		},
+		Sections: []api.Section{
+			{ID: "s1", Name: "Next week", ProjectID: "p1", SectionOrder: 1},
+		},
+		Tasks: []api.Task{
+			{ID: "t2", Content: "Write tests", ProjectID: "p1", ParentID: strPtr("t1"), ChildOrder: 1, Priority: 1},
+			{
+				ID: "t1", Content: "Ship release", ProjectID: "p1", ChildOrder: 1, Priority: 4,
+				Labels: []string{"deep work"}, Description: "Tag and publish\nthe binaries",
+				Due: &api.Due{Date: "2026-05-01", String: "may 1"}, Deadline: &api.Deadline{Date: "2026-05-03"},
+				AddedAt: "2026-04-01T10:00:00Z", NoteCount: 1,
+			},
+			{ID: "t3", Content: "Plan sprint", ProjectID: "p1", SectionID: strPtr("s1"), Priority: 2},
+			{ID: "t4", Content: "Fix bug", ProjectID: "p2", Priority: 1, Checked: true},
+		},
+		Comments: map[string][]api.Comment{
+			"t1": {{ID: "c1", Content: "Blocked on CI", PostedAt: "2026-04-02T08:00:00Z"}},
+		},
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatMarkdown, testData()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# Inbox
+
+# Work
+
+- [ ] Ship release p1 @deep work due:2026-05-01 deadline:2026-05-03
+  Tag and publish
+  the binaries
+  > Blocked on CI (2026-04-02)
+  - [ ] Write tests
+
+## Next week
+
+- [ ] Plan sprint p3
+
+# Work / Backend
+
+- [x] Fix bug
+`
+	if buf.String() != want {
+		t.Errorf("unexpected markdown:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteTodoTxt(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatTodoTxt, testData()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"(A) 2026-04-01 Ship release +Work @deep_work due:2026-05-01 deadline:2026-05-03 id:t1",
+		"Write tests +Work parent:t1",
+		"(C) Plan sprint +Work section:Next_week",
+		"x Fix bug +Backend",
+	}
+	got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected todo.txt:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, testData()); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("expected header and 4 rows, got %d", len(rows))
+	}
+
+	col := make(map[string]int)
+	for i, name := range rows[0] {
+		col[name] = i
+	}
+	sub := rows[2]
+	if sub[col["id"]] != "t2" || sub[col["parent_id"]] != "t1" || sub[col["depth"]] != "1" {
+		t.Errorf("unexpected subtask row: %v", sub)
+	}
+	if rows[1][col["comments"]] != "Blocked on CI" || rows[1][col["priority"]] != "1" {
+		t.Errorf("unexpected task row: %v", rows[1])
+	}
+	if rows[3][col["section"]] != "Next week" || rows[4][col["project"]] != "Work / Backend" {
+		t.Errorf("unexpected grouping: %v / %v", rows[3], rows[4])
+	}
+}
+
+func TestWriteJSON_Lossless(t *testing.T) {
+	in := testData()
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, in); err != nil {
+		t.Fatal(err)
+	}
+
+	var out Data
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if out.Version != Version {
+		t.Errorf("version = %d, want %d", out.Version, Version)
+	}
+	if !reflect.DeepEqual(out.Tasks, in.Tasks) || !reflect.DeepEqual(out.Comments, in.Comments) {
+		t.Error("tasks or comments changed in the JSON round trip")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	d := testData()
+	sel := Select(d.Projects, d.Sections, d.Tasks[3:])
+	if len(sel.Projects) != 1 || sel.Projects[0].ID != "p2" || len(sel.Sections) != 0 {
+		t.Errorf("unexpected selection: %+v %+v", sel.Projects, sel.Sections)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]string{"markdown": FormatMarkdown, "TODOTXT": FormatTodoTxt, "csv": FormatCSV} {
+		if got, err := ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xlsx"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,96 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// writeMarkdown writes one heading per project and section with tasks as
+// nested checklists. Descriptions and comments are indented under their task.
+func writeMarkdown(w io.Writer, d *Data) error {
+	bw := bufio.NewWriter(w)
+	outline := d.outline()
+
+	for i, po := range outline {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		fmt.Fprintf(bw, "# %s\n", po.path)
+		if len(po.loose) > 0 {
+			fmt.Fprintln(bw)
+			writeMarkdownTasks(bw, d, po.loose, 0)
+		}
+		for _, so := range po.sections {
+			fmt.Fprintf(bw, "\n## %s\n", so.section.Name)
+			if len(so.tasks) > 0 {
+				fmt.Fprintln(bw)
+				writeMarkdownTasks(bw, d, so.tasks, 0)
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// writeMarkdownTasks writes nodes and their subtasks as checklist items.
+func writeMarkdownTasks(w io.Writer, d *Data, nodes []*node, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, n := range nodes {
+		t := n.task
+		check := " "
+		if t.Checked {
+			check = "x"
+		}
+		fmt.Fprintf(w, "%s- [%s] %s\n", indent, check, markdownItem(t))
+
+		body := indent + "  "
+		if t.Description != "" {
+			for _, line := range strings.Split(t.Description, "\n") {
+				fmt.Fprintf(w, "%s%s\n", body, line)
+			}
+		}
+		for _, c := range d.Comments[t.ID] {
+			writeMarkdownComment(w, body, c)
+		}
+
+		writeMarkdownTasks(w, d, n.children, depth+1)
+	}
+}
+
+// markdownItem renders a task's content followed by its metadata in the
+// same key:value style as todo.txt.
+func markdownItem(t api.Task) string {
+	parts := []string{t.Content}
+	if p := displayPriority(t.Priority); p < 4 {
+		parts = append(parts, fmt.Sprintf("p%d", p))
+	}
+	for _, l := range t.Labels {
+		parts = append(parts, "@"+l)
+	}
+	if due := dueDate(t); due != "" {
+		parts = append(parts, "due:"+due)
+	}
+	if deadline := deadlineDate(t); deadline != "" {
+		parts = append(parts, "deadline:"+deadline)
+	}
+	return strings.Join(parts, " ")
+}
+
+// writeMarkdownComment writes a comment as a block quote.
+func writeMarkdownComment(w io.Writer, indent string, c api.Comment) {
+	lines := strings.Split(c.Content, "\n")
+	if c.PostedAt != "" {
+		date := c.PostedAt
+		if len(date) > 10 {
+			date = date[:10]
+		}
+		lines[len(lines)-1] += " (" + date + ")"
+	}
+	for _, line := range lines {
+		fmt.Fprintf(w, "%s> %s\n", indent, line)
+	}
+}
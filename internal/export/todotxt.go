@@ -0,0 +1,72 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// writeTodoTxt writes one line per task in the todo.txt format. Subtasks
+// refer to their parent with parent:<id>; descriptions and comments have no
+// todo.txt equivalent and are left out.
+func writeTodoTxt(w io.Writer, d *Data) error {
+	bw := bufio.NewWriter(w)
+
+	hasChildren := make(map[string]bool)
+	for _, t := range d.Tasks {
+		if t.ParentID != nil {
+			hasChildren[*t.ParentID] = true
+		}
+	}
+
+	walkTasks(d.outline(), func(po *projectOutline, section *api.Section, n *node, depth int) {
+		t := n.task
+		var parts []string
+
+		if t.Checked {
+			parts = append(parts, "x")
+			if t.CompletedAt != nil && len(*t.CompletedAt) >= 10 {
+				parts = append(parts, (*t.CompletedAt)[:10])
+			}
+		} else if p := displayPriority(t.Priority); p < 4 {
+			// p1-p3 map to (A)-(C); completed tasks carry no priority
+			parts = append(parts, fmt.Sprintf("(%c)", 'A'+p-1))
+		}
+		if len(t.AddedAt) >= 10 {
+			parts = append(parts, t.AddedAt[:10])
+		}
+
+		parts = append(parts, strings.Join(strings.Fields(t.Content), " "))
+		parts = append(parts, "+"+todoTxtWord(po.project.Name))
+		if section != nil {
+			parts = append(parts, "section:"+todoTxtWord(section.Name))
+		}
+		for _, l := range t.Labels {
+			parts = append(parts, "@"+todoTxtWord(l))
+		}
+		if t.Due != nil && t.Due.Date != "" {
+			parts = append(parts, "due:"+t.Due.Date[:min(len(t.Due.Date), 10)])
+		}
+		if deadline := deadlineDate(t); deadline != "" {
+			parts = append(parts, "deadline:"+deadline)
+		}
+		if hasChildren[t.ID] {
+			parts = append(parts, "id:"+t.ID)
+		}
+		if t.ParentID != nil {
+			parts = append(parts, "parent:"+*t.ParentID)
+		}
+
+		fmt.Fprintln(bw, strings.Join(parts, " "))
+	})
+
+	return bw.Flush()
+}
+
+// todoTxtWord turns a name into a single todo.txt word.
+func todoTxtWord(name string) string {
+	return strings.Join(strings.Fields(name), "_")
+}
@@ -0,0 +1,217 @@
+package fake
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
+)
+
+// Layouts of due dates without and with a time of day (floating).
+const (
+	dateLayout     = "2006-01-02"
+	datetimeLayout = "2006-01-02T15:04:05"
+)
+
+// parseDue understands a subset of Todoist's date language: "today",
+// "tomorrow", "yesterday", "next week", "in N days", weekday names, ISO
+// dates, an optional " at 3pm" or " at 15:30", and "every ..." for
+// recurring dates. It reports false for anything else.
+func parseDue(text string, today time.Time) (*api.Due, bool) {
+	lower := strings.ToLower(strings.TrimSpace(text))
+	if lower == "" {
+		return nil, false
+	}
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	if rule, ok := strings.CutPrefix(lower, "every "); ok {
+		day := today
+		if wd, ok := utils.ParseWeekday(rule); ok {
+			day = utils.NextWeekday(today.AddDate(0, 0, -1), wd)
+		} else if !isInterval(rule) {
+			return nil, false
+		}
+		return &api.Due{String: text, Date: day.Format(dateLayout), IsRecurring: true, Lang: "en"}, true
+	}
+
+	datePart, timePart, hasTime := strings.Cut(lower, " at ")
+	day, ok := parseDay(datePart, today)
+	if !ok {
+		// A bare ISO date or datetime
+		if t, err := time.Parse(datetimeLayout, lower); err == nil {
+			return &api.Due{String: text, Date: t.Format(datetimeLayout), Lang: "en"}, true
+		}
+		if t, err := time.Parse(time.RFC3339, text); err == nil {
+			datetime := t.UTC().Format(time.RFC3339)
+			return &api.Due{String: text, Date: t.UTC().Format(datetimeLayout), Datetime: &datetime, Lang: "en"}, true
+		}
+		return nil, false
+	}
+
+	due := &api.Due{String: text, Date: day.Format(dateLayout), Lang: "en"}
+	if hasTime {
+		clock, ok := parseClock(timePart)
+		if !ok {
+			return nil, false
+		}
+		due.Date = day.Add(clock).Format(datetimeLayout)
+	}
+	return due, true
+}
+
+// parseDay resolves the date part of a due string.
+func parseDay(text string, today time.Time) (time.Time, bool) {
+	switch text {
+	case "today":
+		return today, true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "next week":
+		return today.AddDate(0, 0, 7), true
+	}
+	if n, ok := strings.CutPrefix(text, "in "); ok {
+		n = strings.TrimSuffix(strings.TrimSuffix(n, "s"), " day")
+		if days, err := strconv.Atoi(n); err == nil {
+			return today.AddDate(0, 0, days), true
+		}
+	}
+	if wd, ok := utils.ParseWeekday(strings.TrimPrefix(text, "next ")); ok {
+		return utils.NextWeekday(today, wd), true
+	}
+	if t, err := time.Parse(dateLayout, text); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// parseClock reads "15:30", "3pm" or "3:30pm" as an offset from midnight.
+func parseClock(text string) (time.Duration, bool) {
+	for _, layout := range []string{"15:04", "3pm", "3:04pm"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(text)); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, true
+		}
+	}
+	return 0, false
+}
+
+// isInterval reports whether rule is a recurrence the server can advance.
+func isInterval(rule string) bool {
+	switch rule {
+	case "day", "week", "month", "year", "weekday", "workday":
+		return true
+	}
+	fields := strings.Fields(rule)
+	if len(fields) == 2 {
+		if _, err := strconv.Atoi(fields[0]); err == nil {
+			switch strings.TrimSuffix(fields[1], "s") {
+			case "day", "week", "month", "year":
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nextOccurrence moves a recurring due date to its next occurrence after
+// the current one, keeping the time of day.
+func nextOccurrence(due *api.Due) *api.Due {
+	layout := dateLayout
+	if len(due.Date) > len(dateLayout) {
+		layout = datetimeLayout
+	}
+	current, err := time.Parse(layout, due.Date)
+	if err != nil {
+		return due
+	}
+
+	rule := strings.TrimPrefix(strings.ToLower(due.String), "every ")
+	var next time.Time
+	if wd, ok := utils.ParseWeekday(rule); ok {
+		next = utils.NextWeekday(current, wd)
+	} else {
+		n, unit := 1, rule
+		if fields := strings.Fields(rule); len(fields) == 2 {
+			if v, err := strconv.Atoi(fields[0]); err == nil {
+				n, unit = v, strings.TrimSuffix(fields[1], "s")
+			}
+		}
+		switch unit {
+		case "week":
+			next = current.AddDate(0, 0, 7*n)
+		case "month":
+			next = current.AddDate(0, n, 0)
+		case "year":
+			next = current.AddDate(n, 0, 0)
+		case "weekday", "workday":
+			next = current.AddDate(0, 0, 1)
+			for next.Weekday() == time.Saturday || next.Weekday() == time.Sunday {
+				next = next.AddDate(0, 0, 1)
+			}
+		default:
+			next = current.AddDate(0, 0, n)
+		}
+	}
+
+	moved := *due
+	moved.Date = next.Format(layout)
+	moved.Datetime = nil
+	return &moved
+}
+
+// quickAdd holds the task fields found in quick add text.
+type quickAdd struct {
+	content  string
+	project  string
+	labels   []string
+	priority int
+	due      *api.Due
+	deadline *api.Deadline
+}
+
+// parseQuickAdd picks "#Project", "@label", "p1" to "p4", a "{date}"
+// deadline and a due date out of quick add text. What remains is the
+// content.
+func parseQuickAdd(text string, today time.Time) quickAdd {
+	var q quickAdd
+	if open := strings.Index(text, "{"); open >= 0 {
+		if end := strings.Index(text[open:], "}"); end > 0 {
+			midnight := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+			if day, ok := parseDay(strings.ToLower(strings.TrimSpace(text[open+1:open+end])), midnight); ok {
+				q.deadline = &api.Deadline{Date: day.Format(dateLayout), Lang: "en"}
+				text = text[:open] + " " + text[open+end+1:]
+			}
+		}
+	}
+
+	var words []string
+	for _, w := range strings.Fields(text) {
+		switch {
+		case strings.HasPrefix(w, "#") && len(w) > 1:
+			q.project = w[1:]
+		case strings.HasPrefix(w, "@") && len(w) > 1:
+			q.labels = append(q.labels, w[1:])
+		case len(w) == 2 && (w[0] == 'p' || w[0] == 'P') && w[1] >= '1' && w[1] <= '4':
+			q.priority = 5 - int(w[1]-'0')
+		default:
+			words = append(words, w)
+		}
+	}
+
+	// The longest run of words that reads as a date is the due date
+	for size := len(words); size > 0 && q.due == nil; size-- {
+		for i := 0; i+size <= len(words); i++ {
+			if due, ok := parseDue(strings.Join(words[i:i+size], " "), today); ok {
+				q.due = due
+				words = append(words[:i:i], words[i+size:]...)
+				break
+			}
+		}
+	}
+
+	q.content = strings.Join(words, " ")
+	return q
+}
@@ -0,0 +1,72 @@
+package fake
+
+import (
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
+)
+
+// NewDemo returns a server with a small made-up workspace, with due dates
+// relative to today, for demos and screenshots.
+func NewDemo() *Server {
+	s := New()
+	s.FullName, s.Email = "Demo User", "demo@example.com"
+	today := s.now()
+	day := func(offset int) *api.Due {
+		d := today.AddDate(0, 0, offset)
+		return &api.Due{String: d.Format("Jan 2"), Date: d.Format(dateLayout), Lang: "en"}
+	}
+	at := func(offset, hour int) *api.Due {
+		d := time.Date(today.Year(), today.Month(), today.Day()+offset, hour, 0, 0, 0, time.UTC)
+		return &api.Due{String: d.Format("Jan 2 3pm"), Date: d.Format(datetimeLayout), Lang: "en"}
+	}
+	str := func(v string) *string { return &v }
+
+	for _, name := range []string{"work", "errands", "reading", "waiting"} {
+		s.AddLabel(api.Label{Name: name})
+	}
+
+	work := s.AddProject(api.Project{Name: "Work", Color: "blue", IsFavorite: true, ChildOrder: 1, ViewStyle: "board", Shared: true, CanAssignTasks: true})
+	home := s.AddProject(api.Project{Name: "Home", Color: "green", ChildOrder: 2})
+	garden := s.AddProject(api.Project{Name: "Garden", Color: "lime_green", ParentID: &home.ID, ChildOrder: 1})
+	books := s.AddProject(api.Project{Name: "Reading List", Color: "violet", ChildOrder: 3})
+
+	s.AddCollaborator(work.ID, api.Collaborator{ID: s.UserID, Name: s.FullName, Email: s.Email})
+	s.AddCollaborator(work.ID, api.Collaborator{ID: "2", Name: "Alex Kim", Email: "alex@example.com"})
+
+	todo := s.AddSection(api.Section{Name: "To Do", ProjectID: work.ID, SectionOrder: 1})
+	doing := s.AddSection(api.Section{Name: "In Progress", ProjectID: work.ID, SectionOrder: 2})
+	s.AddSection(api.Section{Name: "Done", ProjectID: work.ID, SectionOrder: 3})
+
+	inbox := s.inbox().ID
+	s.AddTask(api.Task{Content: "Reply to Sam about the offsite", ProjectID: inbox, Priority: 3, Due: day(0), ChildOrder: 1})
+	s.AddTask(api.Task{Content: "Book dentist appointment", ProjectID: inbox, Due: day(2), ChildOrder: 2})
+	s.AddTask(api.Task{Content: "Look into standing desks", ProjectID: inbox, ChildOrder: 3})
+
+	review := s.AddTask(api.Task{Content: "Review pull requests", ProjectID: work.ID, SectionID: &doing.ID, Priority: 4, Labels: []string{"work"}, Due: at(0, 10), ChildOrder: 1, Duration: &api.Duration{Amount: 45, Unit: "minute"}})
+	s.AddTask(api.Task{Content: "Security fixes", ProjectID: work.ID, SectionID: &doing.ID, ParentID: &review.ID, Priority: 4, ChildOrder: 1})
+	s.AddTask(api.Task{Content: "Docs update", ProjectID: work.ID, SectionID: &doing.ID, ParentID: &review.ID, ChildOrder: 2})
+	s.AddTask(api.Task{Content: "Quarterly planning", Description: "Draft goals for next quarter and share with the team.", ProjectID: work.ID, SectionID: &todo.ID, Priority: 3, Due: day(3), Deadline: &api.Deadline{Date: today.AddDate(0, 0, 7).Format(dateLayout)}, ChildOrder: 2})
+	s.AddTask(api.Task{Content: "Weekly sync", ProjectID: work.ID, SectionID: &todo.ID, Labels: []string{"work"}, Due: &api.Due{String: "every monday", Date: utils.NextWeekday(today.AddDate(0, 0, -1), time.Monday).Format(dateLayout), IsRecurring: true, Lang: "en"}, ChildOrder: 3})
+	s.AddTask(api.Task{Content: "Update on-call rota", ProjectID: work.ID, SectionID: &todo.ID, Due: day(-2), ChildOrder: 4, ResponsibleUID: str("2")})
+
+	groceries := s.AddTask(api.Task{Content: "Buy groceries", ProjectID: home.ID, Priority: 2, Labels: []string{"errands"}, Due: day(0), ChildOrder: 1})
+	s.AddTask(api.Task{Content: "Water the plants", ProjectID: home.ID, Due: &api.Due{String: "every day", Date: today.Format(dateLayout), IsRecurring: true, Lang: "en"}, ChildOrder: 2})
+	s.AddTask(api.Task{Content: "Fix the leaking tap", ProjectID: home.ID, Priority: 3, Due: day(-1), ChildOrder: 3})
+	s.AddTask(api.Task{Content: "Plant tomatoes", ProjectID: garden.ID, Due: day(5), ChildOrder: 1})
+	s.AddTask(api.Task{Content: "Order compost", ProjectID: garden.ID, Labels: []string{"errands"}, ChildOrder: 2})
+
+	s.AddTask(api.Task{Content: "The Pragmatic Programmer", ProjectID: books.ID, Labels: []string{"reading"}, ChildOrder: 1})
+	s.AddTask(api.Task{Content: "Designing Data-Intensive Applications", ProjectID: books.ID, Labels: []string{"reading"}, ChildOrder: 2})
+	s.AddTask(api.Task{Content: "Finish chapter 3", ProjectID: books.ID, Checked: true, ChildOrder: 3})
+	s.AddTask(api.Task{Content: "Send invoice", ProjectID: work.ID, Checked: true, ChildOrder: 5})
+
+	s.AddComment(api.Comment{ItemID: &groceries.ID, Content: "Milk, eggs, bread and coffee"})
+	s.AddComment(api.Comment{ItemID: &review.ID, Content: "Start with the API changes", PostedUID: "2"})
+
+	s.AddFilter(api.Filter{Name: "Urgent", Query: "p1 & (today | overdue)", Color: "red", ItemOrder: 1})
+	s.AddFilter(api.Filter{Name: "Errands", Query: "@errands", Color: "orange", ItemOrder: 2})
+
+	return s
+}
@@ -0,0 +1,543 @@
+package fake
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// projectArgs are the project fields of a REST body or Sync command.
+type projectArgs struct {
+	ID          string  `json:"id"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	ParentID    *string `json:"parent_id"`
+	Color       *string `json:"color"`
+	IsFavorite  *bool   `json:"is_favorite"`
+	ViewStyle   *string `json:"view_style"`
+	ChildOrder  *int    `json:"child_order"`
+}
+
+func isActiveProject(p *api.Project) bool { return !p.IsDeleted && !p.IsArchived }
+
+func (s *Server) activeProject(id string) (*api.Project, *apiError) {
+	p := s.projects.get(id)
+	if p == nil || p.IsDeleted {
+		return nil, notFound("Project")
+	}
+	return p, nil
+}
+
+func (s *Server) addProject(a projectArgs) (*api.Project, *apiError) {
+	if a.Name == nil || *a.Name == "" {
+		return nil, missingArgument("name")
+	}
+	p := &api.Project{
+		ID:        s.newID(),
+		Name:      *a.Name,
+		Color:     "charcoal",
+		ViewStyle: "list",
+		Role:      "CREATOR",
+		CreatedAt: s.timestamp(),
+		UpdatedAt: s.timestamp(),
+	}
+	if a.ParentID != nil && *a.ParentID != "" {
+		if _, err := s.activeProject(*a.ParentID); err != nil {
+			return nil, notFound("Parent project")
+		}
+		p.ParentID = a.ParentID
+	}
+	if a.ChildOrder != nil {
+		p.ChildOrder = *a.ChildOrder
+	} else {
+		for _, other := range s.projects.items {
+			if other.ChildOrder >= p.ChildOrder {
+				p.ChildOrder = other.ChildOrder + 1
+			}
+		}
+	}
+	s.applyProjectArgs(p, a)
+	s.projects.put(p.ID, p, s.bump())
+	return p, nil
+}
+
+func (s *Server) updateProjectFields(id string, a projectArgs) (*api.Project, *apiError) {
+	p, err := s.activeProject(id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Name != nil {
+		if *a.Name == "" {
+			return nil, invalidArgument("Name can't be empty")
+		}
+		p.Name = *a.Name
+	}
+	if a.ChildOrder != nil {
+		p.ChildOrder = *a.ChildOrder
+	}
+	s.applyProjectArgs(p, a)
+	p.UpdatedAt = s.timestamp()
+	s.projects.touch(p.ID, s.bump())
+	return p, nil
+}
+
+func (s *Server) applyProjectArgs(p *api.Project, a projectArgs) {
+	if a.Description != nil {
+		p.Description = *a.Description
+	}
+	if a.Color != nil && *a.Color != "" {
+		p.Color = *a.Color
+	}
+	if a.IsFavorite != nil {
+		p.IsFavorite = *a.IsFavorite
+	}
+	if a.ViewStyle != nil && *a.ViewStyle != "" {
+		p.ViewStyle = *a.ViewStyle
+	}
+}
+
+// removeProject deletes a project with its subprojects, sections and tasks.
+func (s *Server) removeProject(id string) *apiError {
+	p, err := s.activeProject(id)
+	if err != nil {
+		return err
+	}
+	if p.InboxProject {
+		return &apiError{http.StatusForbidden, "FORBIDDEN", "Inbox project can't be deleted"}
+	}
+
+	version := s.bump()
+	deleted := map[string]bool{}
+	var mark func(id string)
+	mark = func(id string) {
+		deleted[id] = true
+		s.projects.get(id).IsDeleted = true
+		s.projects.touch(id, version)
+		for _, child := range s.projects.list(isActiveProject) {
+			if child.ParentID != nil && *child.ParentID == id {
+				mark(child.ID)
+			}
+		}
+	}
+	mark(id)
+
+	for _, secID := range s.sections.order {
+		if sec := s.sections.get(secID); deleted[sec.ProjectID] && !sec.IsDeleted {
+			sec.IsDeleted = true
+			s.sections.touch(secID, version)
+		}
+	}
+	for _, taskID := range s.tasks.order {
+		if t := s.tasks.get(taskID); deleted[t.ProjectID] && !t.IsDeleted {
+			t.IsDeleted = true
+			s.tasks.touch(taskID, version)
+		}
+	}
+	return nil
+}
+
+// sectionArgs are the section fields of a REST body or Sync command.
+type sectionArgs struct {
+	ID           string  `json:"id"`
+	Name         *string `json:"name"`
+	ProjectID    string  `json:"project_id"`
+	Order        *int    `json:"order"`
+	SectionOrder *int    `json:"section_order"`
+}
+
+func (s *Server) activeSection(id string) (*api.Section, *apiError) {
+	sec := s.sections.get(id)
+	if sec == nil || sec.IsDeleted {
+		return nil, notFound("Section")
+	}
+	return sec, nil
+}
+
+func (s *Server) addSection(a sectionArgs) (*api.Section, *apiError) {
+	if a.Name == nil || *a.Name == "" {
+		return nil, missingArgument("name")
+	}
+	if a.ProjectID == "" {
+		return nil, missingArgument("project_id")
+	}
+	if _, err := s.activeProject(a.ProjectID); err != nil {
+		return nil, err
+	}
+
+	sec := &api.Section{
+		ID:        s.newID(),
+		Name:      *a.Name,
+		ProjectID: a.ProjectID,
+		UserID:    s.UserID,
+		AddedAt:   s.timestamp(),
+		UpdatedAt: s.timestamp(),
+	}
+	if order := sectionOrder(a); order != nil {
+		sec.SectionOrder = *order
+	} else {
+		for _, other := range s.sections.items {
+			if other.ProjectID == sec.ProjectID && other.SectionOrder >= sec.SectionOrder {
+				sec.SectionOrder = other.SectionOrder + 1
+			}
+		}
+	}
+	s.sections.put(sec.ID, sec, s.bump())
+	return sec, nil
+}
+
+func (s *Server) updateSectionFields(id string, a sectionArgs) (*api.Section, *apiError) {
+	sec, err := s.activeSection(id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Name != nil && *a.Name != "" {
+		sec.Name = *a.Name
+	}
+	if order := sectionOrder(a); order != nil {
+		sec.SectionOrder = *order
+	}
+	sec.UpdatedAt = s.timestamp()
+	s.sections.touch(sec.ID, s.bump())
+	return sec, nil
+}
+
+// sectionOrder returns the position given as order (REST) or section_order.
+func sectionOrder(a sectionArgs) *int {
+	if a.SectionOrder != nil {
+		return a.SectionOrder
+	}
+	return a.Order
+}
+
+// removeSection deletes a section and its tasks.
+func (s *Server) removeSection(id string) *apiError {
+	sec, err := s.activeSection(id)
+	if err != nil {
+		return err
+	}
+	version := s.bump()
+	sec.IsDeleted = true
+	s.sections.touch(sec.ID, version)
+	for _, taskID := range s.tasks.order {
+		if t := s.tasks.get(taskID); t.SectionID != nil && *t.SectionID == id && !t.IsDeleted {
+			t.IsDeleted = true
+			s.tasks.touch(taskID, version)
+		}
+	}
+	return nil
+}
+
+// labelArgs are the label fields of a REST body or Sync command.
+type labelArgs struct {
+	ID         string  `json:"id"`
+	Name       *string `json:"name"`
+	Color      *string `json:"color"`
+	Order      *int    `json:"order"`
+	ItemOrder  *int    `json:"item_order"`
+	IsFavorite *bool   `json:"is_favorite"`
+}
+
+func (s *Server) activeLabel(id string) (*api.Label, *apiError) {
+	l := s.labels.get(id)
+	if l == nil || l.IsDeleted {
+		return nil, notFound("Label")
+	}
+	return l, nil
+}
+
+func (s *Server) addLabel(a labelArgs) (*api.Label, *apiError) {
+	if a.Name == nil || *a.Name == "" {
+		return nil, missingArgument("name")
+	}
+	for _, other := range s.labels.list(func(l *api.Label) bool { return !l.IsDeleted }) {
+		if strings.EqualFold(other.Name, *a.Name) {
+			return nil, invalidArgument("Label already exists")
+		}
+	}
+	l := &api.Label{ID: s.newID(), Name: *a.Name, Color: "charcoal", ItemOrder: len(s.labels.order) + 1}
+	s.applyLabelArgs(l, a)
+	s.labels.put(l.ID, l, s.bump())
+	return l, nil
+}
+
+// updateLabelFields changes a label. A new name is applied to its tasks as well.
+func (s *Server) updateLabelFields(id string, a labelArgs) (*api.Label, *apiError) {
+	l, err := s.activeLabel(id)
+	if err != nil {
+		return nil, err
+	}
+	version := s.bump()
+	if a.Name != nil && *a.Name != "" && *a.Name != l.Name {
+		s.renameLabel(l.Name, *a.Name, version)
+		l.Name = *a.Name
+	}
+	s.applyLabelArgs(l, a)
+	s.labels.touch(l.ID, version)
+	return l, nil
+}
+
+func (s *Server) applyLabelArgs(l *api.Label, a labelArgs) {
+	if a.Color != nil && *a.Color != "" {
+		l.Color = *a.Color
+	}
+	if a.ItemOrder != nil {
+		l.ItemOrder = *a.ItemOrder
+	} else if a.Order != nil {
+		l.ItemOrder = *a.Order
+	}
+	if a.IsFavorite != nil {
+		l.IsFavorite = *a.IsFavorite
+	}
+}
+
+// removeLabel deletes a label and removes it from its tasks.
+func (s *Server) removeLabel(id string) *apiError {
+	l, err := s.activeLabel(id)
+	if err != nil {
+		return err
+	}
+	version := s.bump()
+	l.IsDeleted = true
+	s.labels.touch(l.ID, version)
+	s.renameLabel(l.Name, "", version)
+	return nil
+}
+
+// renameLabel replaces a label name on every task; an empty name removes it.
+func (s *Server) renameLabel(from, to string, version int) {
+	for _, taskID := range s.tasks.order {
+		t := s.tasks.get(taskID)
+		labels := make([]string, 0, len(t.Labels))
+		changed := false
+		for _, name := range t.Labels {
+			if name != from {
+				labels = append(labels, name)
+				continue
+			}
+			changed = true
+			if to != "" {
+				labels = append(labels, to)
+			}
+		}
+		if changed {
+			t.Labels = labels
+			s.tasks.touch(taskID, version)
+		}
+	}
+}
+
+// commentArgs are the comment fields of a REST body or Sync command.
+type commentArgs struct {
+	ID           string              `json:"id"`
+	TaskID       string              `json:"task_id"`
+	ItemID       string              `json:"item_id"`
+	ProjectID    string              `json:"project_id"`
+	Content      *string             `json:"content"`
+	UIDsToNotify []string            `json:"uids_to_notify"`
+	Reactions    map[string][]string `json:"reactions"`
+}
+
+func (s *Server) activeComment(id string) (*api.Comment, *apiError) {
+	c := s.comments.get(id)
+	if c == nil || c.IsDeleted {
+		return nil, notFound("Comment")
+	}
+	return c, nil
+}
+
+// addComment adds a comment to a task or, without one, a project.
+func (s *Server) addComment(a commentArgs) (*api.Comment, *apiError) {
+	if a.Content == nil || *a.Content == "" {
+		return nil, missingArgument("content")
+	}
+	c := &api.Comment{
+		ID:           s.newID(),
+		PostedUID:    s.UserID,
+		PostedAt:     s.timestamp(),
+		Content:      *a.Content,
+		UIDsToNotify: a.UIDsToNotify,
+		Reactions:    map[string][]string{},
+	}
+
+	taskID := a.TaskID
+	if taskID == "" {
+		taskID = a.ItemID
+	}
+	version := s.bump()
+	switch {
+	case taskID != "":
+		t, err := s.activeTask(taskID)
+		if err != nil {
+			return nil, err
+		}
+		c.ItemID = &t.ID
+		t.NoteCount++
+		s.tasks.touch(t.ID, version)
+	case a.ProjectID != "":
+		p, err := s.activeProject(a.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		c.ProjectID = &p.ID
+	default:
+		return nil, missingArgument("task_id or project_id")
+	}
+	s.comments.put(c.ID, c, version)
+	return c, nil
+}
+
+func (s *Server) updateCommentFields(id string, a commentArgs) (*api.Comment, *apiError) {
+	c, err := s.activeComment(id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Content != nil {
+		c.Content = *a.Content
+	}
+	if a.Reactions != nil {
+		c.Reactions = a.Reactions
+	}
+	s.comments.touch(c.ID, s.bump())
+	return c, nil
+}
+
+func (s *Server) removeComment(id string) *apiError {
+	c, err := s.activeComment(id)
+	if err != nil {
+		return err
+	}
+	version := s.bump()
+	c.IsDeleted = true
+	s.comments.touch(c.ID, version)
+	if c.ItemID != nil {
+		if t := s.tasks.get(*c.ItemID); t != nil && t.NoteCount > 0 {
+			t.NoteCount--
+			s.tasks.touch(t.ID, version)
+		}
+	}
+	return nil
+}
+
+// filterArgs are the fields of a filter Sync command.
+type filterArgs struct {
+	ID         string  `json:"id"`
+	Name       *string `json:"name"`
+	Query      *string `json:"query"`
+	Color      *string `json:"color"`
+	ItemOrder  *int    `json:"item_order"`
+	IsFavorite *bool   `json:"is_favorite"`
+}
+
+func (s *Server) addFilter(a filterArgs) (*api.Filter, *apiError) {
+	if a.Name == nil || *a.Name == "" {
+		return nil, missingArgument("name")
+	}
+	if a.Query == nil || *a.Query == "" {
+		return nil, missingArgument("query")
+	}
+	f := &api.Filter{ID: s.newID(), Color: "charcoal", ItemOrder: len(s.filters.order) + 1}
+	s.applyFilterArgs(f, a)
+	s.filters.put(f.ID, f, s.bump())
+	return f, nil
+}
+
+func (s *Server) updateFilter(a filterArgs) *apiError {
+	f := s.filters.get(a.ID)
+	if f == nil || f.IsDeleted {
+		return notFound("Filter")
+	}
+	s.applyFilterArgs(f, a)
+	s.filters.touch(f.ID, s.bump())
+	return nil
+}
+
+func (s *Server) applyFilterArgs(f *api.Filter, a filterArgs) {
+	if a.Name != nil && *a.Name != "" {
+		f.Name = *a.Name
+	}
+	if a.Query != nil && *a.Query != "" {
+		f.Query = *a.Query
+	}
+	if a.Color != nil && *a.Color != "" {
+		f.Color = *a.Color
+	}
+	if a.ItemOrder != nil {
+		f.ItemOrder = *a.ItemOrder
+	}
+	if a.IsFavorite != nil {
+		f.IsFavorite = *a.IsFavorite
+	}
+}
+
+func (s *Server) deleteFilter(id string) *apiError {
+	f := s.filters.get(id)
+	if f == nil || f.IsDeleted {
+		return notFound("Filter")
+	}
+	f.IsDeleted = true
+	s.filters.touch(f.ID, s.bump())
+	return nil
+}
+
+// reminderArgs are the fields of a reminder Sync command.
+type reminderArgs struct {
+	ID           string           `json:"id"`
+	ItemID       string           `json:"item_id"`
+	Type         string           `json:"type"`
+	Due          *api.ReminderDue `json:"due"`
+	MinuteOffset *int             `json:"minute_offset"`
+}
+
+func (s *Server) addReminder(a reminderArgs) (*api.Reminder, *apiError) {
+	t, err := s.activeTask(a.ItemID)
+	if err != nil {
+		return nil, err
+	}
+	r := &api.Reminder{ID: s.newID(), NotifyUID: s.UserID, ItemID: t.ID, Type: a.Type}
+	switch a.Type {
+	case "absolute":
+		if a.Due == nil {
+			return nil, missingArgument("due")
+		}
+	case "relative":
+		if t.Due == nil {
+			return nil, invalidArgument("Relative reminders need a task with a due time")
+		}
+	default:
+		return nil, invalidArgument("Invalid argument value: type")
+	}
+	s.applyReminderArgs(r, a)
+	s.reminders.put(r.ID, r, s.bump())
+	return r, nil
+}
+
+func (s *Server) updateReminder(a reminderArgs) *apiError {
+	r := s.reminders.get(a.ID)
+	if r == nil || r.IsDeleted {
+		return notFound("Reminder")
+	}
+	s.applyReminderArgs(r, a)
+	s.reminders.touch(r.ID, s.bump())
+	return nil
+}
+
+func (s *Server) applyReminderArgs(r *api.Reminder, a reminderArgs) {
+	if a.Due != nil {
+		due := *a.Due
+		r.Due = &due
+	}
+	if a.MinuteOffset != nil {
+		r.MinuteOffset = *a.MinuteOffset
+	}
+}
+
+func (s *Server) deleteReminder(id string) *apiError {
+	r := s.reminders.get(id)
+	if r == nil || r.IsDeleted {
+		return notFound("Reminder")
+	}
+	r.IsDeleted = true
+	s.reminders.touch(r.ID, s.bump())
+	return nil
+}
@@ -0,0 +1,356 @@
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/filterquery"
+)
+
+// noContent is the result of endpoints that answer 204 No Content on success.
+func noContent(err *apiError) (interface{}, *apiError) {
+	return nil, err
+}
+
+// result returns v, or err if v could not be produced.
+func result[T any](v *T, err *apiError) (interface{}, *apiError) {
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func isOpenTask(t *api.Task) bool { return !t.IsDeleted && !t.Checked }
+
+// Tasks
+
+func (s *Server) listTasks(r *http.Request) (interface{}, *apiError) {
+	q := r.URL.Query()
+	var ids []string
+	if v := q.Get("ids"); v != "" {
+		ids = strings.Split(v, ",")
+	}
+	tasks := s.tasks.list(func(t *api.Task) bool {
+		switch {
+		case !isOpenTask(t):
+			return false
+		case q.Get("project_id") != "" && t.ProjectID != q.Get("project_id"):
+			return false
+		case q.Get("section_id") != "" && (t.SectionID == nil || *t.SectionID != q.Get("section_id")):
+			return false
+		case q.Get("label") != "" && !slices.Contains(t.Labels, q.Get("label")):
+			return false
+		case ids != nil && !slices.Contains(ids, t.ID):
+			return false
+		}
+		return true
+	})
+	return page(s, r, tasks, "results")
+}
+
+// filterTasks evaluates a filter query with the filterquery package. Queries
+// it cannot answer are rejected.
+func (s *Server) filterTasks(r *http.Request) (interface{}, *apiError) {
+	query := r.URL.Query().Get("query")
+	if query == "" {
+		return nil, missingArgument("query")
+	}
+	tasks, err := filterquery.Apply(query, s.tasks.list(isOpenTask), filterquery.Context{
+		Now:      s.now(),
+		Projects: s.projects.list(isActiveProject),
+		Sections: s.sections.list(func(sec *api.Section) bool { return !sec.IsDeleted }),
+		UserID:   s.UserID,
+	})
+	if err != nil {
+		return nil, invalidArgument("Invalid filter query: " + err.Error())
+	}
+	return page(s, r, tasks, "results")
+}
+
+func (s *Server) getTask(r *http.Request) (interface{}, *apiError) {
+	return result(s.activeTask(r.PathValue("id")))
+}
+
+func (s *Server) createTask(r *http.Request) (interface{}, *apiError) {
+	var a taskArgs
+	if err := decode(r, &a); err != nil {
+		return nil, err
+	}
+	return result(s.addTask(a))
+}
+
+func (s *Server) updateTask(r *http.Request) (interface{}, *apiError) {
+	var a taskArgs
+	if err := decode(r, &a); err != nil {
+		return nil, err
+	}
+	return result(s.updateTaskFields(r.PathValue("id"), a))
+}
+
+func (s *Server) deleteTask(r *http.Request) (interface{}, *apiError) {
+	return noContent(s.removeTask(r.PathValue("id")))
+}
+
+func (s *Server) closeTask(r *http.Request) (interface{}, *apiError) {
+	return noContent(s.completeTask(r.PathValue("id"), false, ""))
+}
+
+func (s *Server) reopenTask(r *http.Request) (interface{}, *apiError) {
+	return noContent(s.uncompleteTask(r.PathValue("id")))
+}
+
+func (s *Server) moveTask(r *http.Request) (interface{}, *apiError) {
+	var a moveArgs
+	if err := decode(r, &a); err != nil {
+		return nil, err
+	}
+	return result(s.move(r.PathValue("id"), a))
+}
+
+// moveArgs are the destination of a move. A null parent_id moves a subtask
+// to the top level.
+type moveArgs struct {
+	ID        string          `json:"id"`
+	ProjectID *string         `json:"project_id"`
+	SectionID *string         `json:"section_id"`
+	ParentID  json.RawMessage `json:"parent_id"`
+}
+
+func (s *Server) move(id string, a moveArgs) (*api.Task, *apiError) {
+	var parentID *string
+	if a.ParentID != nil {
+		if string(a.ParentID) == "null" {
+			parentID = new(string)
+		} else if err := json.Unmarshal(a.ParentID, &parentID); err != nil {
+			return nil, invalidArgument("Invalid argument value: parent_id")
+		}
+	}
+	return s.moveTaskTo(id, a.ProjectID, a.SectionID, parentID)
+}
+
+func (s *Server) quickAdd(r *http.Request) (interface{}, *apiError) {
+	var body struct {
+		Text string `json:"text"`
+	}
+	if err := decode(r, &body); err != nil {
+		return nil, err
+	}
+	return result(s.addQuickTask(body.Text))
+}
+
+// completedTasks lists completed tasks, most recently completed first.
+func (s *Server) completedTasks(r *http.Request) (interface{}, *apiError) {
+	q := r.URL.Query()
+	since, until := time.Time{}, s.now()
+	if v := q.Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, invalidArgument("Invalid argument value: since")
+		}
+		since = t
+	}
+	if v := q.Get("until"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, invalidArgument("Invalid argument value: until")
+		}
+		until = t
+	}
+
+	tasks := s.tasks.list(func(t *api.Task) bool {
+		if t.IsDeleted || !t.Checked || t.CompletedAt == nil {
+			return false
+		}
+		at, err := time.Parse(time.RFC3339, *t.CompletedAt)
+		if err != nil || at.Before(since) || at.After(until) {
+			return false
+		}
+		if v := q.Get("project_id"); v != "" && t.ProjectID != v {
+			return false
+		}
+		if v := q.Get("section_id"); v != "" && (t.SectionID == nil || *t.SectionID != v) {
+			return false
+		}
+		if v := q.Get("parent_id"); v != "" && (t.ParentID == nil || *t.ParentID != v) {
+			return false
+		}
+		return true
+	})
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return *tasks[i].CompletedAt > *tasks[j].CompletedAt
+	})
+	return page(s, r, tasks, "items")
+}
+
+// stats reports the number of tasks completed per day over the last week.
+func (s *Server) stats(r *http.Request) (interface{}, *apiError) {
+	stats := api.ProductivityStats{Karma: 0, KarmaTrend: "up"}
+	stats.Goals.DailyGoal = 5
+	stats.Goals.WeeklyGoal = 25
+
+	perDay := map[string]int{}
+	for _, t := range s.tasks.list(func(t *api.Task) bool { return t.Checked && t.CompletedAt != nil && !t.IsDeleted }) {
+		if at, err := time.Parse(time.RFC3339, *t.CompletedAt); err == nil {
+			perDay[at.Format(dateLayout)]++
+		}
+		stats.Karma++
+	}
+	today := s.now()
+	for i := 0; i < 7; i++ {
+		day := today.AddDate(0, 0, -i).Format(dateLayout)
+		stats.DaysItems = append(stats.DaysItems, api.DayItems{Date: day, TotalCompleted: perDay[day]})
+	}
+	return &stats, nil
+}
+
+// Projects
+
+func (s *Server) listProjects(r *http.Request) (interface{}, *apiError) {
+	return page(s, r, s.projects.list(isActiveProject), "results")
+}
+
+func (s *Server) getProject(r *http.Request) (interface{}, *apiError) {
+	return result(s.activeProject(r.PathValue("id")))
+}
+
+func (s *Server) createProject(r *http.Request) (interface{}, *apiError) {
+	var a projectArgs
+	if err := decode(r, &a); err != nil {
+		return nil, err
+	}
+	return result(s.addProject(a))
+}
+
+func (s *Server) updateProject(r *http.Request) (interface{}, *apiError) {
+	var a projectArgs
+	if err := decode(r, &a); err != nil {
+		return nil, err
+	}
+	return result(s.updateProjectFields(r.PathValue("id"), a))
+}
+
+func (s *Server) deleteProject(r *http.Request) (interface{}, *apiError) {
+	return noContent(s.removeProject(r.PathValue("id")))
+}
+
+func (s *Server) listCollaborators(r *http.Request) (interface{}, *apiError) {
+	p, err := s.activeProject(r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	collaborators := append([]api.Collaborator{}, s.collaborators[p.ID]...)
+	return collaborators, nil
+}
+
+// Sections
+
+func (s *Server) listSections(r *http.Request) (interface{}, *apiError) {
+	projectID := r.URL.Query().Get("project_id")
+	sections := s.sections.list(func(sec *api.Section) bool {
+		return !sec.IsDeleted && (projectID == "" || sec.ProjectID == projectID)
+	})
+	return page(s, r, sections, "results")
+}
+
+func (s *Server) getSection(r *http.Request) (interface{}, *apiError) {
+	return result(s.activeSection(r.PathValue("id")))
+}
+
+func (s *Server) createSection(r *http.Request) (interface{}, *apiError) {
+	var a sectionArgs
+	if err := decode(r, &a); err != nil {
+		return nil, err
+	}
+	return result(s.addSection(a))
+}
+
+func (s *Server) updateSection(r *http.Request) (interface{}, *apiError) {
+	var a sectionArgs
+	if err := decode(r, &a); err != nil {
+		return nil, err
+	}
+	return result(s.updateSectionFields(r.PathValue("id"), a))
+}
+
+func (s *Server) deleteSection(r *http.Request) (interface{}, *apiError) {
+	return noContent(s.removeSection(r.PathValue("id")))
+}
+
+// Labels
+
+func (s *Server) listLabels(r *http.Request) (interface{}, *apiError) {
+	return page(s, r, s.labels.list(func(l *api.Label) bool { return !l.IsDeleted }), "results")
+}
+
+func (s *Server) getLabel(r *http.Request) (interface{}, *apiError) {
+	return result(s.activeLabel(r.PathValue("id")))
+}
+
+func (s *Server) createLabel(r *http.Request) (interface{}, *apiError) {
+	var a labelArgs
+	if err := decode(r, &a); err != nil {
+		return nil, err
+	}
+	return result(s.addLabel(a))
+}
+
+func (s *Server) updateLabel(r *http.Request) (interface{}, *apiError) {
+	var a labelArgs
+	if err := decode(r, &a); err != nil {
+		return nil, err
+	}
+	return result(s.updateLabelFields(r.PathValue("id"), a))
+}
+
+func (s *Server) deleteLabel(r *http.Request) (interface{}, *apiError) {
+	return noContent(s.removeLabel(r.PathValue("id")))
+}
+
+// Comments
+
+func (s *Server) listComments(r *http.Request) (interface{}, *apiError) {
+	taskID := r.URL.Query().Get("task_id")
+	projectID := r.URL.Query().Get("project_id")
+	if taskID == "" && projectID == "" {
+		return nil, missingArgument("task_id or project_id")
+	}
+	comments := s.comments.list(func(c *api.Comment) bool {
+		if c.IsDeleted {
+			return false
+		}
+		if taskID != "" {
+			return c.ItemID != nil && *c.ItemID == taskID
+		}
+		return c.ProjectID != nil && *c.ProjectID == projectID
+	})
+	return page(s, r, comments, "results")
+}
+
+func (s *Server) getComment(r *http.Request) (interface{}, *apiError) {
+	return result(s.activeComment(r.PathValue("id")))
+}
+
+func (s *Server) createComment(r *http.Request) (interface{}, *apiError) {
+	var a commentArgs
+	if err := decode(r, &a); err != nil {
+		return nil, err
+	}
+	return result(s.addComment(a))
+}
+
+func (s *Server) updateComment(r *http.Request) (interface{}, *apiError) {
+	var a commentArgs
+	if err := decode(r, &a); err != nil {
+		return nil, err
+	}
+	return result(s.updateCommentFields(r.PathValue("id"), a))
+}
+
+func (s *Server) deleteComment(r *http.Request) (interface{}, *apiError) {
+	return noContent(s.removeComment(r.PathValue("id")))
+}
@@ -0,0 +1,208 @@
+package fake
+
+import (
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// The Add methods put resources on the server as they are, without the
+// validation of the endpoints, and return them as stored. An empty ID is
+// assigned; other empty fields get the defaults the API would give them.
+
+// AddProject adds a project.
+func (s *Server) AddProject(p api.Project) api.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p.ID == "" {
+		p.ID = s.newID()
+	}
+	if p.Color == "" {
+		p.Color = "charcoal"
+	}
+	if p.ViewStyle == "" {
+		p.ViewStyle = "list"
+	}
+	if p.CreatedAt == "" {
+		p.CreatedAt = s.timestamp()
+		p.UpdatedAt = p.CreatedAt
+	}
+	s.projects.put(p.ID, &p, s.bump())
+	return p
+}
+
+// AddSection adds a section.
+func (s *Server) AddSection(sec api.Section) api.Section {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sec.ID == "" {
+		sec.ID = s.newID()
+	}
+	if sec.AddedAt == "" {
+		sec.AddedAt = s.timestamp()
+		sec.UpdatedAt = sec.AddedAt
+	}
+	s.sections.put(sec.ID, &sec, s.bump())
+	return sec
+}
+
+// AddTask adds a task. Without a project it goes to the Inbox; a checked
+// task without a completion time was completed now.
+func (s *Server) AddTask(t api.Task) api.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t.ID == "" {
+		t.ID = s.newID()
+	}
+	if t.ProjectID == "" {
+		t.ProjectID = s.inbox().ID
+	}
+	if t.Priority == 0 {
+		t.Priority = 1
+	}
+	if t.Labels == nil {
+		t.Labels = []string{}
+	}
+	if t.UserID == "" {
+		t.UserID = s.UserID
+		t.AddedByUID = s.UserID
+	}
+	if t.AddedAt == "" {
+		t.AddedAt = s.timestamp()
+		t.UpdatedAt = t.AddedAt
+	}
+	if t.Checked && t.CompletedAt == nil {
+		at := s.timestamp()
+		t.CompletedAt = &at
+	}
+	s.tasks.put(t.ID, &t, s.bump())
+	return t
+}
+
+// AddLabel adds a personal label.
+func (s *Server) AddLabel(l api.Label) api.Label {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l.ID == "" {
+		l.ID = s.newID()
+	}
+	if l.Color == "" {
+		l.Color = "charcoal"
+	}
+	s.labels.put(l.ID, &l, s.bump())
+	return l
+}
+
+// AddComment adds a comment to the task or project it names.
+func (s *Server) AddComment(c api.Comment) api.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c.ID == "" {
+		c.ID = s.newID()
+	}
+	if c.PostedUID == "" {
+		c.PostedUID = s.UserID
+	}
+	if c.PostedAt == "" {
+		c.PostedAt = s.timestamp()
+	}
+	version := s.bump()
+	if c.ItemID != nil {
+		if t := s.tasks.get(*c.ItemID); t != nil {
+			t.NoteCount++
+			s.tasks.touch(t.ID, version)
+		}
+	}
+	s.comments.put(c.ID, &c, version)
+	return c
+}
+
+// AddFilter adds a saved filter.
+func (s *Server) AddFilter(f api.Filter) api.Filter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f.ID == "" {
+		f.ID = s.newID()
+	}
+	s.filters.put(f.ID, &f, s.bump())
+	return f
+}
+
+// AddReminder adds a reminder.
+func (s *Server) AddReminder(r api.Reminder) api.Reminder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.ID == "" {
+		r.ID = s.newID()
+	}
+	if r.NotifyUID == "" {
+		r.NotifyUID = s.UserID
+	}
+	s.reminders.put(r.ID, &r, s.bump())
+	return r
+}
+
+// AddCollaborator shares a project with a user.
+func (s *Server) AddCollaborator(projectID string, c api.Collaborator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collaborators[projectID] = append(s.collaborators[projectID], c)
+}
+
+// Task returns a task that has not been deleted, completed or not.
+func (s *Server) Task(id string) (api.Task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.activeTask(id)
+	if err != nil {
+		return api.Task{}, false
+	}
+	return *t, true
+}
+
+// Tasks returns the open tasks.
+func (s *Server) Tasks() []api.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tasks.list(isOpenTask)
+}
+
+// Projects returns the projects that have not been deleted or archived.
+func (s *Server) Projects() []api.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.projects.list(isActiveProject)
+}
+
+// Sections returns the sections that have not been deleted.
+func (s *Server) Sections() []api.Section {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sections.list(func(sec *api.Section) bool { return !sec.IsDeleted })
+}
+
+// Labels returns the labels that have not been deleted.
+func (s *Server) Labels() []api.Label {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.labels.list(func(l *api.Label) bool { return !l.IsDeleted })
+}
+
+// Comments returns the comments that have not been deleted.
+func (s *Server) Comments() []api.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.comments.list(func(c *api.Comment) bool { return !c.IsDeleted })
+}
+
+// Filters returns the filters that have not been deleted.
+func (s *Server) Filters() []api.Filter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.filters.list(func(f *api.Filter) bool { return !f.IsDeleted })
+}
+
+// Reminders returns the reminders that have not been deleted.
+func (s *Server) Reminders() []api.Reminder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reminders.list(func(r *api.Reminder) bool { return !r.IsDeleted })
+}
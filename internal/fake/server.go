@@ -0,0 +1,310 @@
+// Package fake is an in-memory stand-in for the Todoist API v1. It serves
+// the REST and Sync endpoints the client uses, keeps their state consistent
+// across both, and enforces pagination cursors the way the real API does.
+//
+// It backs integration tests:
+//
+//	srv := httptest.NewServer(fake.New())
+//	client := api.NewClient("token")
+//	client.SetBaseURL(srv.URL)
+//
+// and demos, via cmd/todoist-fake and todoist-tui --base-url.
+package fake
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// DefaultPageSize is the page size of list endpoints called without a limit.
+const DefaultPageSize = 50
+
+// Server is a fake Todoist API. Use New to create one; it is an http.Handler.
+type Server struct {
+	// PageSize overrides DefaultPageSize, e.g. to force pagination in tests.
+	PageSize int
+	// Token, if set, is the only access token the server accepts.
+	Token string
+	// UserID is the ID of the user the server acts as.
+	UserID string
+	// FullName and Email describe that user.
+	FullName string
+	Email    string
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	mux     *http.ServeMux
+	version int // Bumped on every change; sync tokens refer to it
+	nextID  int
+
+	cursors    map[string]cursor
+	nextCursor int
+
+	tasks         *store[api.Task]
+	projects      *store[api.Project]
+	sections      *store[api.Section]
+	labels        *store[api.Label]
+	comments      *store[api.Comment]
+	filters       *store[api.Filter]
+	reminders     *store[api.Reminder]
+	collaborators map[string][]api.Collaborator
+}
+
+// cursor is the state behind a next_cursor handed out by a list endpoint.
+type cursor struct {
+	request string // Path and query (without cursor) it belongs to
+	offset  int
+}
+
+// New returns an empty server with just an Inbox project.
+func New() *Server {
+	s := &Server{
+		UserID:        "1",
+		FullName:      "Test User",
+		Email:         "user@example.com",
+		nextID:        1000,
+		cursors:       make(map[string]cursor),
+		tasks:         newStore[api.Task](),
+		projects:      newStore[api.Project](),
+		sections:      newStore[api.Section](),
+		labels:        newStore[api.Label](),
+		comments:      newStore[api.Comment](),
+		filters:       newStore[api.Filter](),
+		reminders:     newStore[api.Reminder](),
+		collaborators: make(map[string][]api.Collaborator),
+	}
+	s.AddProject(api.Project{Name: "Inbox", InboxProject: true})
+	s.routes()
+	return s
+}
+
+func (s *Server) routes() {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /tasks", s.handle(s.listTasks))
+	mux.HandleFunc("POST /tasks", s.handle(s.createTask))
+	mux.HandleFunc("GET /tasks/filter", s.handle(s.filterTasks))
+	mux.HandleFunc("POST /tasks/quick", s.handle(s.quickAdd))
+	mux.HandleFunc("GET /tasks/completed/by_completion_date", s.handle(s.completedTasks))
+	mux.HandleFunc("GET /tasks/completed/stats", s.handle(s.stats))
+	mux.HandleFunc("GET /tasks/{id}", s.handle(s.getTask))
+	mux.HandleFunc("POST /tasks/{id}", s.handle(s.updateTask))
+	mux.HandleFunc("DELETE /tasks/{id}", s.handle(s.deleteTask))
+	mux.HandleFunc("POST /tasks/{id}/close", s.handle(s.closeTask))
+	mux.HandleFunc("POST /tasks/{id}/reopen", s.handle(s.reopenTask))
+	mux.HandleFunc("POST /tasks/{id}/move", s.handle(s.moveTask))
+
+	mux.HandleFunc("GET /projects", s.handle(s.listProjects))
+	mux.HandleFunc("POST /projects", s.handle(s.createProject))
+	mux.HandleFunc("GET /projects/{id}", s.handle(s.getProject))
+	mux.HandleFunc("POST /projects/{id}", s.handle(s.updateProject))
+	mux.HandleFunc("DELETE /projects/{id}", s.handle(s.deleteProject))
+	mux.HandleFunc("GET /projects/{id}/collaborators", s.handle(s.listCollaborators))
+
+	mux.HandleFunc("GET /sections", s.handle(s.listSections))
+	mux.HandleFunc("POST /sections", s.handle(s.createSection))
+	mux.HandleFunc("GET /sections/{id}", s.handle(s.getSection))
+	mux.HandleFunc("POST /sections/{id}", s.handle(s.updateSection))
+	mux.HandleFunc("DELETE /sections/{id}", s.handle(s.deleteSection))
+
+	mux.HandleFunc("GET /labels", s.handle(s.listLabels))
+	mux.HandleFunc("POST /labels", s.handle(s.createLabel))
+	mux.HandleFunc("GET /labels/{id}", s.handle(s.getLabel))
+	mux.HandleFunc("POST /labels/{id}", s.handle(s.updateLabel))
+	mux.HandleFunc("DELETE /labels/{id}", s.handle(s.deleteLabel))
+
+	mux.HandleFunc("GET /comments", s.handle(s.listComments))
+	mux.HandleFunc("POST /comments", s.handle(s.createComment))
+	mux.HandleFunc("GET /comments/{id}", s.handle(s.getComment))
+	mux.HandleFunc("POST /comments/{id}", s.handle(s.updateComment))
+	mux.HandleFunc("DELETE /comments/{id}", s.handle(s.deleteComment))
+
+	mux.HandleFunc("POST /sync", s.handle(s.sync))
+
+	s.mux = mux
+}
+
+// ServeHTTP implements http.Handler. Paths may carry the /api/v1 prefix of
+// the real API, so a base URL can be given either way.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, &apiError{http.StatusUnauthorized, "AUTH_INVALID_TOKEN", "Invalid token"})
+		return
+	}
+	if rest, ok := strings.CutPrefix(r.URL.Path, "/api/v1"); ok {
+		r.URL.Path = rest
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// handle adapts an endpoint to an http.HandlerFunc. Endpoints run with the
+// server locked; a nil result is answered with 204 No Content.
+func (s *Server) handle(endpoint func(r *http.Request) (interface{}, *apiError)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		result, err := endpoint(r)
+		s.mu.Unlock()
+
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if result == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+// apiError is an error response in Todoist's format.
+type apiError struct {
+	status  int
+	tag     string
+	message string
+}
+
+// Error codes Todoist sends along with the tags below.
+var errorCodes = map[string]int{
+	"AUTH_INVALID_TOKEN":     401,
+	"NOT_FOUND":              478,
+	"ARGUMENT_MISSING":       19,
+	"INVALID_ARGUMENT_VALUE": 20,
+	"INVALID_DATE_FORMAT":    110,
+	"INVALID_CURSOR":         482,
+	"INVALID_SYNC_TOKEN":     481,
+	"UNKNOWN_COMMAND":        26,
+	"FORBIDDEN":              403,
+}
+
+// payload returns the JSON error object of e.
+func (e *apiError) payload() map[string]interface{} {
+	return map[string]interface{}{
+		"error":       e.message,
+		"error_code":  errorCodes[e.tag],
+		"error_tag":   e.tag,
+		"http_code":   e.status,
+		"error_extra": map[string]interface{}{},
+	}
+}
+
+func writeError(w http.ResponseWriter, e *apiError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.status)
+	json.NewEncoder(w).Encode(e.payload())
+}
+
+func notFound(what string) *apiError {
+	return &apiError{http.StatusNotFound, "NOT_FOUND", what + " not found"}
+}
+
+func invalidArgument(message string) *apiError {
+	return &apiError{http.StatusBadRequest, "INVALID_ARGUMENT_VALUE", message}
+}
+
+func missingArgument(name string) *apiError {
+	return &apiError{http.StatusBadRequest, "ARGUMENT_MISSING", "Required argument is missing: " + name}
+}
+
+// decode reads the JSON request body into v. An empty body leaves v as is.
+func decode(r *http.Request, v interface{}) *apiError {
+	if r.Body == nil {
+		return nil
+	}
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return invalidArgument("Invalid JSON body")
+	}
+	return nil
+}
+
+// page returns one page of all, as selected by the limit and cursor query
+// parameters, in a response with results under field. Cursors are only
+// accepted for the request they were issued for.
+func page[T any](s *Server, r *http.Request, all []T, field string) (interface{}, *apiError) {
+	query := r.URL.Query()
+
+	limit := s.PageSize
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > api.MaxPageSize {
+			return nil, invalidArgument("Invalid argument value: limit")
+		}
+		limit = n
+	}
+
+	id := query.Get("cursor")
+	query.Del("cursor")
+	request := r.URL.Path + "?" + query.Encode()
+
+	offset := 0
+	if id != "" {
+		c, ok := s.cursors[id]
+		if !ok || c.request != request {
+			return nil, &apiError{http.StatusBadRequest, "INVALID_CURSOR", "Invalid cursor"}
+		}
+		offset = min(c.offset, len(all))
+	}
+
+	end := min(offset+limit, len(all))
+	var next *string
+	if end < len(all) {
+		s.nextCursor++
+		nextID := "cursor-" + strconv.Itoa(s.nextCursor)
+		s.cursors[nextID] = cursor{request: request, offset: end}
+		next = &nextID
+	}
+
+	return map[string]interface{}{
+		field:         all[offset:end],
+		"next_cursor": next,
+	}, nil
+}
+
+// now returns the current time in UTC.
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+// timestamp returns the current time as the API formats it.
+func (s *Server) timestamp() string {
+	return s.now().Format(time.RFC3339Nano)
+}
+
+// newID returns a fresh resource ID.
+func (s *Server) newID() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+// bump starts a new version of the server state and returns it.
+func (s *Server) bump() int {
+	s.version++
+	return s.version
+}
+
+// inbox returns the Inbox project.
+func (s *Server) inbox() *api.Project {
+	for _, id := range s.projects.order {
+		if p := s.projects.get(id); p.InboxProject {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,332 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+var now = time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC) // A Tuesday
+
+func newTestServer(t *testing.T) (*Server, *api.Client) {
+	t.Helper()
+	s := New()
+	s.Now = func() time.Time { return now }
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	client := api.NewClient("test-token")
+	client.SetBaseURL(srv.URL)
+	client.Limiter = nil
+	client.FullSyncLimiter = nil
+	return s, client
+}
+
+func TestPagination(t *testing.T) {
+	s, client := newTestServer(t)
+	s.PageSize = 2
+	for i := 0; i < 5; i++ {
+		s.AddTask(api.Task{Content: "task"})
+	}
+
+	tasks, err := client.GetTasks(context.Background(), api.TaskFilter{})
+	if err != nil {
+		t.Fatalf("GetTasks() error = %v", err)
+	}
+	if len(tasks) != 5 {
+		t.Errorf("expected 5 tasks across pages, got %d", len(tasks))
+	}
+}
+
+func TestPagination_RejectsForeignCursor(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.PageSize = 1
+	s.AddProject(api.Project{Name: "Work"})
+	s.AddTask(api.Task{Content: "a"})
+	s.AddTask(api.Task{Content: "b"})
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	first := get("/projects")
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", first.Code)
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		want   int
+	}{
+		{"same request", "/projects?cursor=cursor-1", http.StatusOK},
+		{"other endpoint", "/tasks?cursor=cursor-1", http.StatusBadRequest},
+		{"other query", "/projects?limit=5&cursor=cursor-1", http.StatusBadRequest},
+		{"unknown cursor", "/projects?cursor=bogus", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := get(tt.target).Code; got != tt.want {
+				t.Errorf("GET %s = %d, want %d", tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRESTAndSyncShareState(t *testing.T) {
+	s, client := newTestServer(t)
+	ctx := context.Background()
+
+	project, err := client.CreateProject(ctx, api.CreateProjectRequest{Name: "Work"})
+	if err != nil {
+		t.Fatalf("CreateProject() error = %v", err)
+	}
+
+	addSection := api.NewSyncCommandWithTempID("section_add", map[string]string{"name": "Next", "project_id": project.ID})
+	addTask := api.NewSyncCommandWithTempID("item_add", map[string]string{
+		"content":    "Write report",
+		"project_id": project.ID,
+		"section_id": addSection.TempID,
+	})
+	resp, err := client.ExecuteCommands(ctx, []api.SyncCommand{addSection, addTask})
+	if err != nil {
+		t.Fatalf("ExecuteCommands() error = %v", err)
+	}
+	for _, cmd := range []api.SyncCommand{addSection, addTask} {
+		if err := resp.CommandError(cmd.UUID); err != nil {
+			t.Fatalf("%s failed: %v", cmd.Type, err)
+		}
+	}
+
+	taskID := resp.TempIDMapping[addTask.TempID]
+	task, err := client.GetTask(ctx, taskID)
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if task.SectionID == nil || *task.SectionID != resp.TempIDMapping[addSection.TempID] {
+		t.Errorf("temp section ID was not resolved: %v", task.SectionID)
+	}
+
+	if err := client.CloseTask(ctx, taskID); err != nil {
+		t.Fatalf("CloseTask() error = %v", err)
+	}
+	if got, _ := s.Task(taskID); !got.Checked {
+		t.Error("task closed over REST is not checked")
+	}
+	if tasks, _ := client.GetTasks(ctx, api.TaskFilter{ProjectID: project.ID}); len(tasks) != 0 {
+		t.Errorf("completed task is still listed: %v", tasks)
+	}
+}
+
+func TestIncrementalSync(t *testing.T) {
+	s, client := newTestServer(t)
+	ctx := context.Background()
+	keep := s.AddTask(api.Task{Content: "keep"})
+	done := s.AddTask(api.Task{Content: "done"})
+	gone := s.AddTask(api.Task{Content: "gone"})
+	kept := "kept"
+
+	sc := api.NewSyncClient(client)
+	first, err := sc.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !first.FullSync || len(first.Tasks) != 3 {
+		t.Fatalf("expected a full sync with 3 tasks, got full=%v tasks=%d", first.FullSync, len(first.Tasks))
+	}
+
+	if err := client.CloseTask(ctx, done.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DeleteTask(ctx, gone.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.UpdateTask(ctx, keep.ID, api.UpdateTaskRequest{Content: &kept}); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := sc.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if second.FullSync {
+		t.Error("expected an incremental sync")
+	}
+	if len(second.Tasks) != 1 || second.Tasks[0].Content != "kept" {
+		t.Errorf("expected only the updated task, got %+v", second.Tasks)
+	}
+}
+
+func TestSync_InvalidToken(t *testing.T) {
+	_, client := newTestServer(t)
+	_, err := client.ReadResources(context.Background(), "bogus", "items")
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.Details.Tag != "INVALID_SYNC_TOKEN" {
+		t.Errorf("expected INVALID_SYNC_TOKEN, got %v", err)
+	}
+}
+
+func TestQuickAdd(t *testing.T) {
+	s, client := newTestServer(t)
+	home := s.AddProject(api.Project{Name: "Home"})
+
+	task, err := client.QuickAddTask(context.Background(), "Buy milk tomorrow {fri} #Home @errands p1")
+	if err != nil {
+		t.Fatalf("QuickAddTask() error = %v", err)
+	}
+	if task.Content != "Buy milk" {
+		t.Errorf("content = %q, want %q", task.Content, "Buy milk")
+	}
+	if task.ProjectID != home.ID {
+		t.Errorf("project = %q, want %q", task.ProjectID, home.ID)
+	}
+	if len(task.Labels) != 1 || task.Labels[0] != "errands" {
+		t.Errorf("labels = %v, want [errands]", task.Labels)
+	}
+	if task.Priority != 4 {
+		t.Errorf("priority = %d, want 4", task.Priority)
+	}
+	if task.Due == nil || task.Due.Date != "2026-03-11" {
+		t.Errorf("due = %+v, want 2026-03-11", task.Due)
+	}
+	if task.Deadline == nil || task.Deadline.Date != "2026-03-13" {
+		t.Errorf("deadline = %+v, want 2026-03-13", task.Deadline)
+	}
+}
+
+func TestParseDue(t *testing.T) {
+	tests := []struct {
+		text      string
+		want      string
+		recurring bool
+	}{
+		{"today", "2026-03-10", false},
+		{"tomorrow", "2026-03-11", false},
+		{"in 3 days", "2026-03-13", false},
+		{"friday", "2026-03-13", false},
+		{"next monday", "2026-03-16", false},
+		{"2026-04-01", "2026-04-01", false},
+		{"tomorrow at 14:30", "2026-03-11T14:30:00", false},
+		{"every day", "2026-03-10", true},
+		{"every monday", "2026-03-16", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			due, ok := parseDue(tt.text, now)
+			if !ok {
+				t.Fatalf("parseDue(%q) failed", tt.text)
+			}
+			if due.Date != tt.want || due.IsRecurring != tt.recurring {
+				t.Errorf("parseDue(%q) = %s recurring=%v, want %s recurring=%v", tt.text, due.Date, due.IsRecurring, tt.want, tt.recurring)
+			}
+		})
+	}
+
+	if _, ok := parseDue("someday", now); ok {
+		t.Error("parseDue accepted an unknown date")
+	}
+}
+
+func TestCloseRecurringTask(t *testing.T) {
+	s, client := newTestServer(t)
+	task := s.AddTask(api.Task{Content: "Water plants", Due: &api.Due{String: "every day", Date: "2026-03-10", IsRecurring: true}})
+
+	if err := client.CloseTask(context.Background(), task.ID); err != nil {
+		t.Fatalf("CloseTask() error = %v", err)
+	}
+
+	got, _ := s.Task(task.ID)
+	if got.Checked {
+		t.Error("recurring task should stay open")
+	}
+	if got.Due == nil || got.Due.Date != "2026-03-11" {
+		t.Errorf("due = %+v, want 2026-03-11", got.Due)
+	}
+}
+
+func TestCompletedTasks(t *testing.T) {
+	s, client := newTestServer(t)
+	s.PageSize = 1
+	for _, at := range []string{"2026-03-09T10:00:00Z", "2026-03-10T08:00:00Z", "2026-01-01T10:00:00Z"} {
+		s.AddTask(api.Task{Content: at, Checked: true, CompletedAt: &at})
+	}
+
+	tasks, err := client.GetAllCompletedTasks(context.Background(), now.AddDate(0, 0, -7), now)
+	if err != nil {
+		t.Fatalf("GetAllCompletedTasks() error = %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks in range, got %d", len(tasks))
+	}
+	if tasks[0].Content != "2026-03-10T08:00:00Z" {
+		t.Errorf("expected the latest completion first, got %q", tasks[0].Content)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	_, client := newTestServer(t)
+
+	_, err := client.GetTask(context.Background(), "missing")
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *api.APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Details.Tag != "NOT_FOUND" {
+		t.Errorf("unexpected error: %+v", apiErr)
+	}
+}
+
+func TestToken(t *testing.T) {
+	s, client := newTestServer(t)
+	s.Token = "secret"
+
+	_, err := client.GetProjects(context.Background())
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %v", err)
+	}
+}
+
+func TestSyncCommands(t *testing.T) {
+	s, client := newTestServer(t)
+	ctx := context.Background()
+	project := s.AddProject(api.Project{Name: "Work"})
+	a := s.AddSection(api.Section{Name: "A", ProjectID: project.ID, SectionOrder: 1})
+	b := s.AddSection(api.Section{Name: "B", ProjectID: project.ID, SectionOrder: 2})
+
+	a.SectionOrder, b.SectionOrder = 2, 1
+	if err := client.ReorderSections(ctx, []api.Section{a, b}); err != nil {
+		t.Fatalf("ReorderSections() error = %v", err)
+	}
+	sections, _ := client.GetSections(ctx, project.ID)
+	for _, sec := range sections {
+		if (sec.ID == a.ID && sec.SectionOrder != 2) || (sec.ID == b.ID && sec.SectionOrder != 1) {
+			t.Errorf("section %s has order %d", sec.Name, sec.SectionOrder)
+		}
+	}
+
+	if _, err := client.CreateFilter(ctx, "Urgent", "p1", "red"); err != nil {
+		t.Fatalf("CreateFilter() error = %v", err)
+	}
+	filters, err := client.GetFilters(ctx)
+	if err != nil || len(filters) != 1 || filters[0].Query != "p1" {
+		t.Errorf("GetFilters() = %+v, %v", filters, err)
+	}
+
+	closeMissing := api.NewSyncCommand("item_close", map[string]string{"id": "missing"})
+	resp, err := client.ExecuteCommands(ctx, []api.SyncCommand{closeMissing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var syncErr *api.SyncError
+	if err := resp.CommandError(closeMissing.UUID); !errors.As(err, &syncErr) || !syncErr.IsNotFound() {
+		t.Errorf("expected a not found SyncError, got %v", err)
+	}
+}
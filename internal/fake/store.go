@@ -0,0 +1,59 @@
+package fake
+
+// store keeps the resources of one kind in creation order, along with the
+// version of the server state at which each last changed.
+type store[T any] struct {
+	order   []string
+	items   map[string]*T
+	changed map[string]int
+}
+
+func newStore[T any]() *store[T] {
+	return &store[T]{
+		items:   make(map[string]*T),
+		changed: make(map[string]int),
+	}
+}
+
+// get returns the resource with the given ID, or nil.
+func (s *store[T]) get(id string) *T {
+	return s.items[id]
+}
+
+// put adds or replaces a resource and marks it changed at version.
+func (s *store[T]) put(id string, v *T, version int) {
+	if _, ok := s.items[id]; !ok {
+		s.order = append(s.order, id)
+	}
+	s.items[id] = v
+	s.changed[id] = version
+}
+
+// touch marks an existing resource changed at version.
+func (s *store[T]) touch(id string, version int) {
+	s.changed[id] = version
+}
+
+// list returns copies of the resources for which keep returns true, in
+// creation order.
+func (s *store[T]) list(keep func(*T) bool) []T {
+	out := make([]T, 0)
+	for _, id := range s.order {
+		if v := s.items[id]; keep == nil || keep(v) {
+			out = append(out, *v)
+		}
+	}
+	return out
+}
+
+// since returns copies of the resources changed after version, including
+// deleted ones, in creation order.
+func (s *store[T]) since(version int) []T {
+	out := make([]T, 0)
+	for _, id := range s.order {
+		if s.changed[id] > version {
+			out = append(out, *s.items[id])
+		}
+	}
+	return out
+}
@@ -0,0 +1,389 @@
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// command is a Sync command as sent by the client.
+type command struct {
+	Type   string          `json:"type"`
+	UUID   string          `json:"uuid"`
+	TempID string          `json:"temp_id"`
+	Args   json.RawMessage `json:"args"`
+}
+
+// syncToken returns the sync token for a version of the server state.
+func syncToken(version int) string {
+	return "fake-" + strconv.Itoa(version)
+}
+
+// sync runs the commands of a Sync request in order, then returns the
+// requested resources: all of them for the sync token "*", or those changed
+// since the given token, deleted ones included.
+func (s *Server) sync(r *http.Request) (interface{}, *apiError) {
+	if err := r.ParseForm(); err != nil {
+		return nil, invalidArgument("Invalid form body")
+	}
+
+	resp := map[string]interface{}{}
+
+	if raw := r.PostForm.Get("commands"); raw != "" {
+		var commands []command
+		if err := json.Unmarshal([]byte(raw), &commands); err != nil {
+			return nil, invalidArgument("Invalid argument value: commands")
+		}
+		if len(commands) > api.MaxCommandsPerSync {
+			return nil, invalidArgument("Too many commands")
+		}
+
+		status := map[string]interface{}{}
+		mapping := map[string]string{}
+		for _, cmd := range commands {
+			id, err := s.execute(cmd, mapping)
+			if err != nil {
+				status[cmd.UUID] = err.payload()
+				continue
+			}
+			status[cmd.UUID] = "ok"
+			if cmd.TempID != "" && id != "" {
+				mapping[cmd.TempID] = id
+			}
+		}
+		resp["sync_status"] = status
+		resp["temp_id_mapping"] = mapping
+	}
+
+	token := r.PostForm.Get("sync_token")
+	if token == "" {
+		resp["sync_token"] = syncToken(s.version)
+		resp["full_sync"] = false
+		return resp, nil
+	}
+
+	since := 0
+	full := token == "*"
+	if !full {
+		v, err := strconv.Atoi(strings.TrimPrefix(token, "fake-"))
+		if !strings.HasPrefix(token, "fake-") || err != nil || v < 0 || v > s.version {
+			return nil, &apiError{http.StatusBadRequest, "INVALID_SYNC_TOKEN", "Invalid sync token"}
+		}
+		since = v
+	}
+
+	var types []string
+	if raw := r.PostForm.Get("resource_types"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &types); err != nil {
+			return nil, invalidArgument("Invalid argument value: resource_types")
+		}
+	}
+	wants := func(name string) bool {
+		return slices.Contains(types, name) || slices.Contains(types, "all")
+	}
+
+	if wants("items") {
+		resp["items"] = resources(s.tasks, full, since, isOpenTask)
+	}
+	if wants("projects") {
+		resp["projects"] = resources(s.projects, full, since, isActiveProject)
+	}
+	if wants("sections") {
+		resp["sections"] = resources(s.sections, full, since, func(sec *api.Section) bool { return !sec.IsDeleted })
+	}
+	if wants("labels") {
+		resp["labels"] = resources(s.labels, full, since, func(l *api.Label) bool { return !l.IsDeleted })
+	}
+	if wants("filters") {
+		resp["filters"] = resources(s.filters, full, since, func(f *api.Filter) bool { return !f.IsDeleted })
+	}
+	if wants("reminders") {
+		resp["reminders"] = resources(s.reminders, full, since, func(r *api.Reminder) bool { return !r.IsDeleted })
+	}
+	if wants("notes") {
+		resp["notes"] = resources(s.comments, full, since, func(c *api.Comment) bool { return !c.IsDeleted && c.ItemID != nil })
+	}
+	if wants("project_notes") {
+		resp["project_notes"] = resources(s.comments, full, since, func(c *api.Comment) bool { return !c.IsDeleted && c.ProjectID != nil })
+	}
+
+	if wants("user") {
+		resp["user"] = s.user()
+	}
+
+	resp["sync_token"] = syncToken(s.version)
+	resp["full_sync"] = full
+	return resp, nil
+}
+
+// user returns the account the server acts as.
+func (s *Server) user() api.User {
+	u := api.User{ID: s.UserID, Email: s.Email, FullName: s.FullName, InboxProjectID: s.inbox().ID}
+	u.TZInfo.Timezone = "UTC"
+	return u
+}
+
+// resources returns the live resources of a store for a full sync, or those
+// changed since a version otherwise.
+func resources[T any](st *store[T], full bool, since int, live func(*T) bool) []T {
+	if full {
+		return st.list(live)
+	}
+	return st.since(since)
+}
+
+// execute applies one command and returns the ID of the resource it created,
+// if any. Temp IDs of earlier commands in args are replaced by real IDs.
+func (s *Server) execute(cmd command, mapping map[string]string) (string, *apiError) {
+	args := cmd.Args
+	if len(mapping) > 0 && len(args) > 0 {
+		var v interface{}
+		if err := json.Unmarshal(args, &v); err == nil {
+			args, _ = json.Marshal(replaceTempIDs(v, mapping))
+		}
+	}
+
+	switch cmd.Type {
+	case "item_add":
+		var a taskArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		t, err := s.addTask(a)
+		return idOf(t, err, func(t *api.Task) string { return t.ID })
+	case "item_update":
+		var a taskArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		_, err := s.updateTaskFields(a.ID, a)
+		return "", err
+	case "item_move":
+		var a moveArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		_, err := s.move(a.ID, a)
+		return "", err
+	case "item_close":
+		var a taskArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		return "", s.completeTask(a.ID, false, "")
+	case "item_complete":
+		var a taskArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		completedAt := ""
+		if a.CompletedAt != nil {
+			completedAt = *a.CompletedAt
+		}
+		return "", s.completeTask(a.ID, true, completedAt)
+	case "item_uncomplete":
+		var a taskArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		return "", s.uncompleteTask(a.ID)
+	case "item_delete":
+		var a taskArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		return "", s.removeTask(a.ID)
+
+	case "project_add":
+		var a projectArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		p, err := s.addProject(a)
+		return idOf(p, err, func(p *api.Project) string { return p.ID })
+	case "project_update":
+		var a projectArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		_, err := s.updateProjectFields(a.ID, a)
+		return "", err
+	case "project_delete":
+		var a projectArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		return "", s.removeProject(a.ID)
+
+	case "section_add":
+		var a sectionArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		sec, err := s.addSection(a)
+		return idOf(sec, err, func(sec *api.Section) string { return sec.ID })
+	case "section_update":
+		var a sectionArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		_, err := s.updateSectionFields(a.ID, a)
+		return "", err
+	case "section_delete":
+		var a sectionArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		return "", s.removeSection(a.ID)
+	case "section_reorder":
+		var a struct {
+			Sections []sectionArgs `json:"sections"`
+		}
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		for _, sec := range a.Sections {
+			if _, err := s.activeSection(sec.ID); err != nil {
+				return "", err
+			}
+		}
+		for _, sec := range a.Sections {
+			if _, err := s.updateSectionFields(sec.ID, sectionArgs{SectionOrder: sec.SectionOrder}); err != nil {
+				return "", err
+			}
+		}
+		return "", nil
+
+	case "label_add":
+		var a labelArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		l, err := s.addLabel(a)
+		return idOf(l, err, func(l *api.Label) string { return l.ID })
+	case "label_update":
+		var a labelArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		_, err := s.updateLabelFields(a.ID, a)
+		return "", err
+	case "label_delete":
+		var a labelArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		return "", s.removeLabel(a.ID)
+
+	case "note_add", "project_note_add":
+		var a commentArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		if cmd.Type == "project_note_add" {
+			a.ItemID, a.TaskID = "", ""
+		}
+		c, err := s.addComment(a)
+		return idOf(c, err, func(c *api.Comment) string { return c.ID })
+	case "note_update", "project_note_update":
+		var a commentArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		_, err := s.updateCommentFields(a.ID, a)
+		return "", err
+	case "note_delete", "project_note_delete":
+		var a commentArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		return "", s.removeComment(a.ID)
+
+	case "filter_add":
+		var a filterArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		f, err := s.addFilter(a)
+		return idOf(f, err, func(f *api.Filter) string { return f.ID })
+	case "filter_update":
+		var a filterArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		return "", s.updateFilter(a)
+	case "filter_delete":
+		var a filterArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		return "", s.deleteFilter(a.ID)
+
+	case "reminder_add":
+		var a reminderArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		rem, err := s.addReminder(a)
+		return idOf(rem, err, func(r *api.Reminder) string { return r.ID })
+	case "reminder_update":
+		var a reminderArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		return "", s.updateReminder(a)
+	case "reminder_delete":
+		var a reminderArgs
+		if err := unmarshalArgs(args, &a); err != nil {
+			return "", err
+		}
+		return "", s.deleteReminder(a.ID)
+	}
+
+	return "", &apiError{http.StatusBadRequest, "UNKNOWN_COMMAND", "Unknown command: " + cmd.Type}
+}
+
+func unmarshalArgs(args json.RawMessage, v interface{}) *apiError {
+	if len(args) == 0 {
+		return missingArgument("args")
+	}
+	if err := json.Unmarshal(args, v); err != nil {
+		return invalidArgument("Invalid argument value: args")
+	}
+	return nil
+}
+
+// idOf returns the ID of a created resource.
+func idOf[T any](v *T, err *apiError, id func(*T) string) (string, *apiError) {
+	if err != nil {
+		return "", err
+	}
+	return id(v), nil
+}
+
+// replaceTempIDs substitutes real IDs for temp IDs anywhere in v.
+func replaceTempIDs(v interface{}, mapping map[string]string) interface{} {
+	switch val := v.(type) {
+	case string:
+		if id, ok := mapping[val]; ok {
+			return id
+		}
+		return val
+	case map[string]interface{}:
+		for k, item := range val {
+			val[k] = replaceTempIDs(item, mapping)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = replaceTempIDs(item, mapping)
+		}
+		return val
+	default:
+		return val
+	}
+}
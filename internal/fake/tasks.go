@@ -0,0 +1,430 @@
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// taskArgs are the task fields of a REST body or a Sync command. REST and
+// Sync name some of them differently; both spellings are accepted.
+type taskArgs struct {
+	ID          string    `json:"id"`
+	Content     *string   `json:"content"`
+	Description *string   `json:"description"`
+	ProjectID   *string   `json:"project_id"`
+	SectionID   *string   `json:"section_id"`
+	ParentID    *string   `json:"parent_id"`
+	Order       *int      `json:"order"`
+	ChildOrder  *int      `json:"child_order"`
+	Labels      *[]string `json:"labels"`
+	Priority    *int      `json:"priority"`
+
+	// REST due fields
+	DueString   *string `json:"due_string"`
+	DueDate     *string `json:"due_date"`
+	DueDatetime *string `json:"due_datetime"`
+	// Sync due object; null clears the due date
+	Due json.RawMessage `json:"due"`
+
+	DeadlineDate *string         `json:"deadline_date"`
+	Deadline     json.RawMessage `json:"deadline"`
+
+	AssigneeID     *string         `json:"assignee_id"`
+	ResponsibleUID json.RawMessage `json:"responsible_uid"`
+
+	// REST sends the amount with a separate unit, Sync an object
+	Duration     json.RawMessage `json:"duration"`
+	DurationUnit *string         `json:"duration_unit"`
+
+	CompletedAt *string `json:"completed_at"`
+}
+
+// activeTask returns the task with the given ID unless it was deleted.
+func (s *Server) activeTask(id string) (*api.Task, *apiError) {
+	t := s.tasks.get(id)
+	if t == nil || t.IsDeleted {
+		return nil, notFound("Task")
+	}
+	return t, nil
+}
+
+// addTask creates a task. Without a project it goes to the project of its
+// parent or section, or else the Inbox.
+func (s *Server) addTask(a taskArgs) (*api.Task, *apiError) {
+	if a.Content == nil || *a.Content == "" {
+		return nil, missingArgument("content")
+	}
+
+	t := &api.Task{
+		ID:         s.newID(),
+		UserID:     s.UserID,
+		AddedByUID: s.UserID,
+		Content:    *a.Content,
+		Labels:     []string{},
+		Priority:   1,
+		AddedAt:    s.timestamp(),
+		UpdatedAt:  s.timestamp(),
+	}
+	if a.Description != nil {
+		t.Description = *a.Description
+	}
+
+	switch {
+	case a.ProjectID != nil && *a.ProjectID != "":
+		if p := s.projects.get(*a.ProjectID); p == nil || p.IsDeleted {
+			return nil, notFound("Project")
+		}
+		t.ProjectID = *a.ProjectID
+	case a.ParentID != nil && *a.ParentID != "":
+		parent, err := s.activeTask(*a.ParentID)
+		if err != nil {
+			return nil, notFound("Parent task")
+		}
+		t.ProjectID = parent.ProjectID
+	case a.SectionID != nil && *a.SectionID != "":
+		sec := s.sections.get(*a.SectionID)
+		if sec == nil || sec.IsDeleted {
+			return nil, notFound("Section")
+		}
+		t.ProjectID = sec.ProjectID
+	default:
+		t.ProjectID = s.inbox().ID
+	}
+
+	if a.SectionID != nil && *a.SectionID != "" {
+		sec := s.sections.get(*a.SectionID)
+		if sec == nil || sec.IsDeleted || sec.ProjectID != t.ProjectID {
+			return nil, notFound("Section")
+		}
+		t.SectionID = a.SectionID
+	}
+	if a.ParentID != nil && *a.ParentID != "" {
+		parent, err := s.activeTask(*a.ParentID)
+		if err != nil || parent.ProjectID != t.ProjectID {
+			return nil, notFound("Parent task")
+		}
+		t.ParentID = a.ParentID
+		t.SectionID = parent.SectionID
+	}
+
+	order := a.ChildOrder
+	if order == nil {
+		order = a.Order
+	}
+	if order != nil {
+		t.ChildOrder = *order
+	} else {
+		for _, other := range s.tasks.items {
+			if other.ProjectID == t.ProjectID && other.ChildOrder >= t.ChildOrder {
+				t.ChildOrder = other.ChildOrder + 1
+			}
+		}
+	}
+
+	if err := s.applyTaskArgs(t, a); err != nil {
+		return nil, err
+	}
+	s.tasks.put(t.ID, t, s.bump())
+	return t, nil
+}
+
+// updateTaskFields changes the given fields of a task. Project, section and
+// parent are changed by moveTaskTo.
+func (s *Server) updateTaskFields(id string, a taskArgs) (*api.Task, *apiError) {
+	t, err := s.activeTask(id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Content != nil {
+		if *a.Content == "" {
+			return nil, invalidArgument("Content can't be empty")
+		}
+		t.Content = *a.Content
+	}
+	if a.Description != nil {
+		t.Description = *a.Description
+	}
+	if a.ChildOrder != nil {
+		t.ChildOrder = *a.ChildOrder
+	}
+	if err := s.applyTaskArgs(t, a); err != nil {
+		return nil, err
+	}
+	t.UpdatedAt = s.timestamp()
+	s.tasks.touch(t.ID, s.bump())
+	return t, nil
+}
+
+// applyTaskArgs sets the fields that creating and updating have in common.
+func (s *Server) applyTaskArgs(t *api.Task, a taskArgs) *apiError {
+	if a.Labels != nil {
+		t.Labels = append([]string{}, (*a.Labels)...)
+	}
+	if a.Priority != nil {
+		if *a.Priority < 1 || *a.Priority > 4 {
+			return invalidArgument("Invalid argument value: priority")
+		}
+		t.Priority = *a.Priority
+	}
+
+	due, clear, err := s.dueFromArgs(a)
+	if err != nil {
+		return err
+	}
+	if clear {
+		t.Due = nil
+	} else if due != nil {
+		t.Due = due
+	}
+
+	switch {
+	case a.DeadlineDate != nil && *a.DeadlineDate == "":
+		t.Deadline = nil
+	case a.DeadlineDate != nil:
+		t.Deadline = &api.Deadline{Date: *a.DeadlineDate, Lang: "en"}
+	case string(a.Deadline) == "null":
+		t.Deadline = nil
+	case a.Deadline != nil:
+		var d api.Deadline
+		if json.Unmarshal(a.Deadline, &d) != nil || d.Date == "" {
+			return invalidArgument("Invalid argument value: deadline")
+		}
+		t.Deadline = &d
+	}
+
+	var assignee *string
+	if a.AssigneeID != nil {
+		assignee = a.AssigneeID
+	} else if a.ResponsibleUID != nil {
+		if err := json.Unmarshal(a.ResponsibleUID, &assignee); err != nil {
+			return invalidArgument("Invalid argument value: responsible_uid")
+		}
+		if assignee == nil {
+			t.ResponsibleUID = nil
+		}
+	}
+	if assignee != nil {
+		if *assignee == "" {
+			t.ResponsibleUID = nil
+		} else {
+			uid := *assignee
+			t.ResponsibleUID = &uid
+			t.AssignedByUID = &s.UserID
+		}
+	}
+
+	switch {
+	case string(a.Duration) == "null":
+		t.Duration = nil
+	case a.Duration != nil:
+		var d api.Duration
+		if err := json.Unmarshal(a.Duration, &d.Amount); err == nil {
+			if a.DurationUnit == nil {
+				return missingArgument("duration_unit")
+			}
+			d.Unit = *a.DurationUnit
+		} else if json.Unmarshal(a.Duration, &d) != nil {
+			return invalidArgument("Invalid argument value: duration")
+		}
+		if d.Amount <= 0 || (d.Unit != "minute" && d.Unit != "day") {
+			return invalidArgument("Invalid argument value: duration")
+		}
+		t.Duration = &d
+	}
+	return nil
+}
+
+// dueFromArgs reads the due date of a REST body or Sync command. clear is
+// true if the due date is to be removed.
+func (s *Server) dueFromArgs(a taskArgs) (due *api.Due, clear bool, err *apiError) {
+	text, date := "", ""
+	switch {
+	case string(a.Due) == "null":
+		return nil, true, nil
+	case a.Due != nil:
+		var d api.Due
+		if json.Unmarshal(a.Due, &d) != nil {
+			return nil, false, invalidArgument("Invalid argument value: due")
+		}
+		text, date = d.String, d.Date
+	case a.DueString != nil:
+		text = *a.DueString
+	case a.DueDatetime != nil:
+		date = *a.DueDatetime
+	case a.DueDate != nil:
+		date = *a.DueDate
+	default:
+		return nil, false, nil
+	}
+
+	if text == "" && date == "" || text == "no date" {
+		return nil, true, nil
+	}
+	if text == "" {
+		text = date
+	}
+	parsed, ok := parseDue(text, s.now())
+	if !ok {
+		return nil, false, &apiError{http.StatusBadRequest, "INVALID_DATE_FORMAT", "Date format is not recognized"}
+	}
+	return parsed, false, nil
+}
+
+// moveTaskTo moves a task and its subtasks to a project, section or parent.
+// An empty parentID makes it a top-level task.
+func (s *Server) moveTaskTo(id string, projectID, sectionID, parentID *string) (*api.Task, *apiError) {
+	t, err := s.activeTask(id)
+	if err != nil {
+		return nil, err
+	}
+
+	project, section, parent := t.ProjectID, t.SectionID, t.ParentID
+	switch {
+	case parentID != nil && *parentID != "":
+		p, err := s.activeTask(*parentID)
+		if err != nil {
+			return nil, notFound("Parent task")
+		}
+		if p.ID == t.ID || slices.Contains(s.descendants(t.ID), p.ID) {
+			return nil, invalidArgument("A task can't be moved under its own subtask")
+		}
+		project, section, parent = p.ProjectID, p.SectionID, &p.ID
+	case sectionID != nil && *sectionID != "":
+		sec := s.sections.get(*sectionID)
+		if sec == nil || sec.IsDeleted {
+			return nil, notFound("Section")
+		}
+		project, section, parent = sec.ProjectID, &sec.ID, nil
+	case projectID != nil && *projectID != "":
+		p := s.projects.get(*projectID)
+		if p == nil || p.IsDeleted {
+			return nil, notFound("Project")
+		}
+		project, section, parent = p.ID, nil, nil
+	case parentID != nil:
+		parent = nil
+	default:
+		return nil, missingArgument("project_id, section_id or parent_id")
+	}
+
+	version := s.bump()
+	t.ParentID = parent
+	for _, taskID := range append([]string{t.ID}, s.descendants(t.ID)...) {
+		moved := s.tasks.get(taskID)
+		moved.ProjectID = project
+		moved.SectionID = section
+		moved.UpdatedAt = s.timestamp()
+		s.tasks.touch(taskID, version)
+	}
+	return t, nil
+}
+
+// completeTask completes a task and its subtasks, or moves a recurring task to
+// its next occurrence unless permanently is set.
+func (s *Server) completeTask(id string, permanently bool, completedAt string) *apiError {
+	t, err := s.activeTask(id)
+	if err != nil {
+		return err
+	}
+	version := s.bump()
+
+	if !permanently && t.Due != nil && t.Due.IsRecurring {
+		t.Due = nextOccurrence(t.Due)
+		t.UpdatedAt = s.timestamp()
+		s.tasks.touch(t.ID, version)
+		return nil
+	}
+
+	if completedAt == "" {
+		completedAt = s.timestamp()
+	}
+	for _, taskID := range append([]string{t.ID}, s.descendants(t.ID)...) {
+		done := s.tasks.get(taskID)
+		if done.Checked {
+			continue
+		}
+		at := completedAt
+		done.Checked = true
+		done.CompletedAt = &at
+		done.CompletedByUID = &s.UserID
+		s.tasks.touch(taskID, version)
+	}
+	return nil
+}
+
+// uncompleteTask uncompletes a task.
+func (s *Server) uncompleteTask(id string) *apiError {
+	t, err := s.activeTask(id)
+	if err != nil {
+		return err
+	}
+	t.Checked = false
+	t.CompletedAt = nil
+	t.CompletedByUID = nil
+	s.tasks.touch(t.ID, s.bump())
+	return nil
+}
+
+// removeTask deletes a task along with its subtasks.
+func (s *Server) removeTask(id string) *apiError {
+	t, err := s.activeTask(id)
+	if err != nil {
+		return err
+	}
+	version := s.bump()
+	for _, taskID := range append([]string{t.ID}, s.descendants(t.ID)...) {
+		s.tasks.get(taskID).IsDeleted = true
+		s.tasks.touch(taskID, version)
+	}
+	return nil
+}
+
+// descendants returns the IDs of the subtasks of a task, at any depth.
+func (s *Server) descendants(id string) []string {
+	var ids []string
+	for _, childID := range s.tasks.order {
+		child := s.tasks.get(childID)
+		if child.ParentID != nil && *child.ParentID == id && !child.IsDeleted {
+			ids = append(ids, childID)
+			ids = append(ids, s.descendants(childID)...)
+		}
+	}
+	return ids
+}
+
+// addQuickTask creates a task from quick add text.
+func (s *Server) addQuickTask(text string) (*api.Task, *apiError) {
+	q := parseQuickAdd(text, s.now())
+	if q.content == "" {
+		return nil, missingArgument("text")
+	}
+
+	a := taskArgs{Content: &q.content, Labels: &q.labels}
+	if q.project != "" {
+		for _, p := range s.projects.list(isActiveProject) {
+			if strings.EqualFold(p.Name, q.project) {
+				a.ProjectID = &p.ID
+				break
+			}
+		}
+		// Unknown projects are left in the content, like Todoist does
+		if a.ProjectID == nil {
+			q.content += " #" + q.project
+		}
+	}
+	if q.priority > 0 {
+		a.Priority = &q.priority
+	}
+
+	t, err := s.addTask(a)
+	if err != nil {
+		return nil, err
+	}
+	t.Due = q.due
+	t.Deadline = q.deadline
+	return t, nil
+}
@@ -0,0 +1,356 @@
+package filterquery
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// Context holds what a query needs besides the tasks themselves.
+type Context struct {
+	// Now is the current time; the zero value means time.Now().
+	Now time.Time
+	// Projects and Sections resolve "#project", "##project" and "/section".
+	Projects []api.Project
+	Sections []api.Section
+	// UserID is the current user, for "assigned to: me" and "assigned to:
+	// others". Those fall back to the server when it is empty.
+	UserID string
+	// Collaborators resolve "assigned to: NAME".
+	Collaborators []api.Collaborator
+}
+
+// Apply parses query and returns the tasks that match it, in their original
+// order. The error wraps ErrUnsupported when the server should be asked
+// instead.
+func Apply(query string, tasks []api.Task, ctx Context) ([]api.Task, error) {
+	q, err := Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	return q.Filter(tasks, ctx)
+}
+
+// Filter returns the tasks that match q, in their original order. Project,
+// section and assignee names are looked up in ctx; names that are not found
+// make the query unsupported rather than match nothing, since the loaded
+// data may be stale.
+func (q *Query) Filter(tasks []api.Task, ctx Context) ([]api.Task, error) {
+	e := newEnv(ctx)
+	match, err := e.compile(q.root)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]api.Task, 0)
+	for i := range tasks {
+		if match(&tasks[i]) {
+			result = append(result, tasks[i])
+		}
+	}
+	return result, nil
+}
+
+// predicate reports whether a task matches part of a query.
+type predicate func(t *api.Task) bool
+
+// env is a Context prepared for evaluation.
+type env struct {
+	ctx   Context
+	now   time.Time
+	today time.Time // Midnight of now, in the local time zone
+}
+
+func newEnv(ctx Context) *env {
+	now := ctx.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	now = now.In(time.Local)
+	return &env{
+		ctx:   ctx,
+		now:   now,
+		today: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local),
+	}
+}
+
+// compile turns a syntax tree into a predicate.
+func (e *env) compile(n node) (predicate, error) {
+	switch n := n.(type) {
+	case andNode:
+		left, right, err := e.compilePair(n.left, n.right)
+		if err != nil {
+			return nil, err
+		}
+		return func(t *api.Task) bool { return left(t) && right(t) }, nil
+	case orNode:
+		left, right, err := e.compilePair(n.left, n.right)
+		if err != nil {
+			return nil, err
+		}
+		return func(t *api.Task) bool { return left(t) || right(t) }, nil
+	case notNode:
+		operand, err := e.compile(n.operand)
+		if err != nil {
+			return nil, err
+		}
+		return func(t *api.Task) bool { return !operand(t) }, nil
+	case term:
+		return e.compileTerm(n)
+	}
+	return nil, fmt.Errorf("unexpected node %T: %w", n, ErrUnsupported)
+}
+
+func (e *env) compilePair(a, b node) (predicate, predicate, error) {
+	left, err := e.compile(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	right, err := e.compile(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	return left, right, nil
+}
+
+// compileTerm turns a single condition into a predicate.
+func (e *env) compileTerm(tm term) (predicate, error) {
+	switch tm.kind {
+	case termToday:
+		return e.dueOn(e.today), nil
+	case termTomorrow:
+		return e.dueOn(e.today.AddDate(0, 0, 1)), nil
+	case termDate:
+		day, err := parseDate(tm.arg, e.today)
+		if err != nil {
+			return nil, err
+		}
+		return e.dueOn(day), nil
+	case termOverdue:
+		return func(t *api.Task) bool {
+			due, hasTime, ok := dueTime(t)
+			if !ok || t.Checked {
+				return false
+			}
+			if hasTime {
+				return due.Before(e.now)
+			}
+			return due.Before(e.today)
+		}, nil
+	case termNoDate:
+		return func(t *api.Task) bool { return t.Due == nil }, nil
+	case termRecurring:
+		return func(t *api.Task) bool { return t.Due != nil && t.Due.IsRecurring }, nil
+	case termNoLabels:
+		return func(t *api.Task) bool { return len(t.Labels) == 0 }, nil
+	case termPriority:
+		// p1 is the API's priority 4
+		priority := 5 - int(tm.arg[0]-'0')
+		return func(t *api.Task) bool { return t.Priority == priority }, nil
+	case termDueBefore:
+		day, err := parseDate(strings.ToLower(tm.arg), e.today)
+		if err != nil {
+			return nil, err
+		}
+		return func(t *api.Task) bool {
+			due, _, ok := dueTime(t)
+			return ok && due.Before(day)
+		}, nil
+	case termDueAfter:
+		day, err := parseDate(strings.ToLower(tm.arg), e.today)
+		if err != nil {
+			return nil, err
+		}
+		next := day.AddDate(0, 0, 1)
+		return func(t *api.Task) bool {
+			due, _, ok := dueTime(t)
+			return ok && !due.Before(next)
+		}, nil
+	case termLabel:
+		return func(t *api.Task) bool {
+			for _, l := range t.Labels {
+				if wildcardMatch(tm.arg, l) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case termProject, termProjectTree:
+		ids := e.projectIDs(tm.arg, tm.kind == termProjectTree)
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("unknown project %q: %w", tm.arg, ErrUnsupported)
+		}
+		return func(t *api.Task) bool { return ids[t.ProjectID] }, nil
+	case termSection:
+		ids := make(map[string]bool)
+		for _, s := range e.ctx.Sections {
+			if wildcardMatch(tm.arg, s.Name) {
+				ids[s.ID] = true
+			}
+		}
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("unknown section %q: %w", tm.arg, ErrUnsupported)
+		}
+		return func(t *api.Task) bool { return t.SectionID != nil && ids[*t.SectionID] }, nil
+	case termAssigned:
+		return func(t *api.Task) bool { return t.ResponsibleUID != nil && *t.ResponsibleUID != "" }, nil
+	case termAssignedTo:
+		return e.assignedTo(tm.arg)
+	case termSearch:
+		text := strings.ToLower(tm.arg)
+		return func(t *api.Task) bool {
+			return strings.Contains(strings.ToLower(t.Content), text)
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown filter %q: %w", tm.kind, ErrUnsupported)
+}
+
+// dueOn matches tasks due on the given day.
+func (e *env) dueOn(day time.Time) predicate {
+	return func(t *api.Task) bool {
+		due, _, ok := dueTime(t)
+		if !ok {
+			return false
+		}
+		y1, m1, d1 := due.Date()
+		y2, m2, d2 := day.Date()
+		return y1 == y2 && m1 == m2 && d1 == d2
+	}
+}
+
+// projectIDs returns the IDs of the projects whose name matches pattern,
+// plus all their subprojects when withChildren is set.
+func (e *env) projectIDs(pattern string, withChildren bool) map[string]bool {
+	ids := make(map[string]bool)
+	for _, p := range e.ctx.Projects {
+		if wildcardMatch(pattern, p.Name) {
+			ids[p.ID] = true
+		}
+	}
+	if !withChildren {
+		return ids
+	}
+	// Add children until nothing changes; the hierarchy is shallow
+	for added := true; added; {
+		added = false
+		for _, p := range e.ctx.Projects {
+			if p.ParentID != nil && ids[*p.ParentID] && !ids[p.ID] {
+				ids[p.ID] = true
+				added = true
+			}
+		}
+	}
+	return ids
+}
+
+// assignedTo matches tasks assigned to "me", "others" or a collaborator.
+func (e *env) assignedTo(who string) (predicate, error) {
+	responsible := func(t *api.Task) string {
+		if t.ResponsibleUID == nil {
+			return ""
+		}
+		return *t.ResponsibleUID
+	}
+
+	switch strings.ToLower(who) {
+	case "me":
+		if e.ctx.UserID == "" {
+			return nil, fmt.Errorf("current user unknown: %w", ErrUnsupported)
+		}
+		return func(t *api.Task) bool { return responsible(t) == e.ctx.UserID }, nil
+	case "others":
+		if e.ctx.UserID == "" {
+			return nil, fmt.Errorf("current user unknown: %w", ErrUnsupported)
+		}
+		return func(t *api.Task) bool {
+			uid := responsible(t)
+			return uid != "" && uid != e.ctx.UserID
+		}, nil
+	}
+
+	ids := make(map[string]bool)
+	for _, c := range e.ctx.Collaborators {
+		if strings.EqualFold(c.Name, who) || strings.EqualFold(c.Email, who) {
+			ids[c.ID] = true
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("unknown collaborator %q: %w", who, ErrUnsupported)
+	}
+	return func(t *api.Task) bool { return ids[responsible(t)] }, nil
+}
+
+// dueTime returns when t is due in the local time zone and whether a time
+// of day is set. Date-only tasks are due at midnight.
+func dueTime(t *api.Task) (time.Time, bool, bool) {
+	if t.Due == nil {
+		return time.Time{}, false, false
+	}
+	if t.Due.Datetime != nil && *t.Due.Datetime != "" {
+		if parsed, err := time.Parse(time.RFC3339, *t.Due.Datetime); err == nil {
+			return parsed.In(time.Local), true, true
+		}
+		if parsed, err := time.ParseInLocation("2006-01-02T15:04:05", *t.Due.Datetime, time.Local); err == nil {
+			return parsed, true, true
+		}
+	}
+	if len(t.Due.Date) > 10 {
+		// Floating time stored in the date field
+		if parsed, err := time.ParseInLocation("2006-01-02T15:04:05", t.Due.Date, time.Local); err == nil {
+			return parsed, true, true
+		}
+	}
+	if len(t.Due.Date) < 10 {
+		return time.Time{}, false, false
+	}
+	parsed, err := time.ParseInLocation("2006-01-02", t.Due.Date[:10], time.Local)
+	if err != nil {
+		return time.Time{}, false, false
+	}
+	return parsed, false, true
+}
+
+// parseDate parses the dates accepted in "due before:" and on their own:
+// today, tomorrow, yesterday and YYYY-MM-DD. Other forms, such as "next
+// monday", are left to the server.
+func parseDate(text string, today time.Time) (time.Time, error) {
+	switch text {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", text, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q: %w", text, ErrUnsupported)
+	}
+	return day, nil
+}
+
+// wildcardMatch reports whether name matches pattern, ignoring case. A "*"
+// in the pattern matches any run of characters.
+func wildcardMatch(pattern, name string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	name = strings.ToLower(name)
+
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
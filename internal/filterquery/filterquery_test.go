@@ -0,0 +1,173 @@
+package filterquery
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+func strPtr(s string) *string { return &s }
+
+// testNow is Monday 2024-01-22 at noon, local time.
+var testNow = time.Date(2024, 1, 22, 12, 0, 0, 0, time.Local)
+
+func testContext() Context {
+	return Context{
+		Now: testNow,
+		Projects: []api.Project{
+			{ID: "inbox", Name: "Inbox", InboxProject: true},
+			{ID: "work", Name: "Work"},
+			{ID: "backend", Name: "Backend", ParentID: strPtr("work")},
+			{ID: "home", Name: "Home"},
+		},
+		Sections: []api.Section{
+			{ID: "s-meet", Name: "Meetings", ProjectID: "work"},
+		},
+		UserID:        "me",
+		Collaborators: []api.Collaborator{{ID: "u2", Name: "Alice", Email: "alice@example.com"}},
+	}
+}
+
+func testTasks() []api.Task {
+	return []api.Task{
+		{ID: "today", Content: "Write report", ProjectID: "work", Priority: 4, Labels: []string{"deep"},
+			Due: &api.Due{Date: "2024-01-22"}},
+		{ID: "overdue", Content: "Pay rent", ProjectID: "home", Priority: 1,
+			Due: &api.Due{Date: "2024-01-20", IsRecurring: true}},
+		{ID: "earlier", Content: "Standup", ProjectID: "work", SectionID: strPtr("s-meet"), Priority: 1,
+			Due: &api.Due{Date: "2024-01-22T09:00:00", Datetime: strPtr("2024-01-22T09:00:00")}, ResponsibleUID: strPtr("me")},
+		{ID: "tomorrow", Content: "Deploy API", ProjectID: "backend", Priority: 3, Labels: []string{"deep-work"},
+			Due: &api.Due{Date: "2024-01-23"}, ResponsibleUID: strPtr("u2")},
+		{ID: "nodate", Content: "Read book", ProjectID: "inbox", Priority: 1},
+	}
+}
+
+func ids(tasks []api.Task) string {
+	var list []string
+	for _, t := range tasks {
+		list = append(list, t.ID)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"today", "earlier,today"},
+		{"overdue", "earlier,overdue"},
+		{"today | overdue", "earlier,overdue,today"},
+		{"tomorrow", "tomorrow"},
+		{"no date", "nodate"},
+		{"p1", "today"},
+		{"p4 & !no date", "earlier,overdue"},
+		{"@deep", "today"},
+		{"@deep*", "today,tomorrow"},
+		{"no labels & #Work", "earlier"},
+		{"#work", "earlier,today"},
+		{"##Work", "earlier,today,tomorrow"},
+		{"#Home | #Inbox", "nodate,overdue"},
+		{"/Meetings", "earlier"},
+		{"#Work & !/Meetings", "today"},
+		{"due before: today", "overdue"},
+		{"due before: tomorrow", "earlier,overdue,today"},
+		{"due after: today", "tomorrow"},
+		{"2024-01-23", "tomorrow"},
+		{"recurring", "overdue"},
+		{"assigned", "earlier,tomorrow"},
+		{"assigned to: me", "earlier"},
+		{"assigned to: others", "tomorrow"},
+		{"assigned to: alice", "tomorrow"},
+		{"search: REPORT", "today"},
+		{"(today | tomorrow) & @deep*", "today,tomorrow"},
+		{"!(today | overdue | no date)", "tomorrow"},
+		{"!!today", "earlier,today"},
+		{"p1 | p2 & #Work", "today"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := Apply(tt.query, testTasks(), testContext())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ids(got) != tt.want {
+				t.Errorf("got %q, want %q", ids(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestApply_Unsupported(t *testing.T) {
+	ctx := testContext()
+	noUser := testContext()
+	noUser.UserID = ""
+
+	tests := []struct {
+		query string
+		ctx   Context
+	}{
+		{"today, overdue", ctx},              // Several lists
+		{"next 7 days", ctx},                 // Unknown term
+		{"due before: next monday", ctx},     // Date the local parser does not know
+		{"#Garden", ctx},                     // Project not loaded
+		{"/Backlog", ctx},                    // Section not loaded
+		{"assigned to: bob", ctx},            // Unknown collaborator
+		{"assigned to: me", noUser},          // Current user unknown
+		{"(today", ctx},                      // Syntax error
+		{"today &", ctx},                     // Syntax error
+		{"", ctx},                            // Empty
+		{"created before: yesterday", ctx},   // Unknown term
+		{"today | view all", ctx},            // Unknown term in an otherwise valid query
+		{"shared & assigned to: me", noUser}, // Unknown term
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Apply(tt.query, testTasks(), tt.ctx)
+			if !errors.Is(err, ErrUnsupported) {
+				t.Errorf("expected ErrUnsupported, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTokenize_Escapes(t *testing.T) {
+	ctx := testContext()
+	ctx.Projects = append(ctx.Projects, api.Project{ID: "rd", Name: "R&D"})
+	tasks := []api.Task{{ID: "1", ProjectID: "rd"}, {ID: "2", ProjectID: "work"}}
+
+	got, err := Apply(`#R\&D`, tasks, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids(got) != "1" {
+		t.Errorf("got %q, want the R&D task", ids(got))
+	}
+}
+
+func TestWildcardMatch(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"work", "Work", true},
+		{"work", "Workshop", false},
+		{"work*", "Workshop", true},
+		{"*shop", "Workshop", true},
+		{"w*k*p", "Workshop", true},
+		{"a*a", "a", false},
+		{"*", "", true},
+	}
+	for _, tt := range tests {
+		if got := wildcardMatch(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("wildcardMatch(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+// Package filterquery parses Todoist filter queries and evaluates them
+// against tasks that are already loaded, so filters can run instantly and
+// offline. Queries it cannot answer exactly are reported with
+// ErrUnsupported and should be sent to the server instead.
+package filterquery
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrUnsupported is returned for queries that only the server can evaluate,
+// e.g. because they use syntax this package does not know or name a project
+// that is not loaded.
+var ErrUnsupported = errors.New("filter query not supported locally")
+
+// Query is a parsed filter query.
+type Query struct {
+	text string
+	root node
+}
+
+// String returns the query text.
+func (q *Query) String() string { return q.text }
+
+// node is an element of the query syntax tree.
+type node interface{}
+
+type andNode struct{ left, right node }
+type orNode struct{ left, right node }
+type notNode struct{ operand node }
+
+// term is a single condition such as "today", "@work" or "due before: tomorrow".
+type term struct {
+	kind string // One of the term* constants
+	arg  string // Name pattern, date or search text, as written
+}
+
+// Term kinds.
+const (
+	termToday       = "today"
+	termTomorrow    = "tomorrow"
+	termOverdue     = "overdue"
+	termNoDate      = "no date"
+	termRecurring   = "recurring"
+	termNoLabels    = "no labels"
+	termDate        = "date"
+	termPriority    = "priority"
+	termLabel       = "label"
+	termProject     = "project"
+	termProjectTree = "project tree"
+	termSection     = "section"
+	termDueBefore   = "due before"
+	termDueAfter    = "due after"
+	termAssigned    = "assigned"
+	termAssignedTo  = "assigned to"
+	termSearch      = "search"
+)
+
+// token kinds.
+const (
+	tokTerm = iota
+	tokAnd
+	tokOr
+	tokNot
+	tokOpen
+	tokClose
+)
+
+type token struct {
+	kind int
+	text string
+}
+
+// Parse parses a filter query. Syntax errors and constructs this package
+// cannot evaluate, such as several comma-separated queries, return an error
+// wrapping ErrUnsupported.
+func Parse(query string) (*Query, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty query: %w", ErrUnsupported)
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q: %w", p.tokens[p.pos].text, ErrUnsupported)
+	}
+	return &Query{text: query, root: root}, nil
+}
+
+// tokenize splits a query into operators and terms. A backslash escapes
+// the next character, so names may contain "&" or "|".
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	var current strings.Builder
+	escaped := false
+
+	flush := func() {
+		if text := strings.TrimSpace(current.String()); text != "" {
+			tokens = append(tokens, token{kind: tokTerm, text: text})
+		}
+		current.Reset()
+	}
+
+	for _, r := range query {
+		if escaped {
+			current.WriteRune(r)
+			escaped = false
+			continue
+		}
+		switch r {
+		case '\\':
+			escaped = true
+		case '&':
+			flush()
+			tokens = append(tokens, token{kind: tokAnd, text: "&"})
+		case '|':
+			flush()
+			tokens = append(tokens, token{kind: tokOr, text: "|"})
+		case '(':
+			flush()
+			tokens = append(tokens, token{kind: tokOpen, text: "("})
+		case ')':
+			flush()
+			tokens = append(tokens, token{kind: tokClose, text: ")"})
+		case '!':
+			// Negation only at the start of a term; "search: hi!" keeps it
+			if strings.TrimSpace(current.String()) == "" {
+				current.Reset()
+				tokens = append(tokens, token{kind: tokNot, text: "!"})
+			} else {
+				current.WriteRune(r)
+			}
+		case ',':
+			// Comma-separated queries produce several lists
+			return nil, fmt.Errorf("multiple queries: %w", ErrUnsupported)
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens, nil
+}
+
+// parser is a recursive descent parser. "!" binds tightest, then "&",
+// then "|".
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != tokOr {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != tokAnd {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of query: %w", ErrUnsupported)
+	}
+	p.pos++
+
+	switch tok.kind {
+	case tokNot:
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	case tokOpen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if next, ok := p.peek(); !ok || next.kind != tokClose {
+			return nil, fmt.Errorf("missing \")\": %w", ErrUnsupported)
+		}
+		p.pos++
+		return inner, nil
+	case tokTerm:
+		return parseTerm(tok.text)
+	}
+	return nil, fmt.Errorf("unexpected %q: %w", tok.text, ErrUnsupported)
+}
+
+// parseTerm recognizes a single condition.
+func parseTerm(text string) (node, error) {
+	lower := strings.ToLower(text)
+
+	switch lower {
+	case "today":
+		return term{kind: termToday}, nil
+	case "tomorrow":
+		return term{kind: termTomorrow}, nil
+	case "overdue", "over due":
+		return term{kind: termOverdue}, nil
+	case "no date", "no due date":
+		return term{kind: termNoDate}, nil
+	case "recurring":
+		return term{kind: termRecurring}, nil
+	case "no labels":
+		return term{kind: termNoLabels}, nil
+	case "assigned":
+		return term{kind: termAssigned}, nil
+	case "p1", "p2", "p3", "p4":
+		return term{kind: termPriority, arg: lower[1:]}, nil
+	}
+
+	switch {
+	case strings.HasPrefix(text, "@"):
+		return term{kind: termLabel, arg: text[1:]}, nil
+	case strings.HasPrefix(text, "##"):
+		return term{kind: termProjectTree, arg: text[2:]}, nil
+	case strings.HasPrefix(text, "#"):
+		return term{kind: termProject, arg: text[1:]}, nil
+	case strings.HasPrefix(text, "/"):
+		return term{kind: termSection, arg: text[1:]}, nil
+	}
+
+	prefixes := []struct {
+		prefix, kind string
+	}{
+		{"due before:", termDueBefore},
+		{"date before:", termDueBefore},
+		{"due after:", termDueAfter},
+		{"date after:", termDueAfter},
+		{"assigned to:", termAssignedTo},
+		{"search:", termSearch},
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(lower, p.prefix) {
+			arg := strings.TrimSpace(text[len(p.prefix):])
+			if arg == "" {
+				return nil, fmt.Errorf("%q needs a value: %w", p.prefix, ErrUnsupported)
+			}
+			if p.kind == termDueBefore || p.kind == termDueAfter {
+				if _, err := parseDate(strings.ToLower(arg), time.Time{}); err != nil {
+					return nil, err
+				}
+			}
+			return term{kind: p.kind, arg: arg}, nil
+		}
+	}
+
+	if _, err := parseDate(lower, time.Time{}); err == nil {
+		return term{kind: termDate, arg: lower}, nil
+	}
+	return nil, fmt.Errorf("unknown filter %q: %w", text, ErrUnsupported)
+}
@@ -0,0 +1,160 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Fields that CSV columns can be mapped to.
+var csvFields = []string{
+	"content", "description", "project", "section", "priority", "due",
+	"deadline", "labels", "completed", "comments", "id", "parent_id",
+}
+
+// ParseMapping parses a column mapping such as "content=Title,due=Due Date".
+// Fields that are not mapped use the column of the same name.
+func ParseMapping(spec string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if strings.TrimSpace(spec) == "" {
+		return mapping, nil
+	}
+	for _, pair := range strings.Split(spec, ",") {
+		field, column, ok := strings.Cut(pair, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		column = strings.TrimSpace(column)
+		if !ok || field == "" || column == "" {
+			return nil, fmt.Errorf("invalid mapping %q (want field=Column)", pair)
+		}
+		if !isCSVField(field) {
+			return nil, fmt.Errorf("unknown field %q (want one of %s)", field, strings.Join(csvFields, ", "))
+		}
+		mapping[field] = column
+	}
+	return mapping, nil
+}
+
+func isCSVField(name string) bool {
+	for _, f := range csvFields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCSV reads a CSV file with a header row. mapping maps fields to column
+// names (case-insensitive); by default the CSV export's column names are
+// used. Priorities use the p1-p4 numbering, labels and comments are comma
+// and blank-line separated, and parent_id refers to the id column of an
+// earlier row.
+func ParseCSV(r io.Reader, mapping map[string]string) (*Plan, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return &Plan{}, nil
+		}
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for _, field := range csvFields {
+		name := field
+		if m, ok := mapping[field]; ok {
+			name = m
+		}
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), name) {
+				columns[field] = i
+				break
+			}
+		}
+		if _, found := columns[field]; !found && mapping[field] != "" {
+			return nil, fmt.Errorf("column %q for %s not found", mapping[field], field)
+		}
+	}
+	if _, ok := columns["content"]; !ok {
+		return nil, errors.New("no content column; map one with content=Column")
+	}
+
+	plan := &Plan{}
+	byID := make(map[string]int)
+	row := 1
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		row++
+		if err != nil {
+			plan.Errors = append(plan.Errors, RowError{Row: row, Err: err})
+			continue
+		}
+
+		get := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		item := Item{
+			Row:         row,
+			Content:     get("content"),
+			Description: get("description"),
+			Project:     get("project"),
+			Section:     get("section"),
+			Due:         get("due"),
+			Deadline:    get("deadline"),
+			Parent:      -1,
+		}
+		if item.Content == "" {
+			plan.Errors = append(plan.Errors, RowError{Row: row, Err: errors.New("empty content")})
+			continue
+		}
+
+		if p := get("priority"); p != "" {
+			n, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(p), "p"))
+			if err != nil || n < 1 || n > 4 {
+				plan.Errors = append(plan.Errors, RowError{Row: row, Err: fmt.Errorf("invalid priority %q (want 1-4)", p)})
+				continue
+			}
+			item.Priority = 5 - n
+		}
+		for _, l := range strings.Split(get("labels"), ",") {
+			if l = strings.TrimSpace(l); l != "" {
+				item.Labels = append(item.Labels, strings.TrimPrefix(l, "@"))
+			}
+		}
+		switch strings.ToLower(get("completed")) {
+		case "true", "yes", "x", "1":
+			item.Completed = true
+		}
+		for _, c := range strings.Split(get("comments"), "\n\n") {
+			if c = strings.TrimSpace(c); c != "" {
+				item.Comments = append(item.Comments, c)
+			}
+		}
+
+		if parentID := get("parent_id"); parentID != "" {
+			p, ok := byID[parentID]
+			if !ok {
+				plan.Errors = append(plan.Errors, RowError{Row: row, Err: fmt.Errorf("parent %q not found in an earlier row", parentID)})
+				continue
+			}
+			item.Parent = p
+		}
+
+		plan.Items = append(plan.Items, item)
+		if id := get("id"); id != "" {
+			byID[id] = len(plan.Items) - 1
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,395 @@
+// Package importer creates Todoist tasks from Markdown checklists, CSV files
+// and todo.txt lists. Parsed items are turned into a batch of Sync commands
+// in which subtasks refer to their parents through temp IDs.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// Supported formats.
+const (
+	FormatMarkdown = "md"
+	FormatCSV      = "csv"
+	FormatTodoTxt  = "todo.txt"
+)
+
+// ParseFormat normalizes a format name, accepting common aliases such as
+// "markdown" and "todotxt".
+func ParseFormat(name string) (string, error) {
+	switch strings.ToLower(name) {
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "csv":
+		return FormatCSV, nil
+	case "todo.txt", "todotxt", "txt":
+		return FormatTodoTxt, nil
+	}
+	return "", fmt.Errorf("unknown import format %q (want md, csv or todo.txt)", name)
+}
+
+// Item is a task parsed from the source file.
+type Item struct {
+	Row         int // Source line or CSV row, starting at 1
+	Content     string
+	Description string
+	Project     string // Project name or "Parent / Child" path; empty for the default project
+	Section     string
+	Priority    int // API numbering (4 is p1); 0 keeps the default
+	Labels      []string
+	Due         string // Natural language or ISO date
+	Deadline    string // YYYY-MM-DD
+	Completed   bool
+	Comments    []string
+	Parent      int // Index of the parent item in Plan.Items, or -1
+}
+
+// RowError is a failure tied to a source row.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e RowError) Error() string {
+	if e.Row == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// Plan is the result of parsing a file.
+type Plan struct {
+	Items []Item
+	// Errors lists rows that could not be parsed; they are not imported.
+	Errors []RowError
+}
+
+// Parse reads r in the given format. mapping is only used for CSV.
+func Parse(r io.Reader, format string, mapping map[string]string) (*Plan, error) {
+	switch format {
+	case FormatMarkdown:
+		return ParseMarkdown(r)
+	case FormatCSV:
+		return ParseCSV(r, mapping)
+	case FormatTodoTxt:
+		return ParseTodoTxt(r)
+	}
+	return nil, fmt.Errorf("unknown import format %q", format)
+}
+
+// depth returns how many ancestors item i has.
+func (p *Plan) depth(i int) int {
+	d := 0
+	for parent := p.Items[i].Parent; parent >= 0; parent = p.Items[parent].Parent {
+		d++
+	}
+	return d
+}
+
+// parseMeta extracts the inline metadata shared by the Markdown and todo.txt
+// formats (p1-p3, @label, due:, deadline:) from text into item and returns
+// the remaining words. Unknown key:value pairs are left in place.
+func parseMeta(text string, item *Item, allowPriority bool) []string {
+	var rest []string
+	for _, word := range strings.Fields(text) {
+		switch {
+		case allowPriority && len(word) == 2 && word[0] == 'p' && word[1] >= '1' && word[1] <= '4':
+			item.Priority = 5 - int(word[1]-'0')
+		case len(word) > 1 && word[0] == '@':
+			item.Labels = append(item.Labels, word[1:])
+		case strings.HasPrefix(word, "due:") && len(word) > len("due:"):
+			item.Due = strings.TrimPrefix(word, "due:")
+		case strings.HasPrefix(word, "deadline:") && len(word) > len("deadline:"):
+			item.Deadline = strings.TrimPrefix(word, "deadline:")
+		default:
+			rest = append(rest, word)
+		}
+	}
+	return rest
+}
+
+// owner identifies what a command was created for, for error reporting.
+type owner struct {
+	row  int
+	what string
+}
+
+// Batch is a plan resolved against the user's projects and sections, ready
+// to be sent.
+type Batch struct {
+	Plan        *Plan
+	Commands    []api.SyncCommand
+	NewProjects []string
+	NewSections []string
+	// Errors lists rows that were skipped, including parse errors.
+	Errors []RowError
+
+	owners     map[string]owner // Command UUID -> owner
+	projectIDs map[string]string
+	sectionIDs map[string]string
+	projects   []api.Project
+	sections   []api.Section
+}
+
+// Build turns plan into Sync commands. Items without a project go to
+// defaultProjectID, or the Inbox when it is empty. Missing projects and
+// sections are created.
+func Build(plan *Plan, projects []api.Project, sections []api.Section, defaultProjectID string) *Batch {
+	b := &Batch{
+		Plan:       plan,
+		Errors:     append([]RowError(nil), plan.Errors...),
+		owners:     make(map[string]owner),
+		projectIDs: make(map[string]string),
+		sectionIDs: make(map[string]string),
+		projects:   projects,
+		sections:   sections,
+	}
+
+	tempIDs := make([]string, len(plan.Items))
+	for i, item := range plan.Items {
+		req := api.CreateTaskRequest{
+			Content:      item.Content,
+			Description:  item.Description,
+			Labels:       item.Labels,
+			Priority:     item.Priority,
+			DueString:    item.Due,
+			DeadlineDate: item.Deadline,
+		}
+
+		if item.Parent >= 0 {
+			// Subtasks live in their parent's project and section
+			if tempIDs[item.Parent] == "" {
+				b.Errors = append(b.Errors, RowError{Row: item.Row, Err: fmt.Errorf("parent on row %d was not imported", plan.Items[item.Parent].Row)})
+				continue
+			}
+			req.ParentID = tempIDs[item.Parent]
+		} else {
+			req.ProjectID = defaultProjectID
+			if item.Project != "" {
+				req.ProjectID = b.resolveProject(item.Project)
+			}
+			if item.Section != "" {
+				if req.ProjectID == "" {
+					req.ProjectID = b.inboxID()
+				}
+				req.SectionID = b.resolveSection(item.Project, req.ProjectID, item.Section)
+			}
+		}
+		req.Order = i + 1
+
+		cmd := api.AddTaskCommand(req)
+		tempIDs[i] = cmd.TempID
+		b.add(cmd, owner{row: item.Row, what: "task"})
+
+		for _, comment := range item.Comments {
+			b.add(api.AddCommentCommand(cmd.TempID, comment), owner{row: item.Row, what: "comment"})
+		}
+		if item.Completed {
+			b.add(api.CloseTaskCommand(cmd.TempID), owner{row: item.Row, what: "completion"})
+		}
+	}
+	return b
+}
+
+// add appends cmd to the batch.
+func (b *Batch) add(cmd api.SyncCommand, o owner) {
+	b.Commands = append(b.Commands, cmd)
+	b.owners[cmd.UUID] = o
+}
+
+// inboxID returns the Inbox project ID, or "" if it is unknown.
+func (b *Batch) inboxID() string {
+	for _, p := range b.projects {
+		if p.InboxProject {
+			return p.ID
+		}
+	}
+	return ""
+}
+
+// resolveProject returns the ID of the project at path ("Parent / Child"),
+// adding project_add commands for the parts that do not exist.
+func (b *Batch) resolveProject(path string) string {
+	parts := strings.Split(path, " / ")
+	parentID := ""
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		key := strings.ToLower(strings.Join(parts[:i+1], " / "))
+		if id, ok := b.projectIDs[key]; ok {
+			parentID = id
+			continue
+		}
+
+		id := ""
+		for _, p := range b.projects {
+			if !strings.EqualFold(p.Name, part) {
+				continue
+			}
+			// The first part may match a project anywhere in the hierarchy
+			if i == 0 || (p.ParentID != nil && *p.ParentID == parentID) {
+				id = p.ID
+				break
+			}
+		}
+		if id == "" {
+			cmd := api.AddProjectCommand(part, parentID)
+			b.add(cmd, owner{what: fmt.Sprintf("project %q", part)})
+			b.NewProjects = append(b.NewProjects, strings.Join(parts[:i+1], " / "))
+			id = cmd.TempID
+		}
+		b.projectIDs[key] = id
+		parentID = id
+	}
+	return parentID
+}
+
+// resolveSection returns the ID of the named section in projectID, adding a
+// section_add command if it does not exist.
+func (b *Batch) resolveSection(projectName, projectID, name string) string {
+	key := projectID + "/" + strings.ToLower(name)
+	if id, ok := b.sectionIDs[key]; ok {
+		return id
+	}
+	for _, s := range b.sections {
+		if s.ProjectID == projectID && strings.EqualFold(s.Name, name) {
+			b.sectionIDs[key] = s.ID
+			return s.ID
+		}
+	}
+
+	cmd := api.AddSectionCommand(name, projectID)
+	b.add(cmd, owner{what: fmt.Sprintf("section %q", name)})
+	if projectName == "" {
+		projectName = "Inbox"
+	}
+	b.NewSections = append(b.NewSections, projectName+" / "+name)
+	b.sectionIDs[key] = cmd.TempID
+	return cmd.TempID
+}
+
+// TaskCount returns the number of tasks the batch creates.
+func (b *Batch) TaskCount() int {
+	n := 0
+	for _, o := range b.owners {
+		if o.what == "task" {
+			n++
+		}
+	}
+	return n
+}
+
+// Preview writes a human-readable description of what the batch creates.
+func (b *Batch) Preview(w io.Writer) {
+	for _, p := range b.NewProjects {
+		fmt.Fprintf(w, "New project: %s\n", p)
+	}
+	for _, s := range b.NewSections {
+		fmt.Fprintf(w, "New section: %s\n", s)
+	}
+
+	skipped := make(map[int]bool)
+	for _, e := range b.Errors {
+		skipped[e.Row] = true
+	}
+
+	lastDest := "\x00"
+	for i, item := range b.Plan.Items {
+		if skipped[item.Row] {
+			continue
+		}
+		if item.Parent < 0 {
+			dest := item.Project
+			if dest == "" {
+				dest = "(default project)"
+			}
+			if item.Section != "" {
+				dest += " / " + item.Section
+			}
+			if dest != lastDest {
+				fmt.Fprintf(w, "%s:\n", dest)
+				lastDest = dest
+			}
+		}
+
+		indent := strings.Repeat("  ", b.Plan.depth(i)+1)
+		check := " "
+		if item.Completed {
+			check = "x"
+		}
+		fmt.Fprintf(w, "%s[%s] %s%s\n", indent, check, item.Content, previewMeta(item))
+	}
+
+	fmt.Fprintf(w, "%d tasks, %d new projects, %d new sections", b.TaskCount(), len(b.NewProjects), len(b.NewSections))
+	if len(b.Errors) > 0 {
+		fmt.Fprintf(w, ", %d rows skipped", len(b.Errors))
+	}
+	fmt.Fprintln(w)
+}
+
+// previewMeta summarizes an item's attributes for Preview.
+func previewMeta(item Item) string {
+	var meta []string
+	if item.Priority > 1 {
+		meta = append(meta, fmt.Sprintf("p%d", 5-item.Priority))
+	}
+	if item.Due != "" {
+		meta = append(meta, "due "+item.Due)
+	}
+	if item.Deadline != "" {
+		meta = append(meta, "deadline "+item.Deadline)
+	}
+	for _, l := range item.Labels {
+		meta = append(meta, "@"+l)
+	}
+	if len(item.Comments) > 0 {
+		meta = append(meta, fmt.Sprintf("%d comments", len(item.Comments)))
+	}
+	if len(meta) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(meta, ", ") + ")"
+}
+
+// Report is the outcome of executing a batch.
+type Report struct {
+	Created int
+	// Failures lists rows, projects and sections the server rejected, plus
+	// the rows skipped while building the batch.
+	Failures []RowError
+}
+
+// Execute sends the batch in chunks of api.MaxCommandsPerSync. Temp IDs
+// resolved by earlier chunks are substituted into later ones. A request
+// failure stops the import; the commands not yet sent are reported as failed.
+func (b *Batch) Execute(ctx context.Context, client *api.Client) *Report {
+	report := &Report{Failures: append([]RowError(nil), b.Errors...)}
+	failed := make(map[owner]bool)
+	fail := func(o owner, err error) {
+		if failed[o] {
+			return
+		}
+		failed[o] = true
+		if o.row == 0 {
+			err = fmt.Errorf("%s: %w", o.what, err)
+		} else if o.what != "task" {
+			err = fmt.Errorf("%s: %w", o.what, err)
+		}
+		report.Failures = append(report.Failures, RowError{Row: o.row, Err: err})
+	}
+
+	result := client.ExecuteBatched(ctx, b.Commands)
+	for _, cmd := range b.Commands {
+		o := b.owners[cmd.UUID]
+		if err := result.Errors[cmd.UUID]; err != nil {
+			fail(o, err)
+		} else if o.what == "task" {
+			report.Created++
+		}
+	}
+	return report
+}
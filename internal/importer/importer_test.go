@@ -0,0 +1,286 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/export"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	input := `# Work
+
+- [ ] Ship release p1 @deep due:2026-05-01 deadline:2026-05-03
+  Tag and publish
+  the binaries
+  > Blocked on CI
+  - [ ] Write tests
+    - [x] Unit tests
+  - [ ] Update docs
+* Plain bullet
+
+## Next week
+
+- [ ] Plan sprint p3
+`
+	plan, err := ParseMarkdown(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Items) != 6 {
+		t.Fatalf("expected 6 items, got %d: %+v", len(plan.Items), plan.Items)
+	}
+
+	ship := plan.Items[0]
+	if ship.Content != "Ship release" || ship.Priority != 4 || ship.Due != "2026-05-01" || ship.Deadline != "2026-05-03" {
+		t.Errorf("unexpected item: %+v", ship)
+	}
+	if ship.Description != "Tag and publish\nthe binaries" || len(ship.Comments) != 1 || ship.Labels[0] != "deep" {
+		t.Errorf("unexpected description/comments/labels: %+v", ship)
+	}
+
+	wantParents := []int{-1, 0, 1, 0, -1, -1}
+	for i, want := range wantParents {
+		if got := plan.Items[i].Parent; got != want {
+			t.Errorf("item %d (%s): parent = %d, want %d", i, plan.Items[i].Content, got, want)
+		}
+	}
+	if !plan.Items[2].Completed {
+		t.Error("expected [x] item to be completed")
+	}
+	if last := plan.Items[5]; last.Project != "Work" || last.Section != "Next week" || last.Priority != 2 {
+		t.Errorf("unexpected last item: %+v", last)
+	}
+}
+
+func TestParseTodoTxt(t *testing.T) {
+	input := `(A) 2026-04-01 Ship release +Work @deep_work due:2026-05-01 id:t1
+Write tests +Work parent:t1
+x 2026-04-03 2026-04-01 Fix bug +Home_Office section:Next_week ref:ABC-12
+
+(D) Low priority thing
+`
+	plan, err := ParseTodoTxt(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Items) != 4 {
+		t.Fatalf("expected 4 items, got %d", len(plan.Items))
+	}
+
+	if it := plan.Items[0]; it.Content != "Ship release" || it.Priority != 4 || it.Project != "Work" || it.Labels[0] != "deep_work" || it.Due != "2026-05-01" {
+		t.Errorf("unexpected first item: %+v", it)
+	}
+	if plan.Items[1].Parent != 0 {
+		t.Errorf("expected subtask of item 0, got parent %d", plan.Items[1].Parent)
+	}
+	if it := plan.Items[2]; !it.Completed || it.Project != "Home Office" || it.Section != "Next week" || it.Content != "Fix bug ref:ABC-12" {
+		t.Errorf("unexpected completed item: %+v", it)
+	}
+	if it := plan.Items[3]; it.Priority != 0 || it.Row != 5 {
+		t.Errorf("expected (D) to keep default priority on row 5, got %+v", it)
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	input := "Title,Prio,When,Tags,id,parent_id\n" +
+		"Ship release,1,tomorrow,\"a,b\",r1,\n" +
+		"Write tests,,,,r2,r1\n" +
+		",2,,,r3,\n" +
+		"Bad priority,9,,,r4,\n" +
+		"Orphan,,,,r5,missing\n"
+
+	mapping, err := ParseMapping("content=Title, priority=Prio, due=When, labels=Tags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := ParseCSV(strings.NewReader(input), mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(plan.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(plan.Items))
+	}
+	if it := plan.Items[0]; it.Priority != 4 || it.Due != "tomorrow" || len(it.Labels) != 2 {
+		t.Errorf("unexpected item: %+v", it)
+	}
+	if plan.Items[1].Parent != 0 {
+		t.Errorf("expected row 3 to be a subtask of row 2")
+	}
+
+	var rows []int
+	for _, e := range plan.Errors {
+		rows = append(rows, e.Row)
+	}
+	if fmt.Sprint(rows) != "[4 5 6]" {
+		t.Errorf("expected errors on rows 4, 5 and 6, got %v", plan.Errors)
+	}
+
+	if _, err := ParseCSV(strings.NewReader("Name\nx\n"), nil); err == nil {
+		t.Error("expected error without a content column")
+	}
+	if _, err := ParseMapping("colour=Name"); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestBuild(t *testing.T) {
+	plan, err := ParseMarkdown(strings.NewReader("# Garden\n\n## Spring\n\n- [x] Dig beds\n  > Use the fork\n  - [ ] Buy seeds\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	projects := []api.Project{{ID: "inbox", Name: "Inbox", InboxProject: true}}
+
+	b := Build(plan, projects, nil, "")
+	var types []string
+	for _, cmd := range b.Commands {
+		types = append(types, cmd.Type)
+	}
+	want := "project_add section_add item_add note_add item_close item_add"
+	if got := strings.Join(types, " "); got != want {
+		t.Fatalf("commands = %s, want %s", got, want)
+	}
+
+	project, section, parent := b.Commands[0], b.Commands[1], b.Commands[2]
+	sectionArgs := section.Args.(map[string]interface{})
+	if sectionArgs["project_id"] != project.TempID {
+		t.Error("section should reference the new project's temp ID")
+	}
+	parentArgs := parent.Args.(map[string]interface{})
+	if parentArgs["project_id"] != project.TempID || parentArgs["section_id"] != section.TempID {
+		t.Errorf("task should be placed in the new project and section: %v", parentArgs)
+	}
+	childArgs := b.Commands[5].Args.(map[string]interface{})
+	if childArgs["parent_id"] != parent.TempID || childArgs["project_id"] != nil {
+		t.Errorf("subtask should only reference its parent: %v", childArgs)
+	}
+	if b.TaskCount() != 2 || len(b.NewProjects) != 1 || len(b.NewSections) != 1 {
+		t.Errorf("unexpected counts: %d tasks, %v, %v", b.TaskCount(), b.NewProjects, b.NewSections)
+	}
+}
+
+// fakeSync is a /sync endpoint that rejects tasks whose content starts with "fail".
+type fakeSync struct {
+	requests int
+	known    map[string]bool // Real IDs handed out so far
+}
+
+func (f *fakeSync) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests++
+	r.ParseForm()
+	var cmds []api.SyncCommand
+	json.Unmarshal([]byte(r.Form.Get("commands")), &cmds)
+
+	mapping := map[string]string{}
+	status := map[string]interface{}{}
+	for i, cmd := range cmds {
+		args := cmd.Args.(map[string]interface{})
+		// Parents from earlier requests must arrive as real IDs
+		if parent, ok := args["parent_id"].(string); ok && !f.known[parent] && mapping[parent] == "" {
+			status[cmd.UUID] = map[string]string{"error": "parent not found"}
+			continue
+		}
+		if content, _ := args["content"].(string); strings.HasPrefix(content, "fail") {
+			status[cmd.UUID] = map[string]string{"error": "invalid content"}
+			continue
+		}
+		if cmd.TempID != "" {
+			id := fmt.Sprintf("real-%d-%d", f.requests, i)
+			mapping[cmd.TempID] = id
+			f.known[id] = true
+		}
+		status[cmd.UUID] = "ok"
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"temp_id_mapping": mapping, "sync_status": status})
+}
+
+func TestExecute(t *testing.T) {
+	// The subtask lands in the second chunk and must reference its parent's real ID
+	var md strings.Builder
+	for i := 0; i < api.MaxCommandsPerSync-1; i++ {
+		fmt.Fprintf(&md, "- [ ] Task %d\n", i)
+	}
+	md.WriteString("- [ ] Parent\n  - [ ] Child\n- [ ] fail here\n")
+
+	plan, err := ParseMarkdown(strings.NewReader(md.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := &fakeSync{known: map[string]bool{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client := api.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+
+	report := Build(plan, nil, nil, "").Execute(context.Background(), client)
+	if fake.requests != 2 {
+		t.Errorf("expected 2 sync requests, got %d", fake.requests)
+	}
+	if report.Created != api.MaxCommandsPerSync+1 {
+		t.Errorf("created = %d, want %d", report.Created, api.MaxCommandsPerSync+1)
+	}
+	if len(report.Failures) != 1 || report.Failures[0].Row != api.MaxCommandsPerSync+2 {
+		t.Errorf("expected one failure on the last row, got %v", report.Failures)
+	}
+}
+
+func TestExecute_RequestFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+	}))
+	defer server.Close()
+	client := api.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+
+	plan, _ := ParseMarkdown(strings.NewReader("- [ ] One\n- [ ] Two\n"))
+	report := Build(plan, nil, nil, "").Execute(context.Background(), client)
+	if report.Created != 0 || len(report.Failures) != 2 {
+		t.Fatalf("expected both rows to fail, got %+v", report)
+	}
+	var apiErr *api.APIError
+	if !errors.As(report.Failures[0].Err, &apiErr) {
+		t.Errorf("expected the API error to be kept, got %v", report.Failures[0].Err)
+	}
+}
+
+func TestMarkdownExportRoundTrip(t *testing.T) {
+	parent := "t1"
+	data := &export.Data{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Ship release", ProjectID: "p1", Priority: 4, Labels: []string{"deep"},
+				Description: "Tag it", Due: &api.Due{Date: "2026-05-01"}, NoteCount: 1},
+			{ID: "t2", Content: "Write tests", ProjectID: "p1", ParentID: &parent, Priority: 1},
+		},
+		Comments: map[string][]api.Comment{"t1": {{Content: "Blocked on CI"}}},
+	}
+	var buf strings.Builder
+	if err := export.Write(&buf, export.FormatMarkdown, data); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := ParseMarkdown(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Items) != 2 {
+		t.Fatalf("expected 2 items, got %+v", plan.Items)
+	}
+	got := plan.Items[0]
+	if got.Content != "Ship release" || got.Project != "Work" || got.Priority != 4 || got.Due != "2026-05-01" ||
+		got.Description != "Tag it" || len(got.Comments) != 1 || got.Labels[0] != "deep" {
+		t.Errorf("round trip lost data: %+v", got)
+	}
+	if plan.Items[1].Parent != 0 {
+		t.Error("round trip lost the subtask")
+	}
+}
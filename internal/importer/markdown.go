@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// listItemRe matches a list item with an optional checkbox:
+// "- [ ] text", "* [x] text" or "- text".
+var listItemRe = regexp.MustCompile(`^([-*+])\s+(?:\[([ xX])\]\s+)?(.*)$`)
+
+// ParseMarkdown reads checklists. Indentation nests items under the item
+// above them, "# Heading" selects the project and "## Heading" the section.
+// Indented text below an item becomes its description and "> quotes" its
+// comments, matching the Markdown export.
+func ParseMarkdown(r io.Reader) (*Plan, error) {
+	plan := &Plan{}
+
+	type open struct {
+		indent int
+		index  int
+	}
+	var stack []open
+	project, section := "", ""
+
+	scanner := bufio.NewScanner(r)
+	row := 0
+	for scanner.Scan() {
+		row++
+		raw := strings.ReplaceAll(scanner.Text(), "\t", "    ")
+		trimmed := strings.TrimSpace(raw)
+		indent := len(raw) - len(strings.TrimLeft(raw, " "))
+		if trimmed == "" {
+			continue
+		}
+
+		// Headings reset nesting
+		if indent == 0 && strings.HasPrefix(trimmed, "#") {
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			title := strings.TrimSpace(trimmed[level:])
+			switch level {
+			case 1:
+				project, section = title, ""
+			default:
+				section = title
+			}
+			stack = stack[:0]
+			continue
+		}
+
+		// Only items indented less than this line can contain it
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		m := listItemRe.FindStringSubmatch(trimmed)
+		if m == nil {
+			// Text under an item is its description or a comment
+			if len(stack) == 0 {
+				continue
+			}
+			item := &plan.Items[stack[len(stack)-1].index]
+			if strings.HasPrefix(trimmed, ">") {
+				item.Comments = append(item.Comments, strings.TrimSpace(strings.TrimPrefix(trimmed, ">")))
+			} else if item.Description == "" {
+				item.Description = trimmed
+			} else {
+				item.Description += "\n" + trimmed
+			}
+			continue
+		}
+
+		item := Item{
+			Row:       row,
+			Project:   project,
+			Section:   section,
+			Completed: m[2] == "x" || m[2] == "X",
+			Parent:    -1,
+		}
+		item.Content = strings.Join(parseMeta(m[3], &item, true), " ")
+		if item.Content == "" {
+			plan.Errors = append(plan.Errors, RowError{Row: row, Err: fmt.Errorf("empty task")})
+			continue
+		}
+		if len(stack) > 0 {
+			item.Parent = stack[len(stack)-1].index
+		}
+
+		plan.Items = append(plan.Items, item)
+		stack = append(stack, open{indent: indent, index: len(plan.Items) - 1})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Markdown: %w", err)
+	}
+	return plan, nil
+}
@@ -0,0 +1,112 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	todoDateRe     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	todoPriorityRe = regexp.MustCompile(`^\(([A-Z])\)$`)
+)
+
+// ParseTodoTxt reads one task per line in the todo.txt format. Priorities
+// (A)-(C) map to p1-p3, the first +project selects the project and
+// @contexts become labels. The section:, due:, deadline:, id: and parent:
+// tags written by the exporter are understood as well.
+func ParseTodoTxt(r io.Reader) (*Plan, error) {
+	plan := &Plan{}
+	byID := make(map[string]int)
+
+	type pending struct {
+		index  int
+		parent string
+	}
+	var children []pending
+
+	scanner := bufio.NewScanner(r)
+	row := 0
+	for scanner.Scan() {
+		row++
+		words := strings.Fields(scanner.Text())
+		if len(words) == 0 {
+			continue
+		}
+
+		item := Item{Row: row, Parent: -1}
+
+		// Completion marker and date
+		if words[0] == "x" {
+			item.Completed = true
+			words = words[1:]
+			if len(words) > 0 && todoDateRe.MatchString(words[0]) {
+				words = words[1:]
+			}
+		}
+		if len(words) > 0 {
+			if m := todoPriorityRe.FindStringSubmatch(words[0]); m != nil {
+				if p := int(m[1][0]-'A') + 1; p <= 3 {
+					item.Priority = 5 - p
+				}
+				words = words[1:]
+			}
+		}
+		// Creation date
+		if len(words) > 0 && todoDateRe.MatchString(words[0]) {
+			words = words[1:]
+		}
+
+		var id, parent string
+		var content []string
+		for _, word := range parseMeta(strings.Join(words, " "), &item, false) {
+			switch {
+			case len(word) > 1 && word[0] == '+':
+				if item.Project == "" {
+					item.Project = fromTodoTxtWord(word[1:])
+				}
+			case strings.HasPrefix(word, "section:"):
+				item.Section = fromTodoTxtWord(strings.TrimPrefix(word, "section:"))
+			case strings.HasPrefix(word, "id:"):
+				id = strings.TrimPrefix(word, "id:")
+			case strings.HasPrefix(word, "parent:"):
+				parent = strings.TrimPrefix(word, "parent:")
+			default:
+				content = append(content, word)
+			}
+		}
+
+		item.Content = strings.Join(content, " ")
+		if item.Content == "" {
+			plan.Errors = append(plan.Errors, RowError{Row: row, Err: fmt.Errorf("empty task")})
+			continue
+		}
+
+		plan.Items = append(plan.Items, item)
+		index := len(plan.Items) - 1
+		if id != "" {
+			byID[id] = index
+		}
+		if parent != "" {
+			children = append(children, pending{index: index, parent: parent})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read todo.txt: %w", err)
+	}
+
+	// Link subtasks; parents must come first so they are created first
+	for _, c := range children {
+		if p, ok := byID[c.parent]; ok && p < c.index {
+			plan.Items[c.index].Parent = p
+		}
+	}
+	return plan, nil
+}
+
+// fromTodoTxtWord undoes the underscore escaping of spaces in todo.txt words.
+func fromTodoTxtWord(word string) string {
+	return strings.ReplaceAll(word, "_", " ")
+}
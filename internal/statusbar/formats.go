@@ -0,0 +1,132 @@
+package statusbar
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+func init() {
+	Register("waybar", static(formatWaybar))
+	Register("polybar", static(formatPolybar))
+	Register("i3blocks", static(formatI3blocks))
+	Register("tmux", static(formatTmux))
+	Register("plain", static(formatPlain))
+	Register("template", newTemplateFormatter)
+}
+
+// static wraps a formatter that takes no options.
+func static(f FormatterFunc) Factory {
+	return func(Options) (Formatter, error) {
+		return f, nil
+	}
+}
+
+// formatWaybar writes a single line of JSON for a Waybar custom module.
+func formatWaybar(w io.Writer, s *Summary) error {
+	type waybarTask struct {
+		Name     string `json:"name"`
+		Date     string `json:"date"`
+		Priority int    `json:"priority"`
+	}
+
+	type waybarOutput struct {
+		// Waybar specific fields
+		Text    string `json:"text"`    // What shows on the bar
+		Tooltip string `json:"tooltip"` // What shows on hover
+		Class   string `json:"class"`   // For CSS styling
+
+		// Data fields
+		Tasks          []waybarTask `json:"tasks"`
+		PriorityCounts map[int]int  `json:"priority_counts"`
+		Total          int          `json:"total"`
+	}
+
+	res := waybarOutput{
+		Text:           fmt.Sprintf("%d", s.Total),
+		Tooltip:        strings.Join(s.Lines(), "\n"),
+		Class:          fmt.Sprintf("p%d", s.MaxPriority),
+		Tasks:          make([]waybarTask, 0, len(s.Tasks)),
+		PriorityCounts: s.PriorityCounts,
+		Total:          s.Total,
+	}
+	for _, item := range s.Tasks {
+		res.Tasks = append(res.Tasks, waybarTask{Name: item.Content, Date: item.Due, Priority: item.Priority})
+	}
+
+	// Single-line JSON is what Waybar's exec expects
+	output, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(output))
+	return err
+}
+
+// formatPolybar writes the count colored with Polybar format tags.
+func formatPolybar(w io.Writer, s *Summary) error {
+	if s.Color == "" {
+		_, err := fmt.Fprintf(w, "%d\n", s.Total)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%%{F%s}%d%%{F-}\n", s.Color, s.Total)
+	return err
+}
+
+// formatI3blocks writes the full_text, short_text and color lines of the
+// i3blocks protocol.
+func formatI3blocks(w io.Writer, s *Summary) error {
+	full := fmt.Sprintf("%d", s.Total)
+	if s.Overdue > 0 {
+		full = fmt.Sprintf("%d (%d overdue)", s.Total, s.Overdue)
+	}
+	_, err := fmt.Fprintf(w, "%s\n%d\n%s\n", full, s.Total, s.Color)
+	return err
+}
+
+// formatTmux writes the count colored with tmux style directives.
+func formatTmux(w io.Writer, s *Summary) error {
+	if s.Color == "" {
+		_, err := fmt.Fprintf(w, "%d\n", s.Total)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "#[fg=%s]%d#[fg=default]\n", s.Color, s.Total)
+	return err
+}
+
+// formatPlain writes the count followed by one line per task, e.g. for Conky.
+func formatPlain(w io.Writer, s *Summary) error {
+	if _, err := fmt.Fprintf(w, "%d\n", s.Total); err != nil {
+		return err
+	}
+	for _, line := range s.Lines() {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateFuncs are available to user templates.
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// newTemplateFormatter renders the summary with the Go template in opts.
+func newTemplateFormatter(opts Options) (Formatter, error) {
+	if opts.Template == "" {
+		return nil, errors.New("the template format requires a template")
+	}
+	tmpl, err := template.New("statusbar").Funcs(templateFuncs).Parse(opts.Template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return FormatterFunc(func(w io.Writer, s *Summary) error {
+		return tmpl.Execute(w, s)
+	}), nil
+}
@@ -0,0 +1,224 @@
+// Package statusbar renders a summary of tasks for status bars such as
+// Waybar, Polybar, i3blocks and tmux.
+package statusbar
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// DefaultFilter is the filter query used when none is configured.
+const DefaultFilter = "today | overdue"
+
+// DefaultFields are the task fields shown in task lines when none are configured.
+var DefaultFields = []string{FieldPriority, FieldContent}
+
+// Task fields that can be shown in task lines.
+const (
+	FieldContent  = "content"
+	FieldDue      = "due"
+	FieldPriority = "priority"
+	FieldProject  = "project"
+	FieldLabels   = "labels"
+)
+
+// Default colors per priority, matching the TUI theme.
+var defaultColors = map[int]string{
+	4: "#D0473D", // P1
+	3: "#EA8811", // P2
+	2: "#296FDF", // P3
+}
+
+// Options configure how a summary is built and rendered.
+type Options struct {
+	// Fields lists the task fields shown in each task line, in order.
+	Fields []string
+	// Template is the Go template used by the "template" format.
+	Template string
+	// Colors overrides the color per API priority (4 is P1).
+	Colors map[int]string
+}
+
+// Item is a single task in a summary.
+type Item struct {
+	ID      string
+	Content string
+	Project string
+	Due     string
+	// Priority uses the API numbering, where 4 is the highest.
+	Priority int
+	Labels   []string
+	Overdue  bool
+	// Line is the task rendered with the configured fields.
+	Line string
+}
+
+// Summary is the data handed to formatters.
+type Summary struct {
+	Tasks []Item
+	Total int
+	// Overdue is the number of tasks due before today.
+	Overdue int
+	// PriorityCounts maps API priorities to the number of tasks.
+	PriorityCounts map[int]int
+	// MaxPriority is the highest API priority among the tasks (1 if none).
+	MaxPriority int
+	// Color is the color of MaxPriority, or empty for normal priority.
+	Color string
+}
+
+// Lines returns the rendered line of every task.
+func (s *Summary) Lines() []string {
+	lines := make([]string, len(s.Tasks))
+	for i, item := range s.Tasks {
+		lines[i] = item.Line
+	}
+	return lines
+}
+
+// Build summarizes tasks. projects maps project IDs to names.
+func Build(tasks []api.Task, projects map[string]string, opts Options, now time.Time) *Summary {
+	fields := opts.Fields
+	if len(fields) == 0 {
+		fields = DefaultFields
+	}
+	today := now.Format("2006-01-02")
+
+	s := &Summary{
+		Tasks:          make([]Item, 0, len(tasks)),
+		Total:          len(tasks),
+		PriorityCounts: make(map[int]int),
+		MaxPriority:    1,
+	}
+
+	for _, t := range tasks {
+		item := Item{
+			ID:       t.ID,
+			Content:  t.Content,
+			Project:  projects[t.ProjectID],
+			Priority: t.Priority,
+			Labels:   t.Labels,
+		}
+		if t.Due != nil {
+			item.Due = t.Due.Date
+			// Dates may carry a time component; compare the day only
+			if len(item.Due) >= 10 && item.Due[:10] < today {
+				item.Overdue = true
+				s.Overdue++
+			}
+		}
+		item.Line = renderLine(item, fields)
+
+		s.Tasks = append(s.Tasks, item)
+		s.PriorityCounts[t.Priority]++
+		if t.Priority > s.MaxPriority {
+			s.MaxPriority = t.Priority
+		}
+	}
+
+	s.Color = defaultColors[s.MaxPriority]
+	if c, ok := opts.Colors[s.MaxPriority]; ok && c != "" {
+		s.Color = c
+	}
+	return s
+}
+
+// renderLine joins the given fields of item with spaces, skipping empty ones.
+// The priority is followed by a colon, as in "P1: Pay rent", which is what
+// the Waybar tooltip has always shown.
+func renderLine(item Item, fields []string) string {
+	var line strings.Builder
+	afterPriority := false
+	for _, f := range fields {
+		var v string
+		switch f {
+		case FieldContent:
+			v = item.Content
+		case FieldDue:
+			v = item.Due
+		case FieldPriority:
+			v = fmt.Sprintf("P%d", 5-item.Priority)
+		case FieldProject:
+			if item.Project != "" {
+				v = "#" + item.Project
+			}
+		case FieldLabels:
+			labels := make([]string, len(item.Labels))
+			for i, l := range item.Labels {
+				labels[i] = "@" + l
+			}
+			v = strings.Join(labels, " ")
+		}
+		if v == "" {
+			continue
+		}
+		switch {
+		case afterPriority:
+			line.WriteString(": ")
+		case line.Len() > 0:
+			line.WriteString(" ")
+		}
+		line.WriteString(v)
+		afterPriority = f == FieldPriority
+	}
+	return line.String()
+}
+
+// ValidateFields reports the first unknown field name.
+func ValidateFields(fields []string) error {
+	for _, f := range fields {
+		switch f {
+		case FieldContent, FieldDue, FieldPriority, FieldProject, FieldLabels:
+		default:
+			return fmt.Errorf("unknown field %q (want content, due, priority, project or labels)", f)
+		}
+	}
+	return nil
+}
+
+// Formatter writes a summary in a status bar's format.
+type Formatter interface {
+	Format(w io.Writer, s *Summary) error
+}
+
+// FormatterFunc adapts a function to the Formatter interface.
+type FormatterFunc func(w io.Writer, s *Summary) error
+
+// Format calls f(w, s).
+func (f FormatterFunc) Format(w io.Writer, s *Summary) error {
+	return f(w, s)
+}
+
+// Factory creates a formatter from options.
+type Factory func(opts Options) (Formatter, error)
+
+var registry = map[string]Factory{}
+
+// Register makes a format available under name, replacing any existing one.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// New returns the formatter registered under name.
+func New(name string, opts Options) (Formatter, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return factory(opts)
+}
+
+// Names returns the registered format names in sorted order.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,117 @@
+package statusbar
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+func testSummary(opts Options) *Summary {
+	tasks := []api.Task{
+		{ID: "1", Content: "Pay rent", ProjectID: "home", Priority: 4, Due: &api.Due{Date: "2026-03-01"}},
+		{ID: "2", Content: "Call mom", ProjectID: "home", Priority: 1, Labels: []string{"phone"}, Due: &api.Due{Date: "2026-03-02T10:00:00"}},
+	}
+	projects := map[string]string{"home": "Home"}
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	return Build(tasks, projects, opts, now)
+}
+
+func TestBuild(t *testing.T) {
+	s := testSummary(Options{Fields: []string{FieldPriority, FieldContent, FieldProject, FieldLabels}})
+
+	if s.Total != 2 || s.Overdue != 1 {
+		t.Errorf("Total=%d Overdue=%d, want 2 and 1", s.Total, s.Overdue)
+	}
+	if s.MaxPriority != 4 || s.Color != "#D0473D" {
+		t.Errorf("MaxPriority=%d Color=%q", s.MaxPriority, s.Color)
+	}
+	if s.PriorityCounts[4] != 1 || s.PriorityCounts[1] != 1 {
+		t.Errorf("unexpected priority counts: %v", s.PriorityCounts)
+	}
+	if got := s.Tasks[1].Line; got != "P4: Call mom #Home @phone" {
+		t.Errorf("line = %q", got)
+	}
+
+	s = testSummary(Options{Colors: map[int]string{4: "#123456"}})
+	if s.Color != "#123456" {
+		t.Errorf("color override not applied: %q", s.Color)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	tests := []struct {
+		format string
+		opts   Options
+		want   string
+	}{
+		{"polybar", Options{}, "%{F#D0473D}2%{F-}\n"},
+		{"i3blocks", Options{}, "2 (1 overdue)\n2\n#D0473D\n"},
+		{"tmux", Options{}, "#[fg=#D0473D]2#[fg=default]\n"},
+		{"plain", Options{}, "2\nP1: Pay rent\nP4: Call mom\n"},
+		{"template", Options{Template: `{{.Total}}/{{.Overdue}} {{join .Lines ", "}}`}, "2/1 P1: Pay rent, P4: Call mom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			f, err := New(tt.format, tt.opts)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := f.Format(&buf, testSummary(tt.opts)); err != nil {
+				t.Fatalf("Format: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWaybarFormat(t *testing.T) {
+	f, err := New("waybar", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := f.Format(&buf, testSummary(Options{})); err != nil {
+		t.Fatal(err)
+	}
+
+	var out struct {
+		Text    string `json:"text"`
+		Tooltip string `json:"tooltip"`
+		Class   string `json:"class"`
+		Total   int    `json:"total"`
+		Tasks   []struct {
+			Name string `json:"name"`
+		} `json:"tasks"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if out.Text != "2" || out.Class != "p4" || out.Total != 2 || len(out.Tasks) != 2 {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+	if out.Tooltip != "P1: Pay rent\nP4: Call mom" {
+		t.Errorf("tooltip = %q", out.Tooltip)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	if _, err := New("conky-xl", Options{}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if _, err := New("template", Options{}); err == nil {
+		t.Error("expected error for empty template")
+	}
+	if _, err := New("template", Options{Template: "{{.Total"}); err == nil {
+		t.Error("expected error for invalid template")
+	}
+	if err := ValidateFields([]string{"content", "colour"}); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
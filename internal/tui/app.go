@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/cache"
 	"github.com/hy4ri/todoist-tui/internal/config"
 	"github.com/hy4ri/todoist-tui/internal/tui/components"
 	"github.com/hy4ri/todoist-tui/internal/tui/logic"
@@ -29,8 +30,9 @@ type App struct {
 // NewApp creates a new App instance.
 func NewApp(client *api.Client, cfg *config.Config, initialView string) *App {
 	s := &state.State{
-		Client: client,
-		Config: cfg,
+		Client:     client,
+		SyncClient: api.NewSyncClient(client),
+		Config:     cfg,
 
 		SearchResults: []api.Task{},
 		SelectionState: state.SelectionState{
@@ -39,6 +41,12 @@ func NewApp(client *api.Client, cfg *config.Config, initialView string) *App {
 		NotifiedTasks: make(map[string]bool),
 	}
 
+	// The on-disk cache is optional; without a data directory we always start from the network
+	if dataDir, err := config.DataDir(); err == nil {
+		s.Cache = cache.NewStore(dataDir)
+		s.Outbox = cache.NewOutbox(dataDir)
+	}
+
 	// Initialize UI components
 	s.SidebarComp = components.NewSidebar()
 	s.FilterSidebarComp = components.NewSidebar()
@@ -103,8 +111,8 @@ func NewApp(client *api.Client, cfg *config.Config, initialView string) *App {
 	}
 
 	// Default from config first
-	if cfg.UI.DefaultView != "" {
-		setView(cfg.UI.DefaultView)
+	if defaultView := cfg.DefaultViewFor(config.CurrentProfile()); defaultView != "" {
+		setView(defaultView)
 	}
 
 	// CLI argument overrides config
@@ -2,11 +2,14 @@ package components
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hy4ri/todoist-tui/internal/api"
 	"github.com/hy4ri/todoist-tui/internal/tui/styles"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
 )
 
 // DetailModel displays task details in a side panel or full view.
@@ -19,8 +22,21 @@ type DetailModel struct {
 	showPanel     bool
 	focused       bool
 	CommentCursor int
+
+	// collaborators resolve comment authors by project; userID is the
+	// current user, whose comments and reactions are shown as theirs.
+	collaborators map[string][]api.Collaborator
+	userID        string
+
+	// reacting is set while the reaction picker of the comment under the
+	// cursor is open.
+	reacting       bool
+	reactionCursor int
 }
 
+// reactionEmojis are the reactions offered by the picker.
+var reactionEmojis = []string{"👍", "❤️", "😄", "🎉", "👀", "🙏"}
+
 // NewDetail creates a new DetailModel.
 func NewDetail() *DetailModel {
 	return &DetailModel{
@@ -41,6 +57,9 @@ func (d *DetailModel) Init() tea.Cmd {
 func (d *DetailModel) Update(msg tea.Msg) (Component, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if d.reacting {
+			return d, d.updateReactionPicker(msg)
+		}
 		switch msg.String() {
 		case "esc":
 			d.showPanel = false
@@ -73,11 +92,45 @@ func (d *DetailModel) Update(msg tea.Msg) (Component, tea.Cmd) {
 					return DeleteCommentMsg{CommentID: c.ID}
 				}
 			}
+		case "+":
+			if d.focused && len(d.comments) > 0 {
+				d.reacting = true
+				d.reactionCursor = 0
+			}
 		}
 	}
 	return d, nil
 }
 
+// updateReactionPicker handles keys while the reaction picker is open.
+func (d *DetailModel) updateReactionPicker(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		d.reacting = false
+	case "h", "left":
+		if d.reactionCursor > 0 {
+			d.reactionCursor--
+		}
+	case "l", "right":
+		if d.reactionCursor < len(reactionEmojis)-1 {
+			d.reactionCursor++
+		}
+	case "enter", " ":
+		d.reacting = false
+		if d.CommentCursor >= len(d.comments) {
+			return nil
+		}
+		msg := ToggleReactionMsg{CommentID: d.comments[d.CommentCursor].ID, Emoji: reactionEmojis[d.reactionCursor]}
+		return func() tea.Msg { return msg }
+	}
+	return nil
+}
+
+// Reacting reports whether the reaction picker is open.
+func (d *DetailModel) Reacting() bool {
+	return d.reacting
+}
+
 // View implements Component.
 func (d *DetailModel) View() string {
 	if d.task == nil {
@@ -153,8 +206,19 @@ func (d *DetailModel) ViewPanel() string {
 		if commentWidth < 10 {
 			commentWidth = 10
 		}
-		for _, c := range d.comments {
-			content.WriteString("• " + styles.CommentContent.Width(commentWidth).Render(c.Content) + "\n")
+		for i, c := range d.comments {
+			bullet := "• "
+			if author := d.authorName(c.PostedUID); author != "" {
+				content.WriteString(bullet + styles.CommentAuthor.Render(author) + "\n")
+				bullet = "  "
+			}
+			content.WriteString(bullet + styles.CommentContent.Width(commentWidth).Render(c.Content) + "\n")
+			if reactions := d.renderReactions(c); reactions != "" {
+				content.WriteString("  " + reactions + "\n")
+			}
+			if d.reacting && i == d.CommentCursor {
+				content.WriteString("  " + d.renderReactionPicker() + "\n")
+			}
 		}
 	}
 
@@ -222,11 +286,14 @@ func (d *DetailModel) renderPanel() string {
 		}
 		b.WriteString(styles.DetailIcon.Render("  " + dueIcon))
 		b.WriteString(styles.DetailLabel.Render("Due"))
-		b.WriteString(dueStyle.Render(t.Due.String))
 		if t.Due.IsRecurring {
-			b.WriteString(styles.HelpDesc.Render(" (recurring)"))
+			b.WriteString(dueStyle.Render(t.DueDisplay()))
+			b.WriteString("\n")
+			b.WriteString(renderOccurrences(t))
+		} else {
+			b.WriteString(dueStyle.Render(t.Due.String))
+			b.WriteString("\n")
 		}
-		b.WriteString("\n")
 	}
 
 	// Reminders
@@ -315,8 +382,12 @@ func (d *DetailModel) renderPanel() string {
 				cursor = "> "
 			}
 
-			// Parse and format timestamp
-			b.WriteString(styles.CommentAuthor.Render(fmt.Sprintf("%s  %s", cursor, c.PostedAt)))
+			// Author and timestamp
+			header := c.PostedAt
+			if author := d.authorName(c.PostedUID); author != "" {
+				header = author + " · " + c.PostedAt
+			}
+			b.WriteString(styles.CommentAuthor.Render(fmt.Sprintf("%s  %s", cursor, header)))
 			b.WriteString("\n")
 
 			// Apply wrapping to comment content
@@ -332,6 +403,13 @@ func (d *DetailModel) renderPanel() string {
 				b.WriteString(styles.CommentContent.Render(fmt.Sprintf("    %s", link)))
 				b.WriteString("\n")
 			}
+
+			if reactions := d.renderReactions(c); reactions != "" {
+				b.WriteString("    " + reactions + "\n")
+			}
+			if d.reacting && i == d.CommentCursor {
+				b.WriteString("    " + d.renderReactionPicker() + "\n")
+			}
 			b.WriteString("\n")
 		}
 	}
@@ -346,12 +424,18 @@ func (d *DetailModel) renderPanel() string {
 	b.WriteString(styles.HelpDesc.Render(" back  "))
 	b.WriteString(styles.HelpKey.Render("x"))
 	b.WriteString(styles.HelpDesc.Render(" complete  "))
+	if t.Due != nil && t.Due.IsRecurring {
+		b.WriteString(styles.HelpKey.Render("X"))
+		b.WriteString(styles.HelpDesc.Render(" complete forever  "))
+	}
 	b.WriteString(styles.HelpKey.Render("j/k"))
 	b.WriteString(styles.HelpDesc.Render(" nav comments  "))
 	b.WriteString(styles.HelpKey.Render("e"))
 	b.WriteString(styles.HelpDesc.Render(" edit  "))
 	b.WriteString(styles.HelpKey.Render("d"))
 	b.WriteString(styles.HelpDesc.Render(" delete  "))
+	b.WriteString(styles.HelpKey.Render("+"))
+	b.WriteString(styles.HelpDesc.Render(" react  "))
 	b.WriteString(styles.HelpKey.Render("s"))
 	b.WriteString(styles.HelpDesc.Render(" add subtask  "))
 	b.WriteString(styles.HelpKey.Render("C"))
@@ -362,6 +446,100 @@ func (d *DetailModel) renderPanel() string {
 	return styles.Dialog.Width(d.width - 4).Render(b.String())
 }
 
+// authorName returns the name of the user who posted a comment, "You" for
+// the current user, or "" if they aren't a known collaborator.
+func (d *DetailModel) authorName(uid string) string {
+	if uid == "" {
+		return ""
+	}
+	if uid == d.userID {
+		return "You"
+	}
+	projectID := ""
+	if d.task != nil {
+		projectID = d.task.ProjectID
+	}
+	if c, ok := utils.FindCollaborator(d.collaborators, projectID, uid); ok {
+		return c.Name
+	}
+	return ""
+}
+
+// renderReactions renders the reactions of a comment with their counts,
+// highlighting the ones of the current user.
+func (d *DetailModel) renderReactions(c api.Comment) string {
+	emojis := make([]string, 0, len(c.Reactions))
+	for emoji, uids := range c.Reactions {
+		if len(uids) > 0 {
+			emojis = append(emojis, emoji)
+		}
+	}
+	slices.Sort(emojis)
+
+	parts := make([]string, 0, len(emojis))
+	for _, emoji := range emojis {
+		uids := c.Reactions[emoji]
+		style := styles.CommentReaction
+		if d.userID != "" && slices.Contains(uids, d.userID) {
+			style = styles.CommentReactionOwn
+		}
+		parts = append(parts, style.Render(fmt.Sprintf("%s %d", emoji, len(uids))))
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderReactionPicker renders the reactions offered for the comment under
+// the cursor.
+func (d *DetailModel) renderReactionPicker() string {
+	parts := make([]string, len(reactionEmojis))
+	for i, emoji := range reactionEmojis {
+		if i == d.reactionCursor {
+			parts[i] = styles.CommentReactionOwn.Render("[" + emoji + "]")
+		} else {
+			parts[i] = " " + emoji + " "
+		}
+	}
+	return strings.Join(parts, "") + styles.HelpDesc.Render("  h/l: choose • Enter: toggle • Esc: cancel")
+}
+
+// recurrencePreviewCount is the number of upcoming occurrences previewed
+// for recurring tasks.
+const recurrencePreviewCount = 5
+
+// renderOccurrences previews the next occurrences of a recurring task.
+func renderOccurrences(t *api.Task) string {
+	var b strings.Builder
+	b.WriteString(styles.DetailIcon.Render("  ↻"))
+
+	r, ok := utils.ParseRecurrence(t.Due.String)
+	if !ok {
+		b.WriteString(styles.DetailLabel.Render("Next"))
+		b.WriteString(styles.HelpDesc.Render("can't preview this rule"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	// Rules counted from completion start over on the day it's done
+	from := time.Now()
+	label := "Next"
+	if r.AfterCompletion {
+		label = "If done today"
+	} else if len(t.Due.Date) >= 10 {
+		if current, err := time.ParseInLocation(utils.DateLayout, t.Due.Date[:10], time.Local); err == nil {
+			from = current
+		}
+	}
+
+	var dates []string
+	for _, d := range r.Occurrences(from, recurrencePreviewCount) {
+		dates = append(dates, d.Format("Mon Jan 2"))
+	}
+	b.WriteString(styles.DetailLabel.Render(label))
+	b.WriteString(styles.TaskRecurring.Render(strings.Join(dates, ", ")))
+	b.WriteString("\n")
+	return b.String()
+}
+
 // SetSize implements Component.
 func (d *DetailModel) SetSize(width, height int) {
 	d.width = width
@@ -377,6 +555,9 @@ func (d *DetailModel) SetTask(task *api.Task) {
 // SetComments sets the comments for the task.
 func (d *DetailModel) SetComments(comments []api.Comment) {
 	d.comments = comments
+	if len(d.comments) == 0 {
+		d.reacting = false
+	}
 	if d.CommentCursor >= len(d.comments) {
 		d.CommentCursor = len(d.comments) - 1
 		if d.CommentCursor < 0 {
@@ -385,6 +566,13 @@ func (d *DetailModel) SetComments(comments []api.Comment) {
 	}
 }
 
+// SetCollaborators sets the collaborators of each project, used to name
+// comment authors, and the ID of the current user.
+func (d *DetailModel) SetCollaborators(byProject map[string][]api.Collaborator, userID string) {
+	d.collaborators = byProject
+	d.userID = userID
+}
+
 // SetReminders sets the reminders for the task.
 func (d *DetailModel) SetReminders(reminders []api.Reminder) {
 	d.reminders = reminders
@@ -408,6 +596,7 @@ func (d *DetailModel) ShowPanel() bool {
 // Hide hides the detail panel.
 func (d *DetailModel) Hide() {
 	d.showPanel = false
+	d.reacting = false
 	d.task = nil
 	d.comments = nil
 	d.reminders = nil
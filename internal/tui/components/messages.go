@@ -40,3 +40,10 @@ type EditCommentMsg struct {
 type DeleteCommentMsg struct {
 	CommentID string
 }
+
+// ToggleReactionMsg is emitted to add or remove the current user's reaction
+// to a comment.
+type ToggleReactionMsg struct {
+	CommentID string
+	Emoji     string
+}
@@ -56,12 +56,6 @@ type SidebarItem struct {
 	Color      string
 }
 
-// LastAction represents an undoable action.
-type LastAction struct {
-	Type   string // "complete", "uncomplete"
-	TaskID string
-}
-
 // lineInfo represents a display line with optional task reference.
 type LineInfo struct {
 	Content   string
@@ -0,0 +1,320 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
+)
+
+// collaboratorsLoadedMsg delivers the collaborators of shared projects.
+type collaboratorsLoadedMsg struct {
+	requested []string // Projects asked for, loaded or not
+	byProject map[string][]api.Collaborator
+	err       error
+	assign    *pendingAssign // :assign to apply once loaded
+}
+
+// pendingAssign is an :assign waiting for collaborators to load. It keeps
+// the tasks it was given, since the cursor and selection may move meanwhile.
+type pendingAssign struct {
+	who     string
+	taskIDs []string
+}
+
+// loadCollaborators fetches the collaborators of the given projects.
+func (h *Handler) loadCollaborators(projectIDs []string, assign *pendingAssign) tea.Cmd {
+	client := h.Client
+	return h.accountLoad(func(ctx context.Context) tea.Msg {
+		byProject, err := client.GetCollaboratorsForProjects(ctx, projectIDs)
+		return collaboratorsLoadedMsg{requested: projectIDs, byProject: byProject, err: err, assign: assign}
+	})
+}
+
+// missingCollaborators returns the shared projects among projectIDs whose
+// collaborators haven't been requested yet, marking them as requested.
+func (h *Handler) missingCollaborators(projectIDs ...string) []string {
+	if h.Collaborators == nil {
+		h.Collaborators = make(map[string][]api.Collaborator)
+	}
+	var missing []string
+	for _, id := range projectIDs {
+		if _, ok := h.Collaborators[id]; ok {
+			continue
+		}
+		if p := h.findProject(id); p == nil || !p.Shared {
+			continue
+		}
+		h.Collaborators[id] = nil
+		missing = append(missing, id)
+	}
+	return missing
+}
+
+// loadSharedCollaborators fetches the collaborators of the shared projects
+// that aren't cached yet.
+func (h *Handler) loadSharedCollaborators() tea.Cmd {
+	ids := make([]string, 0, len(h.Projects))
+	for _, p := range h.Projects {
+		ids = append(ids, p.ID)
+	}
+	missing := h.missingCollaborators(ids...)
+	if len(missing) == 0 {
+		return nil
+	}
+	return h.loadCollaborators(missing, nil)
+}
+
+// loadFormCollaborators hands the collaborator cache to the task form and
+// fetches the collaborators of its project if they are missing.
+func (h *Handler) loadFormCollaborators() tea.Cmd {
+	if h.TaskForm == nil {
+		return nil
+	}
+	missing := h.missingCollaborators(h.TaskForm.ProjectID)
+	h.TaskForm.Collaborators = h.Collaborators
+	if len(missing) == 0 {
+		return nil
+	}
+	return h.loadCollaborators(missing, nil)
+}
+
+// loadProjectCollaborators fetches the collaborators of a shared project if
+// they are missing.
+func (h *Handler) loadProjectCollaborators(projectID string) tea.Cmd {
+	missing := h.missingCollaborators(projectID)
+	if len(missing) == 0 {
+		return nil
+	}
+	return h.loadCollaborators(missing, nil)
+}
+
+func (h *Handler) handleCollaboratorsLoaded(msg collaboratorsLoadedMsg) tea.Cmd {
+	if h.Collaborators == nil {
+		h.Collaborators = make(map[string][]api.Collaborator)
+	}
+	for id, collaborators := range msg.byProject {
+		h.Collaborators[id] = collaborators
+	}
+	if msg.err != nil {
+		// Forget the projects of this request that failed so they are asked
+		// for again; other loads may still be filling in theirs
+		for _, id := range msg.requested {
+			if _, ok := msg.byProject[id]; !ok {
+				delete(h.Collaborators, id)
+			}
+		}
+		if msg.assign != nil {
+			h.StatusMsg = "Failed to load collaborators: " + utils.ErrorMessage(msg.err)
+		}
+		return nil
+	}
+	if msg.assign != nil {
+		var targets []api.Task
+		for _, id := range msg.assign.taskIDs {
+			if t := h.findTask(id); t != nil {
+				targets = append(targets, *t)
+			}
+		}
+		if len(targets) == 0 {
+			h.StatusMsg = "The tasks to assign are gone"
+			return nil
+		}
+		return h.assignTasks(targets, msg.assign.who)
+	}
+	return nil
+}
+
+// allCollaborators returns the cached collaborators of all projects, each
+// user once.
+func (h *Handler) allCollaborators() []api.Collaborator {
+	seen := make(map[string]bool)
+	var all []api.Collaborator
+	for _, collaborators := range h.Collaborators {
+		for _, c := range collaborators {
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				all = append(all, c)
+			}
+		}
+	}
+	return all
+}
+
+// handleAssignCommand assigns the selected tasks, or the task under the
+// cursor, to a collaborator of their project: <name|email|me|none>.
+func handleAssignCommand(h *Handler, args []string) tea.Cmd {
+	if len(args) == 0 {
+		h.StatusMsg = "Usage: :assign <name|me|none>"
+		return nil
+	}
+	who := strings.Join(args, " ")
+
+	if h.CurrentView == state.ViewLabels && h.CurrentLabel == nil {
+		return nil
+	}
+
+	var targets []api.Task
+	if len(h.SelectedTaskIDs) > 0 {
+		for _, t := range h.Tasks {
+			if h.SelectedTaskIDs[t.ID] {
+				targets = append(targets, t)
+			}
+		}
+	} else if task := h.getSelectedTask(); task != nil {
+		targets = append(targets, *task)
+	}
+	if len(targets) == 0 {
+		h.StatusMsg = "No task selected"
+		return nil
+	}
+	return h.assignTasks(targets, who)
+}
+
+// assignTasks assigns targets to who, a collaborator's name or email, "me"
+// or "none".
+func (h *Handler) assignTasks(targets []api.Task, who string) tea.Cmd {
+	unassign := false
+	uid := ""
+	switch strings.ToLower(who) {
+	case "none", "nobody", "clear":
+		unassign = true
+	case "me":
+		if h.User == nil {
+			h.StatusMsg = "Account not loaded yet"
+			return nil
+		}
+		uid = h.User.ID
+	}
+
+	// Collaborators are needed to resolve names; fetch the missing ones first
+	if !unassign && uid == "" {
+		projectIDs := make([]string, 0, len(targets))
+		taskIDs := make([]string, 0, len(targets))
+		for _, t := range targets {
+			projectIDs = append(projectIDs, t.ProjectID)
+			taskIDs = append(taskIDs, t.ID)
+		}
+		if missing := h.missingCollaborators(projectIDs...); len(missing) > 0 {
+			h.StatusMsg = "Loading collaborators..."
+			return h.loadCollaborators(missing, &pendingAssign{who: who, taskIDs: taskIDs})
+		}
+	}
+
+	// Resolve the assignee within the project of each task
+	assignees := make(map[string]string, len(targets))
+	var assigned []api.Task
+	name := who
+	for _, t := range targets {
+		switch {
+		case unassign:
+			if t.ResponsibleUID == nil {
+				continue
+			}
+			assignees[t.ID] = ""
+		case uid != "":
+			if p := h.findProject(t.ProjectID); p == nil || !p.Shared {
+				continue
+			}
+			assignees[t.ID] = uid
+		default:
+			c, ok := utils.MatchCollaborator(h.Collaborators[t.ProjectID], who)
+			if !ok {
+				continue
+			}
+			assignees[t.ID] = c.ID
+			name = c.Name
+		}
+		assigned = append(assigned, t)
+	}
+	if len(assigned) == 0 {
+		if unassign {
+			h.StatusMsg = "No assigned task selected"
+		} else {
+			h.StatusMsg = fmt.Sprintf("No collaborator %q in the projects of the selected tasks", who)
+		}
+		return nil
+	}
+
+	var undo, redo []api.SyncCommand
+	for _, t := range assigned {
+		old := ""
+		if t.ResponsibleUID != nil {
+			old = *t.ResponsibleUID
+		}
+		next := assignees[t.ID]
+		undo = append(undo, api.UpdateTaskCommand(t.ID, api.UpdateTaskRequest{AssigneeID: &old}))
+		redo = append(redo, api.UpdateTaskCommand(t.ID, api.UpdateTaskRequest{AssigneeID: &next}))
+	}
+
+	verb := "Assign"
+	if unassign {
+		verb = "Unassign"
+	}
+	entry := undoEntry(describeChange(verb, assigned), undo, redo)
+
+	// Optimistic update
+	setAssignee := func(t *api.Task) {
+		next, ok := assignees[t.ID]
+		if !ok {
+			return
+		}
+		t.ResponsibleUID = nil
+		if next != "" {
+			t.ResponsibleUID = &next
+		}
+	}
+	for i := range h.Tasks {
+		setAssignee(&h.Tasks[i])
+	}
+	for i := range h.AllTasks {
+		setAssignee(&h.AllTasks[i])
+	}
+
+	h.clearSelection()
+	h.refilterCurrentView()
+
+	switch {
+	case unassign:
+		h.StatusMsg = describeChange("Unassigned", assigned)
+	case uid != "":
+		h.StatusMsg = describeChange("Assigned", assigned) + " to you"
+	default:
+		h.StatusMsg = describeChange("Assigned", assigned) + " to " + name
+	}
+	if skipped := len(targets) - len(assigned); skipped > 0 {
+		h.StatusMsg += fmt.Sprintf(" (%d skipped)", skipped)
+	}
+
+	cmds := make([]api.SyncCommand, len(assigned))
+	for i, t := range assigned {
+		next := assignees[t.ID]
+		cmds[i] = api.UpdateTaskCommand(t.ID, api.UpdateTaskRequest{AssigneeID: &next})
+	}
+	return h.undoable(entry, h.sendBulk(cmds, taskUpdatedMsg{}))
+}
+
+// handleAssignedCommand opens the tasks assigned to the user, or with
+// "others", those assigned to someone else.
+func handleAssignedCommand(h *Handler, args []string) tea.Cmd {
+	filter := &api.Filter{ID: "assigned-me", Name: "Assigned to me", Query: "assigned to: me"}
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "me":
+		case "others":
+			filter = &api.Filter{ID: "assigned-others", Name: "Assigned to others", Query: "assigned to: others"}
+		default:
+			h.StatusMsg = "Usage: :assigned [me|others]"
+			return nil
+		}
+	}
+
+	if h.CurrentTab != state.TabFilters {
+		h.switchToTab(state.TabFilters)
+	}
+	return h.runFilter(filter)
+}
@@ -0,0 +1,148 @@
+package logic
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+)
+
+func TestAssignCommand(t *testing.T) {
+	alex := api.Collaborator{ID: "2", Name: "Alex Kim", Email: "alex@example.com"}
+	h := newTasksHandler(
+		api.Task{ID: "t1", Content: "Write report", ProjectID: "p1"},
+		api.Task{ID: "t2", Content: "Review PRs", ProjectID: "p1"},
+	)
+	h.Projects[0].Shared = true
+	h.Collaborators = map[string][]api.Collaborator{"p1": {alex}}
+	h.SelectedTaskIDs["t1"] = true
+	h.SelectedTaskIDs["t2"] = true
+
+	if handleAssignCommand(h, []string{"alex"}) == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	for _, tasks := range [][]api.Task{h.Tasks, h.AllTasks} {
+		for _, task := range tasks {
+			if task.ResponsibleUID == nil || *task.ResponsibleUID != "2" {
+				t.Errorf("expected an optimistic assignee on %q, got %v", task.Content, task.ResponsibleUID)
+			}
+		}
+	}
+	if h.StatusMsg != "Assigned 2 tasks to Alex Kim" {
+		t.Errorf("status = %q", h.StatusMsg)
+	}
+
+	h.TaskCursor = 0
+	if handleAssignCommand(h, []string{"none"}) == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	if h.Tasks[0].ResponsibleUID != nil || h.Tasks[1].ResponsibleUID == nil {
+		t.Errorf("expected only the task under the cursor to be unassigned, got %v and %v", h.Tasks[0].ResponsibleUID, h.Tasks[1].ResponsibleUID)
+	}
+	if h.StatusMsg != "Unassigned 'Write report'" {
+		t.Errorf("status = %q", h.StatusMsg)
+	}
+
+	if handleAssignCommand(h, []string{"nobody@example.com"}) != nil {
+		t.Error("expected no command for an unknown collaborator")
+	}
+	if h.StatusMsg != `No collaborator "nobody@example.com" in the projects of the selected tasks` {
+		t.Errorf("status = %q", h.StatusMsg)
+	}
+}
+
+func TestE2E_AssignCommand(t *testing.T) {
+	h, srv := newFakeHandler(t)
+	h.Projects[0].Shared = true
+	srv.AddCollaborator("p1", api.Collaborator{ID: "2", Name: "Alex Kim", Email: "alex@example.com"})
+	first := srv.AddTask(api.Task{Content: "Write report", ProjectID: "p1", ChildOrder: 1})
+	second := srv.AddTask(api.Task{Content: "Review PRs", ProjectID: "p1", ChildOrder: 2})
+
+	loadFakeProject(t, h)
+	h.Collaborators = nil // Not loaded yet
+	h.SelectedTaskIDs[first.ID] = true
+	h.SelectedTaskIDs[second.ID] = true
+
+	// The collaborators are fetched first, then the command is applied
+	cmd := handleAssignCommand(h, []string{"alex"})
+	if cmd == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	// Moving on while they load doesn't change which tasks are assigned
+	h.clearSelection()
+	h.TaskCursor = 1
+	cmd = h.Update(cmd())
+	if cmd == nil {
+		t.Fatalf("expected the assignment after loading, status %q", h.StatusMsg)
+	}
+	cmd()
+	for _, id := range []string{first.ID, second.ID} {
+		if got, _ := srv.Task(id); got.ResponsibleUID == nil || *got.ResponsibleUID != "2" {
+			t.Fatalf("task %s not assigned on the server: %v", id, got.ResponsibleUID)
+		}
+	}
+}
+
+func TestAssignedCommand(t *testing.T) {
+	h := newTasksHandler()
+	h.User = &api.User{ID: "1"}
+	mine, theirs := "1", "2"
+	h.AllTasks = []api.Task{
+		{ID: "a", Content: "Mine", ProjectID: "p1", ResponsibleUID: &mine},
+		{ID: "b", Content: "Theirs", ProjectID: "p1", ResponsibleUID: &theirs},
+		{ID: "c", Content: "Nobody's", ProjectID: "p1"},
+	}
+
+	for _, tt := range []struct {
+		arg  string
+		want string
+	}{
+		{"me", "a"},
+		{"others", "b"},
+	} {
+		msg := handleAssignedCommand(h, []string{tt.arg})()
+		h.Update(msg)
+		if h.CurrentTab != state.TabFilters {
+			t.Fatalf("expected the filters tab, got %v", h.CurrentTab)
+		}
+		if len(h.Tasks) != 1 || h.Tasks[0].ID != tt.want {
+			t.Errorf(":assigned %s gave %+v, want task %s", tt.arg, h.Tasks, tt.want)
+		}
+	}
+}
+
+func TestCollaboratorsLoaded_ErrorForgetsOnlyFailedProjects(t *testing.T) {
+	h := newTasksHandler()
+	// p2 and p3 are still being loaded by another request
+	h.Collaborators = map[string][]api.Collaborator{"p1": nil, "p2": nil, "p3": nil}
+
+	h.Update(collaboratorsLoadedMsg{
+		requested: []string{"p1", "p3"},
+		byProject: map[string][]api.Collaborator{"p3": {{ID: "2", Name: "Alex Kim"}}},
+		err:       errors.New("boom"),
+	})
+	if _, ok := h.Collaborators["p1"]; ok {
+		t.Error("expected the failed project to be forgotten")
+	}
+	if _, ok := h.Collaborators["p2"]; !ok {
+		t.Error("expected the project of the other request to stay pending")
+	}
+	if len(h.Collaborators["p3"]) != 1 {
+		t.Errorf("expected the loaded project to be kept, got %+v", h.Collaborators["p3"])
+	}
+}
+
+func TestFilterPage_LoadsSharedCollaborators(t *testing.T) {
+	h := newTasksHandler()
+	h.Projects[0].Shared = true
+	h.CurrentTab = state.TabFilters
+	h.CurrentFilter = &api.Filter{Name: "Shared"}
+
+	if h.Update(filterPageMsg{filter: h.CurrentFilter, first: true}) == nil {
+		t.Fatal("expected the collaborators of the shared project to be loaded")
+	}
+	if _, ok := h.Collaborators["p1"]; !ok {
+		t.Errorf("expected the shared project to be marked as requested, got %+v", h.Collaborators)
+	}
+}
@@ -57,9 +57,31 @@ func registerCommands() {
 		{
 			Name:        "complete",
 			Aliases:     []string{"c", "done"},
-			Description: "Complete the selected task",
+			Description: "Complete the selected task: [forever]",
 			Handler:     handleCompleteCommand,
 		},
+		{
+			Name:        "deadline",
+			Aliases:     []string{"dl"},
+			Description: "Set the deadline of the selected tasks: <date|none>",
+			Handler:     handleDeadlineCommand,
+		},
+		{
+			Name:        "assign",
+			Aliases:     []string{"as"},
+			Description: "Assign the selected tasks: <name|email|me|none>",
+			Handler:     handleAssignCommand,
+		},
+		{
+			Name:        "assigned",
+			Description: "Show tasks assigned to you or to others: [me|others]",
+			Handler:     handleAssignedCommand,
+		},
+		{
+			Name:        "react",
+			Description: "Toggle a reaction on the selected comment: <emoji>",
+			Handler:     handleReactCommand,
+		},
 		{
 			Name:        "project",
 			Aliases:     []string{"p", "prj"},
@@ -114,6 +136,40 @@ func registerCommands() {
 			Description: "Manage reminders for selected task",
 			Handler:     handleRemindersCommand,
 		},
+		{
+			Name:        "undo",
+			Aliases:     []string{"u"},
+			Description: "Undo the last N changes (default 1)",
+			Handler:     handleUndoCommand,
+		},
+		{
+			Name:        "redo",
+			Aliases:     []string{"red"},
+			Description: "Redo the last N undone changes (default 1)",
+			Handler:     handleRedoCommand,
+		},
+		{
+			Name:        "history",
+			Aliases:     []string{"hist"},
+			Description: "Show the undo/redo history",
+			Handler:     handleHistoryCommand,
+		},
+		{
+			Name:        "export",
+			Description: "Export tasks: <md|csv|json|todo.txt> <path> [view|project|all]",
+			Handler:     handleExportCommand,
+		},
+		{
+			Name:        "import",
+			Description: "Import tasks: <md|csv|todo.txt> <path> [dry]",
+			Handler:     handleImportCommand,
+		},
+		{
+			Name:        "profile",
+			Aliases:     []string{"account"},
+			Description: "Switch to another account: <name>",
+			Handler:     handleProfileCommand,
+		},
 	}
 
 	for _, cmd := range commands {
@@ -160,7 +216,8 @@ func handleAddCommand(h *Handler, args []string) tea.Cmd {
 	// Quick add with arguments
 	content := strings.Join(args, " ")
 	h.StatusMsg = "Adding task..."
-	return func() tea.Msg {
+	ctx, client := h.Context(), h.Client
+	return h.accountChange(func() tea.Msg {
 		// Detect project tag by matching against known projects (longest match wins)
 		var detectedProjectID string
 		var detectedTag string // The full "#ProjectName" substring to strip
@@ -187,20 +244,22 @@ func handleAddCommand(h *Handler, args []string) tea.Cmd {
 			cleanText = strings.Join(strings.Fields(cleanText), " ") // Normalize whitespace
 		}
 
-		task, err := h.Client.QuickAddTask(cleanText)
+		task, err := client.QuickAddTask(ctx, cleanText)
 		if err != nil {
 			return errMsg{err}
 		}
 
 		// Move task to detected project if QuickAddTask put it elsewhere
 		if detectedProjectID != "" && task.ProjectID != detectedProjectID {
-			if err := h.Client.MoveTask(task.ID, nil, &detectedProjectID, nil); err != nil {
+			if err := client.MoveTask(ctx, task.ID, nil, &detectedProjectID, nil); err != nil {
 				return errMsg{err}
 			}
+			task.ProjectID = detectedProjectID
+			task.SectionID = nil
 		}
 
-		return quickAddTaskCreatedMsg{}
-	}
+		return quickAddTaskCreatedMsg{task: task}
+	})
 }
 
 func handleDeleteCommand(h *Handler, args []string) tea.Cmd {
@@ -208,6 +267,10 @@ func handleDeleteCommand(h *Handler, args []string) tea.Cmd {
 }
 
 func handleCompleteCommand(h *Handler, args []string) tea.Cmd {
+	// "forever" stops a recurring task instead of moving it to its next date
+	if len(args) > 0 && strings.EqualFold(args[0], "forever") {
+		return h.handleCompleteForever()
+	}
 	return h.handleComplete()
 }
 
@@ -234,17 +297,26 @@ func handleProjectCommand(h *Handler, args []string) tea.Cmd {
 	}
 
 	if bestMatch != nil {
-		h.CurrentProject = bestMatch
-		h.FocusedPane = state.PaneMain
-		h.Sections = nil
-		// We need to trigger load, switching tab sets view to Project but doesn't load specific project
-		return h.loadProjectTasks(bestMatch.ID)
+		return h.openProject(bestMatch)
 	}
 
 	h.StatusMsg = fmt.Sprintf("Project not found: %s", query)
 	return nil
 }
 
+// openProject shows the tasks of project p in the projects tab.
+func (h *Handler) openProject(p *api.Project) tea.Cmd {
+	if h.CurrentTab != state.TabProjects {
+		h.switchToTab(state.TabProjects)
+	}
+	h.CancelViewLoads()
+	h.CurrentProject = p
+	h.FocusedPane = state.PaneMain
+	h.Sections = nil
+	// We need to trigger load, switching tab sets view to Project but doesn't load specific project
+	return h.loadProjectTasks(p.ID)
+}
+
 func handleLabelCommand(h *Handler, args []string) tea.Cmd {
 	if len(args) == 0 {
 		return h.switchToTab(state.TabLabels)
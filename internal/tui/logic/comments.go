@@ -0,0 +1,91 @@
+package logic
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
+)
+
+// commentCollaborators returns the collaborators of the project of the task
+// being commented on, who can be @mentioned.
+func (h *Handler) commentCollaborators() []api.Collaborator {
+	task := h.SelectedTask
+	if task == nil {
+		task = h.getSelectedTask()
+	}
+	if task == nil {
+		return nil
+	}
+	return h.Collaborators[task.ProjectID]
+}
+
+// completeMention completes the @mention being typed in the comment input
+// with the first collaborator it matches. It reports false if there is
+// nothing to complete.
+func (h *Handler) completeMention() bool {
+	text := h.CommentInput.Value()
+	query, ok := utils.MentionQuery(text)
+	if !ok {
+		return false
+	}
+	matches := utils.MentionSuggestions(h.commentCollaborators(), query)
+	if len(matches) == 0 {
+		return false
+	}
+	h.CommentInput.SetValue(utils.CompleteMention(text, matches[0]))
+	return true
+}
+
+// mentionedUIDs returns the collaborators @mentioned in a comment, other
+// than the current user, to notify of it.
+func (h *Handler) mentionedUIDs(content string) []string {
+	uids := utils.MentionedUIDs(h.commentCollaborators(), content)
+	if h.User != nil {
+		uids = slices.DeleteFunc(uids, func(uid string) bool { return uid == h.User.ID })
+	}
+	return uids
+}
+
+// handleToggleReaction adds the current user's emoji reaction to a comment
+// of the task in the detail view, or removes it if it is already there.
+func (h *Handler) handleToggleReaction(commentID, emoji string) tea.Cmd {
+	if h.User == nil {
+		h.StatusMsg = "Your account is still loading"
+		return nil
+	}
+	i := slices.IndexFunc(h.Comments, func(c api.Comment) bool { return c.ID == commentID })
+	if i < 0 {
+		return nil
+	}
+
+	// Optimistic update; the comment cache shares the slice
+	reactions := utils.ToggleReaction(h.Comments[i].Reactions, emoji, h.User.ID)
+	h.Comments[i].Reactions = reactions
+	if slices.Contains(reactions[emoji], h.User.ID) {
+		h.StatusMsg = "Reacted " + emoji
+	} else {
+		h.StatusMsg = "Removed " + emoji
+	}
+
+	// Nothing to reload on success: the reactions are already shown
+	return h.accountChange(h.sendBulk([]api.SyncCommand{api.UpdateCommentReactionsCommand(commentID, reactions)}, nil))
+}
+
+// handleReactCommand toggles a reaction on the comment under the cursor in
+// the detail view: <emoji>.
+func handleReactCommand(h *Handler, args []string) tea.Cmd {
+	if len(args) == 0 {
+		h.StatusMsg = "Usage: :react <emoji>"
+		return nil
+	}
+	if h.CurrentView != state.ViewTaskDetail || len(h.Comments) == 0 {
+		h.StatusMsg = "No comment selected"
+		return nil
+	}
+	cursor := min(h.DetailComp.CommentCursor, len(h.Comments)-1)
+	return h.handleToggleReaction(h.Comments[cursor].ID, strings.Join(args, " "))
+}
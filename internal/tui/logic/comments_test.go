@@ -0,0 +1,97 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/components"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+)
+
+func TestCommentMentions(t *testing.T) {
+	task := api.Task{ID: "t1", Content: "Write report", ProjectID: "p1"}
+	h := newTasksHandler(task)
+	h.User = &api.User{ID: "1"}
+	h.Collaborators = map[string][]api.Collaborator{"p1": {
+		{ID: "1", Name: "Test User"},
+		{ID: "2", Name: "Alex Kim"},
+	}}
+	h.SelectedTask = &task
+	h.CommentInput = textarea.New()
+	h.CommentInput.SetValue("thanks @al")
+
+	if !h.completeMention() {
+		t.Fatal("expected the mention to be completed")
+	}
+	content := h.CommentInput.Value()
+	if content != "thanks @Alex Kim " {
+		t.Fatalf("completed to %q", content)
+	}
+	// The current user is never notified of their own comment
+	if got := h.mentionedUIDs(content + "cc @Test User"); !reflect.DeepEqual(got, []string{"2"}) {
+		t.Errorf("mentionedUIDs = %v, want [2]", got)
+	}
+	if h.completeMention() {
+		t.Error("expected nothing to complete after a finished mention")
+	}
+}
+
+func TestReactCommand(t *testing.T) {
+	task := api.Task{ID: "t1", Content: "Write report", ProjectID: "p1"}
+	h := newTasksHandler(task)
+	h.User = &api.User{ID: "1"}
+	h.SelectedTask = &task
+	h.CurrentView = state.ViewTaskDetail
+	h.DetailComp = components.NewDetail()
+	h.Comments = []api.Comment{{ID: "c1", ItemID: &task.ID, Content: "Looks good",
+		Reactions: map[string][]string{"👍": {"2"}}}}
+
+	if handleReactCommand(h, []string{"👍"}) == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	if got := h.Comments[0].Reactions["👍"]; !reflect.DeepEqual(got, []string{"2", "1"}) {
+		t.Errorf("expected an optimistic reaction, got %v", got)
+	}
+	if h.StatusMsg != "Reacted 👍" {
+		t.Errorf("status = %q", h.StatusMsg)
+	}
+
+	// Toggling again from the picker removes it
+	if h.Update(components.ToggleReactionMsg{CommentID: "c1", Emoji: "👍"}) == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	if got := h.Comments[0].Reactions["👍"]; !reflect.DeepEqual(got, []string{"2"}) {
+		t.Errorf("expected the reaction to be removed, got %v", got)
+	}
+	if h.StatusMsg != "Removed 👍" {
+		t.Errorf("status = %q", h.StatusMsg)
+	}
+
+	if handleReactCommand(h, nil) != nil || h.StatusMsg != "Usage: :react <emoji>" {
+		t.Errorf("expected the usage without an emoji, status %q", h.StatusMsg)
+	}
+}
+
+func TestE2E_ToggleReaction(t *testing.T) {
+	h, srv := newFakeHandler(t)
+	h.User = &api.User{ID: "1"}
+	task := srv.AddTask(api.Task{Content: "Write report", ProjectID: "p1"})
+	comment := srv.AddComment(api.Comment{ItemID: &task.ID, Content: "Looks good",
+		Reactions: map[string][]string{"👍": {"2"}}})
+
+	h.SelectedTask = &task
+	h.CurrentView = state.ViewTaskDetail
+	h.DetailComp = components.NewDetail()
+	h.Comments = []api.Comment{comment}
+
+	cmd := handleReactCommand(h, []string{"👍"})
+	if cmd == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	cmd()
+	if got := srv.Comments()[0].Reactions["👍"]; !reflect.DeepEqual(got, []string{"2", "1"}) {
+		t.Errorf("server reactions = %v", got)
+	}
+}
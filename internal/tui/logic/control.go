@@ -0,0 +1,125 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/control"
+	"github.com/hy4ri/todoist-tui/internal/tui/components"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+	"github.com/hy4ri/todoist-tui/internal/tui/views"
+)
+
+// errDialogOpen refuses remote requests that need to switch tabs, which
+// can't happen while a dialog is open.
+var errDialogOpen = errors.New("close the open dialog first")
+
+// handleControl executes a request received on the control socket.
+func (h *Handler) handleControl(msg control.Msg) tea.Cmd {
+	req := msg.Request
+
+	switch req.Command {
+	case control.CommandAdd:
+		add := handleAddCommand(h, []string{req.Text})
+		// Reply once the task is created, so a failed add is reported
+		return func() tea.Msg {
+			result := add()
+			reply := result
+			if change, ok := reply.(viewLoadedMsg); ok {
+				reply = change.msg
+			}
+			if e, ok := reply.(errMsg); ok {
+				msg.Reply(e.err)
+			} else {
+				msg.Reply(nil)
+			}
+			return result
+		}
+
+	case control.CommandProject:
+		p := h.findProject(req.Project)
+		if p == nil {
+			msg.Reply(fmt.Errorf("project not found: %s", req.Project))
+			return nil
+		}
+		if h.inModalView() {
+			// Its tasks would replace the view under the dialog
+			msg.Reply(errDialogOpen)
+			return nil
+		}
+		msg.Reply(nil)
+		return h.openProject(p)
+
+	case control.CommandPomodoro:
+		task := h.findTask(req.TaskID)
+		if task == nil {
+			msg.Reply(fmt.Errorf("task not found: %s", req.TaskID))
+			return nil
+		}
+		if h.inModalView() {
+			// The Pomodoro tab can't be shown, so its timer has no target
+			msg.Reply(errDialogOpen)
+			return nil
+		}
+		msg.Reply(nil)
+		return h.startPomodoro(task)
+
+	case control.CommandRefresh:
+		msg.Reply(nil)
+		h.LastDataFetch = time.Time{}
+		return func() tea.Msg { return refreshMsg{Force: true} }
+	}
+
+	msg.Reply(fmt.Errorf("unknown command %q", req.Command))
+	return nil
+}
+
+// findProject looks up a project by ID or case-insensitive name.
+func (h *Handler) findProject(nameOrID string) *api.Project {
+	for i := range h.Projects {
+		if h.Projects[i].ID == nameOrID {
+			return &h.Projects[i]
+		}
+	}
+	for i := range h.Projects {
+		if strings.EqualFold(h.Projects[i].Name, nameOrID) {
+			return &h.Projects[i]
+		}
+	}
+	return nil
+}
+
+// findTask looks up an active task by ID.
+func (h *Handler) findTask(id string) *api.Task {
+	for i := range h.AllTasks {
+		if h.AllTasks[i].ID == id {
+			return &h.AllTasks[i]
+		}
+	}
+	for i := range h.Tasks {
+		if h.Tasks[i].ID == id {
+			return &h.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// startPomodoro switches to the Pomodoro tab and starts the timer on task.
+func (h *Handler) startPomodoro(task *api.Task) tea.Cmd {
+	h.setPomodoroTask(task)
+	switched := h.switchToTab(state.TabPomodoro)
+	h.StatusMsg = "Pomodoro started 🍅"
+
+	if h.PomodoroRunning {
+		// The running timer already has a tick scheduled
+		return switched
+	}
+	h.PomodoroRunning = true
+	return tea.Batch(switched, tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return components.TimerTickMsg{ID: views.PomodoroTimerID}
+	}))
+}
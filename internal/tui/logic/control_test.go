@@ -0,0 +1,137 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/config"
+	"github.com/hy4ri/todoist-tui/internal/control"
+	"github.com/hy4ri/todoist-tui/internal/tui/components"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+)
+
+func newControlTestHandler() (*Handler, *state.State) {
+	s := &state.State{
+		Client:   api.NewClient("test-token"),
+		Config:   config.DefaultConfig(),
+		Projects: []api.Project{{ID: "p1", Name: "Inbox"}, {ID: "p2", Name: "Work"}},
+		AllTasks: []api.Task{{ID: "t1", Content: "Write report", ProjectID: "p2"}},
+		SelectionState: state.SelectionState{
+			SelectedTaskIDs: make(map[string]bool),
+		},
+		CurrentTab:  state.TabInbox,
+		FocusedPane: state.PaneMain,
+		CurrentView: state.ViewInbox,
+		SidebarComp: components.NewSidebar(),
+	}
+	return NewHandler(s), s
+}
+
+func TestHandleControl(t *testing.T) {
+	t.Run("project by name", func(t *testing.T) {
+		h, s := newControlTestHandler()
+		msg, reply := control.NewMsg(control.Request{Command: control.CommandProject, Project: "work"})
+		if cmd := h.Update(msg); cmd == nil {
+			t.Error("expected a load command")
+		}
+		if resp := <-reply; !resp.OK {
+			t.Errorf("unexpected error: %s", resp.Error)
+		}
+		if s.CurrentProject == nil || s.CurrentProject.ID != "p2" || s.CurrentTab != state.TabProjects {
+			t.Errorf("expected project p2 to be open, got %+v in tab %v", s.CurrentProject, s.CurrentTab)
+		}
+	})
+
+	t.Run("project in a dialog", func(t *testing.T) {
+		h, s := newControlTestHandler()
+		s.CurrentView = state.ViewTaskForm
+		msg, reply := control.NewMsg(control.Request{Command: control.CommandProject, Project: "work"})
+		if cmd := h.Update(msg); cmd != nil {
+			t.Error("expected no load command")
+		}
+		if resp := <-reply; resp.OK {
+			t.Error("expected an error while a dialog is open")
+		}
+		if s.CurrentProject != nil || s.CurrentView != state.ViewTaskForm {
+			t.Errorf("expected the dialog to stay open, got project %+v in view %v", s.CurrentProject, s.CurrentView)
+		}
+	})
+
+	t.Run("unknown project", func(t *testing.T) {
+		h, _ := newControlTestHandler()
+		msg, reply := control.NewMsg(control.Request{Command: control.CommandProject, Project: "Nope"})
+		h.Update(msg)
+		if resp := <-reply; resp.OK {
+			t.Error("expected an error for an unknown project")
+		}
+	})
+
+	t.Run("pomodoro", func(t *testing.T) {
+		h, s := newControlTestHandler()
+		msg, reply := control.NewMsg(control.Request{Command: control.CommandPomodoro, TaskID: "t1"})
+		if cmd := h.Update(msg); cmd == nil {
+			t.Error("expected a timer tick command")
+		}
+		if resp := <-reply; !resp.OK {
+			t.Errorf("unexpected error: %s", resp.Error)
+		}
+		if !s.PomodoroRunning || s.PomodoroTask == nil || s.PomodoroTask.ID != "t1" {
+			t.Errorf("expected Pomodoro running on t1, got running=%v task=%+v", s.PomodoroRunning, s.PomodoroTask)
+		}
+		if s.PomodoroProject != "Work" || s.CurrentView != state.ViewPomodoro {
+			t.Errorf("unexpected project %q or view %v", s.PomodoroProject, s.CurrentView)
+		}
+	})
+
+	t.Run("pomodoro in a dialog", func(t *testing.T) {
+		h, s := newControlTestHandler()
+		s.CurrentView = state.ViewTaskForm
+		msg, reply := control.NewMsg(control.Request{Command: control.CommandPomodoro, TaskID: "t1"})
+		h.Update(msg)
+		if resp := <-reply; resp.OK {
+			t.Error("expected an error while a dialog is open")
+		}
+		if s.PomodoroRunning {
+			t.Error("expected the timer not to start without a target")
+		}
+	})
+
+	t.Run("add", func(t *testing.T) {
+		h, _ := newFakeHandler(t)
+		msg, reply := control.NewMsg(control.Request{Command: control.CommandAdd, Text: "Buy milk"})
+		cmd := h.Update(msg)
+		select {
+		case <-reply:
+			t.Fatal("expected no reply before the task is created")
+		default:
+		}
+		if _, ok := changeResult(t, cmd()).(quickAddTaskCreatedMsg); !ok {
+			t.Fatal("expected the task to be created")
+		}
+		if resp := <-reply; !resp.OK {
+			t.Errorf("unexpected error: %s", resp.Error)
+		}
+
+		// A priority alone leaves the task without content
+		msg, reply = control.NewMsg(control.Request{Command: control.CommandAdd, Text: "p1"})
+		h.Update(msg)()
+		if resp := <-reply; resp.OK {
+			t.Error("expected a failed add to be reported")
+		}
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		h, _ := newControlTestHandler()
+		msg, reply := control.NewMsg(control.Request{Command: control.CommandRefresh})
+		cmd := h.Update(msg)
+		if resp := <-reply; !resp.OK {
+			t.Errorf("unexpected error: %s", resp.Error)
+		}
+		if cmd == nil {
+			t.Fatal("expected a refresh command")
+		}
+		if got, ok := cmd().(refreshMsg); !ok || !got.Force {
+			t.Errorf("expected forced refreshMsg, got %#v", got)
+		}
+	})
+}
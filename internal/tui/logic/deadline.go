@@ -0,0 +1,106 @@
+package logic
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
+)
+
+// handleDeadlineCommand sets or removes the deadline of the selected tasks,
+// or of the task under the cursor.
+func handleDeadlineCommand(h *Handler, args []string) tea.Cmd {
+	if len(args) == 0 {
+		h.StatusMsg = "Usage: :deadline <date|none>"
+		return nil
+	}
+
+	date := ""
+	text := strings.Join(args, " ")
+	switch strings.ToLower(text) {
+	case "none", "clear", "remove":
+	default:
+		day, ok := utils.ParseDate(text, time.Now())
+		if !ok {
+			h.StatusMsg = "Unrecognized deadline: " + text
+			return nil
+		}
+		date = day.Format(utils.DateLayout)
+	}
+
+	if h.CurrentView == state.ViewLabels && h.CurrentLabel == nil {
+		return nil
+	}
+
+	var targets []api.Task
+	if len(h.SelectedTaskIDs) > 0 {
+		for _, t := range h.Tasks {
+			if h.SelectedTaskIDs[t.ID] {
+				targets = append(targets, t)
+			}
+		}
+	} else if task := h.getSelectedTask(); task != nil {
+		targets = append(targets, *task)
+	}
+	if len(targets) == 0 {
+		h.StatusMsg = "No task selected"
+		return nil
+	}
+
+	ids := make(map[string]bool, len(targets))
+	var undo, redo []api.SyncCommand
+	for _, t := range targets {
+		ids[t.ID] = true
+		old := ""
+		if t.Deadline != nil {
+			old = t.Deadline.Date
+		}
+		undo = append(undo, api.UpdateTaskCommand(t.ID, api.UpdateTaskRequest{DeadlineDate: &old}))
+		redo = append(redo, api.UpdateTaskCommand(t.ID, api.UpdateTaskRequest{DeadlineDate: &date}))
+	}
+
+	verb := "Set deadline on"
+	if date == "" {
+		verb = "Remove deadline from"
+	}
+	entry := undoEntry(describeChange(verb, targets), undo, redo)
+
+	// Optimistic update
+	setDeadline := func(t *api.Task) {
+		if !ids[t.ID] {
+			return
+		}
+		t.Deadline = nil
+		if date != "" {
+			t.Deadline = &api.Deadline{Date: date}
+		}
+	}
+	for i := range h.Tasks {
+		setDeadline(&h.Tasks[i])
+	}
+	for i := range h.AllTasks {
+		setDeadline(&h.AllTasks[i])
+	}
+
+	h.clearSelection()
+	h.refilterCurrentView()
+
+	switch {
+	case date == "":
+		h.StatusMsg = describeChange("Removed deadline from", targets)
+	case len(targets) == 1:
+		h.StatusMsg = "Deadline set to " + date
+	default:
+		h.StatusMsg = fmt.Sprintf("Deadline set to %s on %d tasks", date, len(targets))
+	}
+
+	cmds := make([]api.SyncCommand, len(targets))
+	for i, t := range targets {
+		cmds[i] = api.UpdateTaskCommand(t.ID, api.UpdateTaskRequest{DeadlineDate: &date})
+	}
+	return h.undoable(entry, h.sendBulk(cmds, taskUpdatedMsg{}))
+}
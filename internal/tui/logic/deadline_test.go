@@ -0,0 +1,70 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+func TestDeadlineCommand(t *testing.T) {
+	h := newTasksHandler(
+		api.Task{ID: "t1", Content: "Write report", ProjectID: "p1"},
+		api.Task{ID: "t2", Content: "Review PRs", ProjectID: "p1", Deadline: &api.Deadline{Date: "2030-01-01"}},
+	)
+	h.SelectedTaskIDs["t1"] = true
+	h.SelectedTaskIDs["t2"] = true
+
+	if handleDeadlineCommand(h, []string{"2030-01-15"}) == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	for _, tasks := range [][]api.Task{h.Tasks, h.AllTasks} {
+		for _, task := range tasks {
+			if task.Deadline == nil || task.Deadline.Date != "2030-01-15" {
+				t.Errorf("%s: expected an optimistic deadline, got %+v", task.ID, task.Deadline)
+			}
+		}
+	}
+	if h.StatusMsg != "Deadline set to 2030-01-15 on 2 tasks" {
+		t.Errorf("status = %q", h.StatusMsg)
+	}
+	if len(h.SelectedTaskIDs) != 0 {
+		t.Error("expected the selection to be cleared")
+	}
+
+	h.TaskCursor = 0
+	if handleDeadlineCommand(h, []string{"none"}) == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	if h.Tasks[0].Deadline != nil || h.AllTasks[0].Deadline != nil {
+		t.Errorf("expected the deadline to be removed, got %+v", h.Tasks[0].Deadline)
+	}
+	if h.Tasks[1].Deadline == nil {
+		t.Error("expected only the task under the cursor to change")
+	}
+	if h.StatusMsg != "Removed deadline from 'Write report'" {
+		t.Errorf("status = %q", h.StatusMsg)
+	}
+
+	if handleDeadlineCommand(h, []string{"someday"}) != nil {
+		t.Error("expected no command for an unrecognized date")
+	}
+	if h.StatusMsg != "Unrecognized deadline: someday" {
+		t.Errorf("status = %q", h.StatusMsg)
+	}
+}
+
+func TestE2E_DeadlineCommand(t *testing.T) {
+	h, srv := newFakeHandler(t)
+	task := srv.AddTask(api.Task{Content: "Write report", ProjectID: "p1"})
+
+	loadFakeProject(t, h)
+
+	cmd := handleDeadlineCommand(h, []string{"2030-01-15"})
+	if cmd == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	cmd()
+	if got, _ := srv.Task(task.ID); got.Deadline == nil || got.Deadline.Date != "2030-01-15" {
+		t.Fatalf("deadline not set on the server: %+v", got.Deadline)
+	}
+}
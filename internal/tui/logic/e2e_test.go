@@ -0,0 +1,119 @@
+package logic
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/fake"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+)
+
+// newFakeHandler returns a handler viewing project "p1" on a fake server.
+func newFakeHandler(t *testing.T) (*Handler, *fake.Server) {
+	t.Helper()
+	srv := fake.New()
+	srv.AddProject(api.Project{ID: "p1", Name: "Work"})
+	return newViewLoadHandler(newFakeClient(t, srv)), srv
+}
+
+// newFakeClient returns a client of srv that doesn't pace its requests.
+func newFakeClient(t *testing.T, srv *fake.Server) *api.Client {
+	t.Helper()
+	server := httptest.NewServer(srv)
+	t.Cleanup(server.Close)
+
+	client := api.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+	client.Limiter = nil
+	client.FullSyncLimiter = nil
+	return client
+}
+
+// loadFakeProject loads the tasks of project "p1" into h, as opening the
+// project would, and fails the test if that fails.
+func loadFakeProject(t *testing.T, h *Handler) {
+	t.Helper()
+	h.Update(h.loadProjectTasks("p1")())
+	if h.Err != nil {
+		t.Fatalf("load failed: %v", h.Err)
+	}
+	h.AllTasks = append([]api.Task(nil), h.Tasks...)
+}
+
+// changeResult returns the result of an accountChange, failing the test if
+// msg isn't one.
+func changeResult(t *testing.T, msg tea.Msg) tea.Msg {
+	t.Helper()
+	change, ok := msg.(viewLoadedMsg)
+	if !ok {
+		t.Fatalf("expected the result of a change, got %T", msg)
+	}
+	return change.msg
+}
+
+func TestE2E_CompleteTask(t *testing.T) {
+	h, srv := newFakeHandler(t)
+	first := srv.AddTask(api.Task{Content: "Write report", ProjectID: "p1", ChildOrder: 1})
+	srv.AddTask(api.Task{Content: "Review PRs", ProjectID: "p1", ChildOrder: 2})
+
+	loadFakeProject(t, h)
+	if len(h.Tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(h.Tasks))
+	}
+
+	h.TaskCursor = 0
+	for i, idx := range h.TaskOrderedIndices {
+		if h.Tasks[idx].ID == first.ID {
+			h.TaskCursor = i
+		}
+	}
+	cmd := h.handleComplete()
+	if cmd == nil {
+		t.Fatal("expected a completion command")
+	}
+	h.Update(cmd())
+	if h.Err != nil {
+		t.Fatalf("complete failed: %v", h.Err)
+	}
+
+	if got, _ := srv.Task(first.ID); !got.Checked {
+		t.Error("task was not completed on the server")
+	}
+	if open := srv.Tasks(); len(open) != 1 || open[0].Content != "Review PRs" {
+		t.Errorf("unexpected open tasks on the server: %+v", open)
+	}
+}
+
+func TestE2E_PaginatedLoad(t *testing.T) {
+	h, srv := newFakeHandler(t)
+	srv.PageSize = 2
+	for i := 0; i < 5; i++ {
+		srv.AddTask(api.Task{Content: "task", ProjectID: "p1"})
+	}
+
+	loadFakeProject(t, h)
+	if len(h.Tasks) != 5 {
+		t.Errorf("expected all 5 tasks across pages, got %d", len(h.Tasks))
+	}
+}
+
+func TestE2E_EditDuration(t *testing.T) {
+	h, srv := newFakeHandler(t)
+	task := srv.AddTask(api.Task{Content: "Write report", ProjectID: "p1", Duration: &api.Duration{Amount: 30, Unit: "minute"}})
+
+	loadFakeProject(t, h)
+
+	h.PreviousView = h.CurrentView
+	h.TaskForm = state.NewEditTaskForm(&h.Tasks[0], h.Projects, h.Labels)
+	h.TaskForm.Duration.SetValue("1h30m")
+	cmd := h.submitForm()
+	if cmd == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	h.Update(cmd())
+	if got, _ := srv.Task(task.ID); got.Duration == nil || got.Duration.Amount != 90 || got.Duration.Unit != "minute" {
+		t.Fatalf("duration not updated on the server: %+v", got.Duration)
+	}
+}
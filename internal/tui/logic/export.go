@@ -0,0 +1,93 @@
+package logic
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/config"
+	"github.com/hy4ri/todoist-tui/internal/export"
+)
+
+// Export scopes accepted by :export.
+const (
+	exportScopeView    = "view"
+	exportScopeProject = "project"
+	exportScopeAll     = "all"
+)
+
+// handleExportCommand writes tasks to a file: the current view by default,
+// the current project, or everything.
+func handleExportCommand(h *Handler, args []string) tea.Cmd {
+	if len(args) < 2 {
+		h.StatusMsg = "Usage: :export <md|csv|json|todo.txt> <path> [view|project|all]"
+		return nil
+	}
+
+	format, err := export.ParseFormat(args[0])
+	if err != nil {
+		h.StatusMsg = err.Error()
+		return nil
+	}
+	path, scope := pathArgument(args[1:], exportScopeView, exportScopeProject, exportScopeAll)
+	path = config.ExpandHome(path)
+	if scope == "" {
+		scope = exportScopeView
+	}
+
+	var data *export.Data
+	switch scope {
+	case exportScopeView:
+		data = export.Select(h.Projects, h.AllSections, append([]api.Task(nil), h.Tasks...))
+	case exportScopeProject:
+		if h.CurrentProject == nil {
+			h.StatusMsg = "No project open to export"
+			return nil
+		}
+		var tasks []api.Task
+		for _, t := range h.AllTasks {
+			if t.ProjectID == h.CurrentProject.ID {
+				tasks = append(tasks, t)
+			}
+		}
+		data = export.Select(h.Projects, h.AllSections, tasks)
+		// Export the project even when it has no tasks
+		if len(data.Projects) == 0 {
+			data.Projects = []api.Project{*h.CurrentProject}
+		}
+	case exportScopeAll:
+		data = &export.Data{
+			Projects: append([]api.Project(nil), h.Projects...),
+			Sections: append([]api.Section(nil), h.AllSections...),
+			Tasks:    append([]api.Task(nil), h.AllTasks...),
+		}
+	}
+
+	client := h.Client
+	ctx := h.Context()
+	h.StatusMsg = fmt.Sprintf("Exporting %d tasks...", len(data.Tasks))
+	return func() tea.Msg {
+		comments, err := export.LoadComments(ctx, client, data.Tasks)
+		if err != nil {
+			return errMsg{fmt.Errorf("export failed: %w", err)}
+		}
+		data.Comments = comments
+
+		if err := export.WriteFile(path, format, data); err != nil {
+			return errMsg{fmt.Errorf("export failed: %w", err)}
+		}
+		return statusMsg{msg: fmt.Sprintf("Exported %d tasks to %s", len(data.Tasks), path)}
+	}
+}
+
+// pathArgument joins command arguments back into a path, which may contain
+// spaces, after taking off a trailing option among opts.
+func pathArgument(args []string, opts ...string) (path, opt string) {
+	if n := len(args); n > 1 && slices.Contains(opts, strings.ToLower(args[n-1])) {
+		opt = strings.ToLower(args[n-1])
+		args = args[:n-1]
+	}
+	return strings.Join(args, " "), opt
+}
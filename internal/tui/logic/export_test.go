@@ -0,0 +1,21 @@
+package logic
+
+import "testing"
+
+func TestPathArgument(t *testing.T) {
+	tests := []struct {
+		args []string
+		path string
+		opt  string
+	}{
+		{[]string{"tasks.md"}, "tasks.md", ""},
+		{[]string{"~/My", "Tasks.md", "All"}, "~/My Tasks.md", "all"},
+		{[]string{"all"}, "all", ""}, // A lone argument is always the path
+	}
+	for _, tt := range tests {
+		path, opt := pathArgument(tt.args, exportScopeView, exportScopeProject, exportScopeAll)
+		if path != tt.path || opt != tt.opt {
+			t.Errorf("pathArgument(%q) = %q, %q, want %q, %q", tt.args, path, opt, tt.path, tt.opt)
+		}
+	}
+}
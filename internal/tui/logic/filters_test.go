@@ -0,0 +1,97 @@
+package logic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/components"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+)
+
+func TestRunAdHocFilter(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"results": [{"id": "server-1"}], "next_cursor": "page-2"}`))
+			return
+		}
+		w.Write([]byte(`{"results": [{"id": "server-2"}]}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+
+	s := &state.State{
+		Client:   client,
+		Projects: []api.Project{{ID: "p1", Name: "Inbox"}, {ID: "p2", Name: "Work"}},
+		AllTasks: []api.Task{
+			{ID: "t1", Content: "Write report", ProjectID: "p2", Priority: 4},
+			{ID: "t2", Content: "Buy milk", ProjectID: "p1", Priority: 4},
+			{ID: "t3", Content: "Plan sprint", ProjectID: "p2", Priority: 1},
+		},
+		SelectionState: state.SelectionState{
+			SelectedTaskIDs: make(map[string]bool),
+		},
+		CurrentTab:  state.TabFilters,
+		FocusedPane: state.PaneMain,
+		CurrentView: state.ViewFilters,
+		SidebarComp: components.NewSidebar(),
+	}
+	h := NewHandler(s)
+
+	t.Run("evaluated locally", func(t *testing.T) {
+		msg := h.runAdHocFilter("#Work & p1")()
+		loaded, ok := msg.(dataLoadedMsg)
+		if !ok {
+			t.Fatalf("expected dataLoadedMsg, got %T", msg)
+		}
+		if len(loaded.tasks) != 1 || loaded.tasks[0].ID != "t1" {
+			t.Errorf("unexpected tasks %v", loaded.tasks)
+		}
+		if n := atomic.LoadInt32(&requests); n != 0 {
+			t.Errorf("expected no requests, got %d", n)
+		}
+	})
+
+	t.Run("streams server pages", func(t *testing.T) {
+		var msg tea.Msg
+		cmd := h.runAdHocFilter("next 7 days")
+		var seen []int
+		for cmd != nil {
+			msg = cmd()
+			page, ok := msg.(filterPageMsg)
+			if !ok {
+				t.Fatalf("expected filterPageMsg, got %T", msg)
+			}
+			cmd = h.Update(page)
+			seen = append(seen, len(s.Tasks))
+		}
+
+		// One task after the first page, both after the second
+		if len(seen) != 3 || seen[0] != 1 || seen[1] != 2 || seen[2] != 2 {
+			t.Errorf("unexpected task counts per page: %v", seen)
+		}
+		if n := atomic.LoadInt32(&requests); n != 2 {
+			t.Errorf("expected two requests, got %d", n)
+		}
+	})
+
+	t.Run("drops pages of a replaced filter", func(t *testing.T) {
+		cmd := h.runAdHocFilter("next 7 days")
+		msg := cmd()
+		h.runAdHocFilter("#Work")
+		s.Tasks = nil
+		if next := h.Update(msg); next != nil {
+			t.Error("expected no further fetches")
+		}
+		if s.Tasks != nil {
+			t.Errorf("expected the stale page to be ignored, got %v", s.Tasks)
+		}
+	})
+}
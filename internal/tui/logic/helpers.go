@@ -1,6 +1,8 @@
 package logic
 
 import (
+	"context"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hy4ri/todoist-tui/internal/api"
 	"github.com/hy4ri/todoist-tui/internal/tui/utils"
@@ -17,11 +19,47 @@ func (h *Handler) extractLabelsFromTasks() []api.Label {
 
 // reorderSectionsCmd updates the section order using the Sync API.
 func (h *Handler) reorderSectionsCmd(sections []api.Section) tea.Cmd {
-	return func() tea.Msg {
-		if err := h.Client.ReorderSections(sections); err != nil {
+	ctx, client := h.Context(), h.Client
+	return h.accountChange(func() tea.Msg {
+		if err := client.ReorderSections(ctx, sections); err != nil {
 			return errMsg{err}
 		}
 		return reorderCompleteMsg{}
+	})
+}
+
+// viewLoad runs load with the current view context. When the view changes
+// before it finishes, the request is cancelled and its result dropped, so a
+// slow load cannot replace what the new view shows.
+func (h *Handler) viewLoad(load func(ctx context.Context) tea.Msg) tea.Cmd {
+	ctx := h.ViewContext()
+	return func() tea.Msg {
+		return viewLoadedMsg{ctx: ctx, msg: load(ctx)}
+	}
+}
+
+// accountLoad runs load with the context of the signed-in account. When the
+// profile is switched before it finishes, the request is cancelled and its
+// result dropped, so it cannot land in the new account.
+func (h *Handler) accountLoad(load func(ctx context.Context) tea.Msg) tea.Cmd {
+	ctx := h.AccountContext()
+	return func() tea.Msg {
+		return viewLoadedMsg{ctx: ctx, msg: load(ctx)}
+	}
+}
+
+// accountChange tags the result of cmd, which changes the data of the
+// signed-in account, with the account's context. The request still runs to
+// the end, but if the profile is switched before it does its result is
+// dropped, as for accountLoad. cmd must use the client and outbox of the
+// account as they were when it was built.
+func (h *Handler) accountChange(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	ctx := h.AccountContext()
+	return func() tea.Msg {
+		return viewLoadedMsg{ctx: ctx, msg: cmd()}
 	}
 }
 
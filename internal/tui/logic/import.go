@@ -0,0 +1,89 @@
+package logic
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/config"
+	"github.com/hy4ri/todoist-tui/internal/importer"
+)
+
+// importParsedMsg carries the tasks read from the file given to :import.
+type importParsedMsg struct {
+	plan   *importer.Plan
+	dryRun bool
+}
+
+// importFinishedMsg reports the outcome of :import.
+type importFinishedMsg struct {
+	report *importer.Report
+}
+
+// handleImportCommand creates tasks from a file. Tasks without a project go
+// to the open project, or the Inbox. With "dry" only a summary is shown.
+func handleImportCommand(h *Handler, args []string) tea.Cmd {
+	if len(args) < 2 {
+		h.StatusMsg = "Usage: :import <md|csv|todo.txt> <path> [dry]"
+		return nil
+	}
+
+	format, err := importer.ParseFormat(args[0])
+	if err != nil {
+		h.StatusMsg = err.Error()
+		return nil
+	}
+	path, opt := pathArgument(args[1:], "dry")
+	path = config.ExpandHome(path)
+	dryRun := opt != ""
+
+	h.Loading = true
+	h.StatusMsg = "Reading " + path + "..."
+	return func() tea.Msg {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errMsg{fmt.Errorf("import failed: %w", err)}
+		}
+		plan, err := importer.Parse(bytes.NewReader(data), format, nil)
+		if err != nil {
+			return errMsg{fmt.Errorf("import failed: %w", err)}
+		}
+		return importParsedMsg{plan: plan, dryRun: dryRun}
+	}
+}
+
+// handleImportParsed matches the parsed tasks against the loaded projects
+// and sections, then creates them unless it is a dry run.
+func (h *Handler) handleImportParsed(msg importParsedMsg) tea.Cmd {
+	var projectID string
+	if h.CurrentProject != nil {
+		projectID = h.CurrentProject.ID
+	}
+	batch := importer.Build(msg.plan, h.Projects, h.AllSections, projectID)
+
+	if msg.dryRun {
+		h.Loading = false
+		h.StatusMsg = fmt.Sprintf("Would create %d tasks, %d projects and %d sections (%d rows skipped)",
+			batch.TaskCount(), len(batch.NewProjects), len(batch.NewSections), len(batch.Errors))
+		return nil
+	}
+
+	ctx, client := h.Context(), h.Client
+	h.StatusMsg = fmt.Sprintf("Importing %d tasks...", batch.TaskCount())
+	return h.accountChange(func() tea.Msg {
+		return importFinishedMsg{report: batch.Execute(ctx, client)}
+	})
+}
+
+// handleImportFinished reports the import and reloads the data.
+func (h *Handler) handleImportFinished(msg importFinishedMsg) tea.Cmd {
+	h.Loading = false
+	r := msg.report
+	if len(r.Failures) == 0 {
+		h.StatusMsg = fmt.Sprintf("Imported %d tasks", r.Created)
+	} else {
+		h.StatusMsg = fmt.Sprintf("Imported %d tasks, %d failed (%v)", r.Created, len(r.Failures), r.Failures[0])
+	}
+	return h.handleRefresh(true)
+}
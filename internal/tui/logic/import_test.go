@@ -0,0 +1,29 @@
+package logic
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImportCommand_DryRun(t *testing.T) {
+	h, _ := newFakeHandler(t)
+	path := filepath.Join(t.TempDir(), "my notes.md")
+	if err := os.WriteFile(path, []byte("- [ ] Write report\n- [ ] Review PRs\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The file is read by the command, not while the input is handled
+	cmd := handleImportCommand(h, strings.Fields("md "+path+" dry"))
+	if cmd == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	h.Update(cmd())
+	if h.Err != nil {
+		t.Fatalf("import failed: %v", h.Err)
+	}
+	if !strings.HasPrefix(h.StatusMsg, "Would create 2 tasks") {
+		t.Errorf("status = %q", h.StatusMsg)
+	}
+}
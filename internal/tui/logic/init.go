@@ -1,105 +1,68 @@
 package logic
 
 import (
+	"context"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/cache"
 	"github.com/hy4ri/todoist-tui/internal/tui/state"
 )
 
 // Init implements tea.Model.
 func (h *Handler) Init() tea.Cmd {
+	// Render the cached snapshot right away; LoadInitialData reconciles it in the background
+	restored := h.restoreCache()
+
+	load := h.LoadInitialData()
+	if h.Outbox != nil && h.Outbox.Len() > 0 {
+		// Replay changes queued while offline before fetching server state
+		load = tea.Sequence(h.flushOutbox(), load)
+	}
+
 	return tea.Batch(
 		h.Spinner.Tick,
-		h.LoadInitialData(),
+		restored,
+		load,
 		checkDueCmd(),
 	)
 }
 
 // loadInitialData loads all necessary data concurrently.
 func (h *Handler) LoadInitialData() tea.Cmd {
-	return func() tea.Msg {
+	// The goroutines must not touch the handler: a profile switch replaces
+	// the clients and cache while they run
+	client, syncClient, store, tab := h.Client, h.SyncClient, h.Cache, h.CurrentTab
+	return h.accountLoad(func(ctx context.Context) tea.Msg {
 		// Create buffered channels to prevent goroutine leaks on early return
-		type projectResult struct {
-			data []api.Project
-			err  error
-		}
-		type labelResult struct {
-			data []api.Label
-			err  error
-		}
-		type taskResult struct {
-			data []api.Task
-			err  error
-		}
-		type sectionResult struct {
-			data []api.Section
+		type syncResult struct {
+			data *api.SyncResult
 			err  error
 		}
 		type statsResult struct {
 			data *api.ProductivityStats
 			err  error
 		}
-		type reminderResult struct {
-			data []api.Reminder
-			err  error
-		}
 
-		projChan := make(chan projectResult, 1)
-		labelChan := make(chan labelResult, 1)
-		taskChan := make(chan taskResult, 1)
-		secChan := make(chan sectionResult, 1)
+		syncChan := make(chan syncResult, 1)
 		statsChan := make(chan statsResult, 1)
-		remChan := make(chan reminderResult, 1)
 
-		// Launch concurrent requests
+		// A single sync round trip returns projects, labels, tasks, sections,
+		// filters, reminders and the user; stats live on a separate endpoint.
 		go func() {
-			p, e := h.Client.GetProjects()
-			projChan <- projectResult{data: p, err: e}
+			r, e := syncClient.Sync(ctx)
+			syncChan <- syncResult{data: r, err: e}
 		}()
 
 		go func() {
-			l, e := h.Client.GetLabels()
-			labelChan <- labelResult{data: l, err: e}
-		}()
-
-		go func() {
-			t, e := h.Client.GetTasks(api.TaskFilter{})
-			taskChan <- taskResult{data: t, err: e}
-		}()
-
-		go func() {
-			s, e := h.Client.GetSections("")
-			secChan <- sectionResult{data: s, err: e}
-		}()
-
-		go func() {
-			s, e := h.Client.GetProductivityStats()
+			s, e := client.GetProductivityStats(ctx)
 			statsChan <- statsResult{data: s, err: e}
 		}()
 
-		go func() {
-			r, e := h.Client.GetReminders()
-			remChan <- reminderResult{data: r, err: e}
-		}()
-
 		// Collect ALL results before processing errors to ensure all goroutines exit
-		pRes := <-projChan
-		lRes := <-labelChan
-		tRes := <-taskChan
-		sRes := <-secChan
+		sRes := <-syncChan
 		statsRes := <-statsChan
-		rRes := <-remChan
 
-		// Now check for errors
-		if pRes.err != nil {
-			return errMsg{pRes.err}
-		}
-		if lRes.err != nil {
-			return errMsg{lRes.err}
-		}
-		if tRes.err != nil {
-			return errMsg{tRes.err}
-		}
 		if sRes.err != nil {
 			return errMsg{sRes.err}
 		}
@@ -112,63 +75,108 @@ func (h *Handler) LoadInitialData() tea.Cmd {
 			statsErr = statsRes.err
 		}
 
-		var reminders []api.Reminder
-		if rRes.err == nil {
-			reminders = rRes.data
-		}
+		projects := sRes.data.Projects
+		labels := sRes.data.Labels
+		allTasks := sRes.data.Tasks
+		allSections := sRes.data.Sections
 
-		projects := pRes.data
-		labels := lRes.data
-		allTasks := tRes.data
-		allSections := sRes.data
-
-		// Filter tasks for the initial view
-		initialTasks := []api.Task{}
-		switch h.CurrentTab {
-		case state.TabUpcoming:
-			for _, t := range allTasks {
-				if t.Due != nil {
-					initialTasks = append(initialTasks, t)
-				}
-			}
-		case state.TabCalendar:
-			initialTasks = nil
-		case state.TabProjects, state.TabLabels:
-			initialTasks = nil
-		case state.TabInbox:
-			// Find inbox ID
-			var inboxID string
-			for _, p := range projects {
-				if p.InboxProject {
-					inboxID = p.ID
-					break
-				}
-			}
-			for _, t := range allTasks {
-				if t.ProjectID == inboxID && !t.Checked && !t.IsDeleted {
-					initialTasks = append(initialTasks, t)
-				}
-			}
-		default:
-			// TabToday or fallback
-			for _, t := range allTasks {
-				if t.IsOverdue() || t.IsDueToday() {
-					initialTasks = append(initialTasks, t)
-				}
-			}
-		}
+		// Persist the new snapshot so the next launch can render instantly
+		saveCache(store, syncClient, sRes.data)
 
 		return dataLoadedMsg{
 			projects:    projects,
-			tasks:       initialTasks,
+			tasks:       initialViewTasks(tab, projects, allTasks),
 			allTasks:    allTasks,
 			labels:      labels,
 			allSections: allSections,
+			filters:     sRes.data.Filters,
 			stats:       prodStats,
 			statsErr:    statsErr,
-			reminders:   reminders,
+			reminders:   sRes.data.Reminders,
+			user:        sRes.data.User,
+			synced:      true,
 		}
+	})
+}
+
+// restoreCache applies the on-disk snapshot to the state and seeds the sync
+// client with it, so the first sync is incremental.
+func (h *Handler) restoreCache() tea.Cmd {
+	if h.Cache == nil {
+		return nil
+	}
+
+	snap, err := h.Cache.Load()
+	if err != nil || snap == nil {
+		return nil
+	}
+
+	h.SyncClient.Restore(snap.SyncToken, snap.SyncResult())
+	cmd := h.handleDataLoaded(dataLoadedMsg{
+		projects:    snap.Projects,
+		tasks:       initialViewTasks(h.CurrentTab, snap.Projects, snap.Tasks),
+		allTasks:    snap.Tasks,
+		allSections: snap.Sections,
+		labels:      snap.Labels,
+		filters:     snap.Filters,
+		reminders:   snap.Reminders,
+		user:        snap.User,
+		synced:      true,
+	})
+
+	// Cached data is not fresh; the next refresh must hit the server
+	h.LastDataFetch = snap.SavedAt
+	// Keep the spinner running until the background sync finishes
+	h.Loading = true
+	return cmd
+}
+
+// saveCache writes a sync result of syncClient to its account's store.
+// Failures are ignored since the cache is only an optimization.
+func saveCache(store *cache.Store, syncClient *api.SyncClient, result *api.SyncResult) {
+	if store == nil {
+		return
 	}
+	_ = store.Save(cache.NewSnapshot(syncClient.Token(), result))
+}
+
+// initialViewTasks selects the tasks shown by the given tab on startup.
+func initialViewTasks(tab state.Tab, projects []api.Project, allTasks []api.Task) []api.Task {
+	initialTasks := []api.Task{}
+	switch tab {
+	case state.TabUpcoming:
+		for _, t := range allTasks {
+			if t.Due != nil {
+				initialTasks = append(initialTasks, t)
+			}
+		}
+	case state.TabCalendar:
+		initialTasks = nil
+	case state.TabProjects, state.TabLabels:
+		initialTasks = nil
+	case state.TabInbox:
+		// Find inbox ID
+		var inboxID string
+		for _, p := range projects {
+			if p.InboxProject {
+				inboxID = p.ID
+				break
+			}
+		}
+		for _, t := range allTasks {
+			if t.ProjectID == inboxID && !t.Checked && !t.IsDeleted {
+				initialTasks = append(initialTasks, t)
+			}
+		}
+	default:
+		// TabToday or fallback
+		for _, t := range allTasks {
+			if t.IsOverdue() || t.IsDueToday() {
+				initialTasks = append(initialTasks, t)
+			}
+		}
+	}
+	return initialTasks
 }
 
 // Message types
@@ -181,15 +189,20 @@ type dataLoadedMsg struct {
 	sections    []api.Section
 	allSections []api.Section
 	labels      []api.Label
+	filters     []api.Filter
 	stats       *api.ProductivityStats
 	statsErr    error
 	reminders   []api.Reminder
+	user        *api.User // nil if it couldn't be loaded
+	// synced marks a full snapshot from the Sync API; empty slices are
+	// applied as-is instead of being treated as "not loaded".
+	synced bool
 }
 type taskUpdatedMsg struct{ task *api.Task }
 type taskDeletedMsg struct{ id string }
 type taskCompletedMsg struct{ id string }
-type taskCreatedMsg struct{}
-type quickAddTaskCreatedMsg struct{}
+type taskCreatedMsg struct{ task *api.Task }
+type quickAddTaskCreatedMsg struct{ task *api.Task }
 type projectCreatedMsg struct{ project *api.Project }
 type projectUpdatedMsg struct{ project *api.Project }
 type projectDeletedMsg struct{ id string }
@@ -202,13 +215,20 @@ type sectionDeletedMsg struct{ id string }
 type commentCreatedMsg struct{ comment *api.Comment }
 type commentUpdatedMsg struct{ comment *api.Comment }
 type commentDeletedMsg struct{ id string }
-type subtaskCreatedMsg struct{}
-type undoCompletedMsg struct{}
+type subtaskCreatedMsg struct{ task *api.Task }
 type searchRefreshMsg struct{}
 type searchResultsLoadedMsg struct{ tasks []api.Task }
 type refreshMsg struct{ Force bool }
 type commentsLoadedMsg struct{ comments []api.Comment }
 
+// viewLoadedMsg carries the result of a load started with viewLoad or
+// accountLoad, or of a change started with accountChange; it is dropped if
+// the context it was tagged with was cancelled.
+type viewLoadedMsg struct {
+	ctx context.Context
+	msg tea.Msg
+}
+
 type reorderCompleteMsg struct{}
 
 // Update implements tea.Model.
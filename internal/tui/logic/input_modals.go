@@ -40,8 +40,9 @@ func (h *Handler) handleFormKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		// Otherwise, let form handle enter (e.g. for opening project list)
 	}
 
-	// Forward to form
-	return h.TaskForm.Update(msg)
+	// Forward to form, then fetch the collaborators of a newly picked project
+	cmd := h.TaskForm.Update(msg)
+	return tea.Batch(cmd, h.loadFormCollaborators())
 }
 
 // handleQuickAddKeyMsg handles keyboard input for the Quick Add popup.
@@ -82,10 +83,11 @@ func (h *Handler) handleQuickAddKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		h.StatusMsg = "Adding task..."
 
 		// Create task in background using Quick Add API
-		return func() tea.Msg {
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
 			// Send clean text to QuickAddTask — let it handle dates, priorities, labels
 			// Do NOT append #ProjectName (fails with spaces in project names)
-			task, err := h.Client.QuickAddTask(content)
+			task, err := client.QuickAddTask(ctx, content)
 			if err != nil {
 				return errMsg{err}
 			}
@@ -97,18 +99,21 @@ func (h *Handler) handleQuickAddKeyMsg(msg tea.KeyMsg) tea.Cmd {
 				if sectionID != "" {
 					secPtr = &sectionID
 				}
-				if err := h.Client.MoveTask(task.ID, secPtr, &projectID, nil); err != nil {
+				if err := client.MoveTask(ctx, task.ID, secPtr, &projectID, nil); err != nil {
 					return errMsg{err}
 				}
+				task.ProjectID = projectID
+				task.SectionID = secPtr
 			} else if sectionID != "" && (task.SectionID == nil || *task.SectionID != sectionID) {
 				// Same project but wrong section
-				if err := h.Client.MoveTask(task.ID, &sectionID, nil, nil); err != nil {
+				if err := client.MoveTask(ctx, task.ID, &sectionID, nil, nil); err != nil {
 					return errMsg{err}
 				}
+				task.SectionID = &sectionID
 			}
 
-			return quickAddTaskCreatedMsg{}
-		}
+			return quickAddTaskCreatedMsg{task: task}
+		})
 	}
 
 	// Forward other keys to the form input
@@ -135,13 +140,14 @@ func (h *Handler) handleCommentEditKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		h.EditingComment = nil
 		h.CommentInput.Reset()
 
-		return func() tea.Msg {
-			c, err := h.Client.UpdateComment(commentID, api.UpdateCommentRequest{Content: content})
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
+			c, err := client.UpdateComment(ctx, commentID, api.UpdateCommentRequest{Content: content})
 			if err != nil {
 				return errMsg{err}
 			}
 			return commentUpdatedMsg{comment: c}
-		}
+		})
 	}
 	var cmd tea.Cmd
 	h.CommentInput, cmd = h.CommentInput.Update(msg)
@@ -158,13 +164,14 @@ func (h *Handler) handleDeleteCommentConfirmKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		commentID := h.EditingComment.ID
 		h.EditingComment = nil
 
-		return func() tea.Msg {
-			err := h.Client.DeleteComment(commentID)
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
+			err := client.DeleteComment(ctx, commentID)
 			if err != nil {
 				return errMsg{err}
 			}
 			return commentDeletedMsg{id: commentID}
-		}
+		})
 	case "n", "N", "esc":
 		h.ConfirmDeleteComment = false
 		h.EditingComment = nil
@@ -198,21 +205,30 @@ func (h *Handler) handleCommentInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			return nil
 		}
 
+		uids := h.mentionedUIDs(content)
 		h.IsAddingComment = false
 		h.CommentInput.Reset()
 		h.Loading = true
 		h.StatusMsg = "Adding comment..."
 
-		return func() tea.Msg {
-			comment, err := h.Client.CreateComment(api.CreateCommentRequest{
-				TaskID:  taskID,
-				Content: content,
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
+			comment, err := client.CreateComment(ctx, api.CreateCommentRequest{
+				TaskID:       taskID,
+				Content:      content,
+				UIDsToNotify: uids,
 			})
 			if err != nil {
 				return errMsg{err}
 			}
 			return commentCreatedMsg{comment: comment}
+		})
+
+	case "tab":
+		if h.completeMention() {
+			return nil
 		}
+		fallthrough
 
 	default:
 		var cmd tea.Cmd
@@ -286,6 +302,12 @@ func (h *Handler) handleTaskDetailKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		return nil
 	}
 
+	// The reaction picker takes all keys until it is closed
+	if h.DetailComp.Reacting() {
+		_, cmd := h.DetailComp.Update(msg)
+		return cmd
+	}
+
 	// Handle reminder input
 	if h.IsAddingReminder || h.IsEditingReminder {
 		return h.handleReminderInputKeyMsg(msg)
@@ -319,7 +341,7 @@ func (h *Handler) handleTaskDetailKeyMsg(msg tea.KeyMsg) tea.Cmd {
 				h.CommentInput.SetHeight(3)
 				h.CommentInput.ShowLineNumbers = false
 				h.CommentInput.Prompt = ""
-				return nil
+				return h.loadProjectCollaborators(h.SelectedTask.ProjectID)
 			}
 		case "edit":
 			return h.handleEdit()
@@ -327,6 +349,8 @@ func (h *Handler) handleTaskDetailKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			return h.handleDelete()
 		case "complete":
 			return h.handleComplete()
+		case "complete_forever":
+			return h.handleCompleteForever()
 		case "priority1", "priority2", "priority3", "priority4":
 			return h.handlePriority(action)
 		case "due_tomorrow":
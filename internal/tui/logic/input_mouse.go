@@ -22,7 +22,7 @@ func (h *Handler) handleMouseMsg(msg tea.MouseMsg) tea.Cmd {
 	}
 
 	// Skip if in modal views
-	if h.CurrentView == state.ViewHelp || h.CurrentView == state.ViewTaskForm || h.CurrentView == state.ViewQuickAdd || h.CurrentView == state.ViewSearch || h.CurrentView == state.ViewTaskDetail {
+	if h.inModalView() {
 		return nil
 	}
 
@@ -11,6 +11,12 @@ import (
 	"github.com/hy4ri/todoist-tui/internal/tui/state"
 )
 
+// inModalView reports whether a modal view, which tabs can't be switched
+// away from, is open.
+func (h *Handler) inModalView() bool {
+	return h.CurrentView == state.ViewHelp || h.CurrentView == state.ViewTaskForm || h.CurrentView == state.ViewQuickAdd || h.CurrentView == state.ViewSearch || h.CurrentView == state.ViewTaskDetail
+}
+
 // switchToTab switches to a specific tab using the view coordinator.
 func (h *Handler) switchToTab(tab state.Tab) tea.Cmd {
 	// Capture the current task before switching
@@ -32,13 +38,16 @@ func (h *Handler) switchToTab(tab state.Tab) tea.Cmd {
 	}
 
 	// Don't switch if in modal views
-	if h.CurrentView == state.ViewHelp || h.CurrentView == state.ViewTaskForm || h.CurrentView == state.ViewQuickAdd || h.CurrentView == state.ViewSearch || h.CurrentView == state.ViewTaskDetail {
+	if h.inModalView() {
 		return nil
 	}
 
 	h.TaskCursor = 0
 	h.CurrentLabel = nil
 
+	// Results still loading for the old tab must not replace the new one
+	h.CancelViewLoads()
+
 	// Update state via coordinator (sets CurrentTab, CurrentView, FocusedPane)
 	h.coordinator.SwitchToTab(tab)
 	h.CurrentTab = tab
@@ -129,6 +138,7 @@ func (h *Handler) handleSelect() tea.Cmd {
 
 				// Selections are project-local: clear them when switching projects.
 				h.clearSelection()
+				h.CancelViewLoads()
 
 				// Close detail panel when switching projects
 				if h.ShowDetailPanel {
@@ -232,8 +242,8 @@ func (h *Handler) handleBack() tea.Cmd {
 		// Go back to calendar view
 		h.CurrentView = state.ViewCalendar
 		h.TaskCursor = 0
-		// Reload all tasks for calendar display
-		return h.loadAllTasks()
+		// Refresh tasks for calendar display
+		return h.syncData()
 	case state.ViewProject:
 		// In Projects tab, just clear selection
 		if h.CurrentTab == state.TabProjects {
@@ -273,11 +283,12 @@ func (h *Handler) setDefaultView() tea.Cmd {
 	}
 
 	if viewName != "" {
-		// Update in memory
-		h.Config.UI.DefaultView = viewName
+		// Update in memory, for the active profile
+		profile := config.CurrentProfile()
+		h.Config.SetDefaultViewFor(profile, viewName)
 
 		// Update on disk (preserving comments)
-		if err := config.UpdateDefaultView(viewName); err != nil {
+		if err := config.UpdateDefaultView(h.Config, profile, viewName); err != nil {
 			h.StatusMsg = fmt.Sprintf("Failed to save config: %v", err)
 		} else {
 			h.StatusMsg = fmt.Sprintf("Default view set to: %s", viewName)
@@ -300,6 +311,13 @@ func (h *Handler) handleSendTaskToPomodoro() tea.Cmd {
 		return nil
 	}
 
+	h.setPomodoroTask(task)
+	h.StatusMsg = "Task sent to Pomodoro 🍅"
+	return nil
+}
+
+// setPomodoroTask makes a copy of task the Pomodoro's current task.
+func (h *Handler) setPomodoroTask(task *api.Task) {
 	taskCopy := new(api.Task)
 	*taskCopy = *task
 	h.PomodoroTask = taskCopy
@@ -311,7 +329,4 @@ func (h *Handler) handleSendTaskToPomodoro() tea.Cmd {
 			break
 		}
 	}
-
-	h.StatusMsg = "Task sent to Pomodoro 🍅"
-	return nil
 }
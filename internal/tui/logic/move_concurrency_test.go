@@ -67,11 +67,13 @@ func TestExecuteMoveToProjectBatch(t *testing.T) {
 	}
 
 	// Run the background command
-	msg := cmd()
+	msg := changeResult(t, cmd())
 
-	// Verify it returned a statusMsg (success)
-	if _, ok := msg.(statusMsg); !ok {
-		t.Errorf("Expected statusMsg, got %T: %v", msg, msg)
+	// Verify it returned a statusMsg (success), recorded for undo
+	if undoable, ok := msg.(undoableMsg); !ok {
+		t.Errorf("Expected undoableMsg, got %T: %v", msg, msg)
+	} else if _, ok := undoable.msg.(statusMsg); !ok {
+		t.Errorf("Expected statusMsg, got %T: %v", undoable.msg, undoable.msg)
 	}
 
 	// Verify batching
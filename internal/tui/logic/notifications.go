@@ -101,6 +101,40 @@ func (h *Handler) handleCheckDue(t time.Time) tea.Cmd {
 		}
 	}
 
+	// Check for approaching deadlines: once on the day before and once on
+	// the day itself, from 9:00 AM like day-only tasks
+	if t.Hour() >= 9 {
+		for _, task := range h.AllTasks {
+			if task.Checked || task.IsDeleted {
+				continue
+			}
+			days, ok := task.DaysUntilDeadline(t)
+			if !ok || days < 0 || days > 1 {
+				continue
+			}
+
+			key := fmt.Sprintf("deadline:%s:%s:%d", task.ID, task.Deadline.Date, days)
+			if h.NotifiedTasks[key] {
+				continue
+			}
+			h.NotifiedTasks[key] = true
+
+			content := "Deadline today: " + task.Content
+			if days == 1 {
+				content = "Deadline tomorrow: " + task.Content
+			}
+			project := "Todoist"
+			if p, ok := h.getProjectName(task.ProjectID); ok {
+				project = p
+			}
+
+			cmds = append(cmds, func() tea.Msg {
+				_ = beeep.Notify(project, content, "")
+				return nil
+			})
+		}
+	}
+
 	// Check for reminders
 	for _, rem := range h.Reminders {
 		// Skip if already notified
@@ -1,6 +1,7 @@
 package logic
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -111,6 +112,50 @@ func TestHandleCheckDue(t *testing.T) {
 	}
 }
 
+func TestHandleCheckDue_Deadline(t *testing.T) {
+	now := time.Now()
+	today9am := time.Date(now.Year(), now.Month(), now.Day(), 9, 30, 0, 0, time.Local)
+	date := func(days int) string { return today9am.AddDate(0, 0, days).Format("2006-01-02") }
+	key := func(id string, days int) string { return fmt.Sprintf("deadline:%s:%s:%d", id, date(days), days) }
+
+	tests := []struct {
+		name         string
+		currentTime  time.Time
+		deadline     string
+		expectedKey  string
+		expectNotify bool
+	}{
+		{"Deadline today", today9am, date(0), key("1", 0), true},
+		{"Deadline tomorrow", today9am, date(1), key("1", 1), true},
+		{"Deadline in two days", today9am, date(2), "", false},
+		{"Deadline passed", today9am, date(-1), "", false},
+		{"Before 9am", today9am.Add(-2 * time.Hour), date(0), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &state.State{
+				AllTasks: []api.Task{{
+					ID:       "1",
+					Content:  "Report",
+					Deadline: &api.Deadline{Date: tt.deadline},
+				}},
+				NotifiedTasks: make(map[string]bool),
+			}
+			h := &Handler{State: s}
+
+			h.handleCheckDue(tt.currentTime)
+
+			if tt.expectNotify && !h.NotifiedTasks[tt.expectedKey] {
+				t.Errorf("expected %s in notified tasks, got %v", tt.expectedKey, h.NotifiedTasks)
+			}
+			if !tt.expectNotify && len(h.NotifiedTasks) > 0 {
+				t.Errorf("expected no notification, got %v", h.NotifiedTasks)
+			}
+		})
+	}
+}
+
 func ptr(s string) *string {
 	return &s
 }
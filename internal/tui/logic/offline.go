@@ -0,0 +1,296 @@
+package logic
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/cache"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
+)
+
+// outboxRetryInterval is how often queued changes are retried while offline.
+const outboxRetryInterval = 30 * time.Second
+
+// outboxQueuedMsg reports that changes were queued because the network is down.
+type outboxQueuedMsg struct {
+	count int
+	// created holds tasks added while offline, identified by their temp ID.
+	created []api.Task
+}
+
+// outboxRetryMsg triggers another attempt to flush the outbox.
+type outboxRetryMsg struct{}
+
+// outboxFlushedMsg carries the result of replaying the outbox.
+type outboxFlushedMsg struct {
+	result *cache.FlushResult
+	err    error
+}
+
+// bulkRejectedMsg reports the commands of a bulk change the server rejected.
+type bulkRejectedMsg struct {
+	count int
+	err   error // Failure of the first rejected command
+	// queued is the number of the other commands queued because the
+	// network was down, or queueErr the reason they couldn't be.
+	queued   int
+	queueErr error
+}
+
+// queueOffline stores commands in outbox when err is a network failure, so
+// the optimistic update that was already applied survives until connectivity
+// returns. Any other error is reported as usual.
+// Safe to call from a command goroutine.
+func queueOffline(outbox *cache.Outbox, err error, cmds ...api.SyncCommand) tea.Msg {
+	if outbox == nil || !api.IsNetworkError(err) {
+		return errMsg{err}
+	}
+	if qErr := outbox.Add(cmds...); qErr != nil {
+		return errMsg{qErr}
+	}
+	return outboxQueuedMsg{count: len(cmds)}
+}
+
+// sendBulk sends the commands of a change to several tasks in Sync batches,
+// so a large selection costs one request per MaxCommandsPerSync tasks rather
+// than one per task and stays well within the rate limit. done is returned
+// on success. If the network is down the commands are queued; if the server
+// rejected any, they are reported and the data is refreshed to undo the
+// optimistic update.
+func (h *Handler) sendBulk(cmds []api.SyncCommand, done tea.Msg) tea.Cmd {
+	ctx, client, outbox := h.Context(), h.Client, h.Outbox
+	return func() tea.Msg {
+		result := client.ExecuteBatched(ctx, cmds)
+
+		var offline []api.SyncCommand
+		var offlineErr error
+		rejected := bulkRejectedMsg{}
+		for _, cmd := range cmds {
+			switch err := result.Errors[cmd.UUID]; {
+			case err == nil:
+			case api.IsNetworkError(err):
+				offline = append(offline, cmd)
+				offlineErr = err
+			default:
+				if rejected.count == 0 {
+					rejected.err = err
+				}
+				rejected.count++
+			}
+		}
+
+		// Queue what didn't reach the server before reporting rejections,
+		// so those changes aren't lost
+		var queued tea.Msg
+		if len(offline) > 0 {
+			queued = queueOffline(outbox, offlineErr, offline...)
+		}
+		if rejected.count > 0 {
+			switch msg := queued.(type) {
+			case outboxQueuedMsg:
+				rejected.queued = msg.count
+			case errMsg:
+				rejected.queueErr = msg.err
+			}
+			return rejected
+		}
+		if queued != nil {
+			return queued
+		}
+		return done
+	}
+}
+
+// handleBulkRejected reports rejected changes and reloads the data to undo
+// their optimistic updates.
+func (h *Handler) handleBulkRejected(msg bulkRejectedMsg) tea.Cmd {
+	h.Loading = false
+	h.StatusMsg = fmt.Sprintf("%d change(s) rejected: %s", msg.count, utils.ErrorMessage(msg.err))
+
+	cmds := []tea.Cmd{h.syncData()}
+	switch {
+	case msg.queueErr != nil:
+		h.Err = msg.queueErr
+		h.StatusMsg += "; " + utils.ErrorMessage(msg.queueErr)
+	case msg.queued > 0:
+		h.StatusMsg += fmt.Sprintf("; offline: %d change(s) queued", h.Outbox.Len())
+		cmds = append(cmds, h.scheduleOutboxRetry())
+	}
+	return tea.Batch(cmds...)
+}
+
+// queueOfflineCreate is like queueOffline for item_add commands. The task is
+// inserted locally under its temp ID until the outbox is flushed.
+func queueOfflineCreate(outbox *cache.Outbox, err error, cmd api.SyncCommand, task api.Task) tea.Msg {
+	msg := queueOffline(outbox, err, cmd)
+	if queued, ok := msg.(outboxQueuedMsg); ok {
+		task.ID = cmd.TempID
+		queued.created = []api.Task{task}
+		return queued
+	}
+	return msg
+}
+
+// handleOutboxQueued applies offline-created tasks and schedules a retry.
+func (h *Handler) handleOutboxQueued(msg outboxQueuedMsg) tea.Cmd {
+	h.Loading = false
+
+	if len(msg.created) > 0 {
+		h.AllTasks = append(h.AllTasks, msg.created...)
+		h.refilterCurrentView()
+		h.rebuildSidebarCounts()
+		h.DataVersion++
+	}
+
+	if h.TaskForm != nil && len(msg.created) > 0 {
+		h.CurrentView = h.PreviousView
+		h.TaskForm = nil
+	}
+
+	h.StatusMsg = fmt.Sprintf("Offline: %d change(s) queued", h.Outbox.Len())
+	return h.scheduleOutboxRetry()
+}
+
+// scheduleOutboxRetry starts the retry timer unless one is already pending.
+func (h *Handler) scheduleOutboxRetry() tea.Cmd {
+	if h.OutboxRetryPending {
+		return nil
+	}
+	h.OutboxRetryPending = true
+	return tea.Tick(outboxRetryInterval, func(time.Time) tea.Msg {
+		return outboxRetryMsg{}
+	})
+}
+
+// handleOutboxRetry flushes the outbox if anything is still queued.
+func (h *Handler) handleOutboxRetry() tea.Cmd {
+	h.OutboxRetryPending = false
+	if h.Outbox == nil || h.Outbox.Len() == 0 {
+		return nil
+	}
+	return h.flushOutbox()
+}
+
+// flushOutbox replays queued commands in order.
+func (h *Handler) flushOutbox() tea.Cmd {
+	ctx, client, outbox := h.Context(), h.Client, h.Outbox
+	return h.accountChange(func() tea.Msg {
+		result, err := outbox.Flush(ctx, client)
+		return outboxFlushedMsg{result: result, err: err}
+	})
+}
+
+// handleOutboxFlushed resolves temp IDs and refreshes from the server.
+func (h *Handler) handleOutboxFlushed(msg outboxFlushedMsg) tea.Cmd {
+	if msg.result != nil {
+		h.resolveTempIDs(msg.result.TempIDMapping)
+	}
+
+	if msg.err != nil {
+		if api.IsNetworkError(msg.err) {
+			// Still offline; keep the queue and try again later
+			return h.scheduleOutboxRetry()
+		}
+		h.Err = msg.err
+		h.StatusMsg = utils.ErrorMessage(msg.err)
+		return nil
+	}
+
+	if msg.result == nil || msg.result.Sent+len(msg.result.Failed) == 0 {
+		return nil
+	}
+
+	if len(msg.result.Failed) > 0 {
+		h.StatusMsg = fmt.Sprintf("Synced %d queued change(s), %d rejected: %v",
+			msg.result.Sent, len(msg.result.Failed), msg.result.Failed[0].Err)
+	} else {
+		h.StatusMsg = fmt.Sprintf("Synced %d queued change(s)", msg.result.Sent)
+	}
+
+	// Pull the authoritative state now that the server has our changes
+	return h.syncData()
+}
+
+// resolveTempIDs replaces temp IDs of tasks created offline with their real IDs.
+func (h *Handler) resolveTempIDs(mapping map[string]string) {
+	if len(mapping) == 0 {
+		return
+	}
+
+	resolve := func(t *api.Task) {
+		if realID, ok := mapping[t.ID]; ok {
+			t.ID = realID
+		}
+		if t.ParentID != nil {
+			if realID, ok := mapping[*t.ParentID]; ok {
+				t.ParentID = &realID
+			}
+		}
+	}
+
+	for i := range h.AllTasks {
+		resolve(&h.AllTasks[i])
+	}
+	for i := range h.Tasks {
+		resolve(&h.Tasks[i])
+	}
+	if h.SelectedTask != nil {
+		resolve(h.SelectedTask)
+	}
+	for tempID, realID := range mapping {
+		if h.SelectedTaskIDs[tempID] {
+			delete(h.SelectedTaskIDs, tempID)
+			h.SelectedTaskIDs[realID] = true
+		}
+	}
+
+	h.DataVersion++
+}
+
+// taskFromCreateRequest builds the local placeholder for a task created offline.
+func taskFromCreateRequest(req api.CreateTaskRequest) api.Task {
+	task := api.Task{
+		Content:     req.Content,
+		Description: req.Description,
+		ProjectID:   req.ProjectID,
+		Labels:      req.Labels,
+		Priority:    req.Priority,
+	}
+	if task.Priority == 0 {
+		task.Priority = 1
+	}
+	if req.SectionID != "" {
+		sectionID := req.SectionID
+		task.SectionID = &sectionID
+	}
+	if req.ParentID != "" {
+		parentID := req.ParentID
+		task.ParentID = &parentID
+	}
+
+	// Only concrete dates can be shown before the server parses natural language
+	date := req.DueDate
+	if date == "" && len(req.DueDatetime) >= 10 {
+		date = req.DueDatetime[:10]
+	}
+	if date != "" {
+		task.Due = &api.Due{Date: date, String: req.DueString}
+		if parsed, err := time.ParseInLocation("2006-01-02", date, time.Local); err == nil {
+			task.ParsedDate = &parsed
+		}
+	}
+	if req.Duration > 0 && req.DurationUnit != "" {
+		task.Duration = &api.Duration{Amount: req.Duration, Unit: req.DurationUnit}
+	}
+	if req.DeadlineDate != "" {
+		task.Deadline = &api.Deadline{Date: req.DeadlineDate}
+	}
+	if req.AssigneeID != "" {
+		assignee := req.AssigneeID
+		task.ResponsibleUID = &assignee
+	}
+
+	return task
+}
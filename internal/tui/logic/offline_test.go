@@ -0,0 +1,145 @@
+package logic
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/cache"
+	"github.com/hy4ri/todoist-tui/internal/tui/components"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+)
+
+// offlineTransport fails every request as if the network were down.
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("dial tcp: network is unreachable")
+}
+
+func TestHandleComplete_QueuesWhenOffline(t *testing.T) {
+	client := api.NewClient("test-token")
+	client.MaxRetries = 1
+	client.RetryBaseDelay = 1
+	client.SetHTTPClient(&http.Client{Transport: offlineTransport{}})
+
+	s := &state.State{
+		Client:   client,
+		Outbox:   cache.NewOutbox(t.TempDir()),
+		Tasks:    []api.Task{{ID: "t1", Content: "Offline"}},
+		AllTasks: []api.Task{{ID: "t1", Content: "Offline"}},
+		SelectionState: state.SelectionState{
+			SelectedTaskIDs: make(map[string]bool),
+		},
+		CurrentTab:  state.TabInbox,
+		FocusedPane: state.PaneMain,
+		CurrentView: state.ViewInbox,
+		SidebarComp: components.NewSidebar(),
+	}
+	h := NewHandler(s)
+
+	cmd := h.handleComplete()
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+
+	msg, ok := changeResult(t, cmd()).(undoableMsg)
+	if !ok {
+		t.Fatalf("expected undoableMsg, got %T", msg)
+	}
+	if _, ok := msg.msg.(outboxQueuedMsg); !ok {
+		t.Fatalf("expected outboxQueuedMsg, got %T", msg.msg)
+	}
+	h.Update(msg)
+	// A queued change still reaches the server, so it can be undone
+	if len(s.UndoHistory.Undos()) != 1 {
+		t.Errorf("expected the queued change in the undo history, got %d entries", len(s.UndoHistory.Undos()))
+	}
+
+	if s.Outbox.Len() != 1 {
+		t.Fatalf("expected 1 queued command, got %d", s.Outbox.Len())
+	}
+	if queued := s.Outbox.Commands()[0]; queued.Type != "item_close" || queued.UUID == "" {
+		t.Errorf("unexpected queued command: %+v", queued)
+	}
+	if len(s.AllTasks) != 0 {
+		t.Errorf("expected optimistic completion to be kept, got %d tasks", len(s.AllTasks))
+	}
+	if !s.OutboxRetryPending {
+		t.Error("expected a retry to be scheduled")
+	}
+}
+
+func TestResolveTempIDs(t *testing.T) {
+	parent := "temp-1"
+	s := &state.State{
+		AllTasks: []api.Task{
+			{ID: "temp-1", Content: "Parent"},
+			{ID: "t2", Content: "Child", ParentID: &parent},
+		},
+		Tasks: []api.Task{{ID: "temp-1", Content: "Parent"}},
+		SelectionState: state.SelectionState{
+			SelectedTaskIDs: map[string]bool{"temp-1": true},
+		},
+	}
+	h := NewHandler(s)
+
+	h.resolveTempIDs(map[string]string{"temp-1": "real-1"})
+
+	if s.AllTasks[0].ID != "real-1" || s.Tasks[0].ID != "real-1" {
+		t.Errorf("expected task IDs to be resolved, got %s / %s", s.AllTasks[0].ID, s.Tasks[0].ID)
+	}
+	if s.AllTasks[1].ParentID == nil || *s.AllTasks[1].ParentID != "real-1" {
+		t.Errorf("expected parent ID to be resolved, got %v", s.AllTasks[1].ParentID)
+	}
+	if !s.SelectedTaskIDs["real-1"] || s.SelectedTaskIDs["temp-1"] {
+		t.Errorf("expected selection to follow the real ID, got %v", s.SelectedTaskIDs)
+	}
+}
+
+// dropAfterTransport lets the first n requests through, then fails the rest
+// as if the network went down.
+type dropAfterTransport struct{ n int }
+
+func (t *dropAfterTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if t.n == 0 {
+		return offlineTransport{}.RoundTrip(r)
+	}
+	t.n--
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestSendBulk_QueuesOfflineCommandsAlongsideRejections(t *testing.T) {
+	h, _ := newFakeHandler(t)
+	h.Outbox = cache.NewOutbox(t.TempDir())
+	h.Client.MaxRetries = 1
+	h.Client.RetryBaseDelay = 1
+	h.Client.SetHTTPClient(&http.Client{Transport: &dropAfterTransport{n: 1}})
+
+	// The first batch reaches the server, which rejects the unknown tasks;
+	// the network is down for the second
+	cmds := make([]api.SyncCommand, api.MaxCommandsPerSync+1)
+	for i := range cmds {
+		cmds[i] = api.CloseTaskCommand("missing")
+	}
+	msg, ok := h.sendBulk(cmds, taskUpdatedMsg{})().(bulkRejectedMsg)
+	if !ok {
+		t.Fatalf("expected bulkRejectedMsg, got %T", msg)
+	}
+	if msg.count != api.MaxCommandsPerSync || msg.queued != 1 {
+		t.Errorf("got %d rejected and %d queued", msg.count, msg.queued)
+	}
+	if h.Outbox.Len() != 1 {
+		t.Errorf("expected the offline command to be queued, got %d", h.Outbox.Len())
+	}
+
+	h.Update(msg)
+	if !strings.Contains(h.StatusMsg, "100 change(s) rejected") || !strings.Contains(h.StatusMsg, "1 change(s) queued") {
+		t.Errorf("status = %q", h.StatusMsg)
+	}
+	if !h.OutboxRetryPending {
+		t.Error("expected a retry to be scheduled")
+	}
+}
@@ -0,0 +1,144 @@
+package logic
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/cache"
+	"github.com/hy4ri/todoist-tui/internal/config"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
+)
+
+func handleProfileCommand(h *Handler, args []string) tea.Cmd {
+	current := config.CurrentProfile()
+	if len(args) == 0 {
+		h.StatusMsg = fmt.Sprintf("Profile: %s (available: %s)", current, strings.Join(h.Config.ProfileNames(), ", "))
+		return nil
+	}
+
+	name := args[0]
+	if !h.Config.HasProfile(name) {
+		h.StatusMsg = fmt.Sprintf("Unknown profile: %s", name)
+		return nil
+	}
+	if name == current {
+		h.StatusMsg = fmt.Sprintf("Already using profile %s", name)
+		return nil
+	}
+	return h.switchProfile(name)
+}
+
+// switchProfile signs in to the account of profile and reloads everything
+// from its cache and the server.
+func (h *Handler) switchProfile(profile string) tea.Cmd {
+	if h.NewClient == nil {
+		h.StatusMsg = "Switching profiles is not available"
+		return nil
+	}
+
+	previous := config.CurrentProfile()
+	if err := config.SetProfile(profile); err != nil {
+		h.StatusMsg = err.Error()
+		return nil
+	}
+	client, err := h.NewClient()
+	if err != nil {
+		// Stay signed in to the previous account
+		_ = config.SetProfile(previous)
+		h.StatusMsg = fmt.Sprintf("Cannot switch to %s: %s", profile, utils.ErrorMessage(err))
+		return nil
+	}
+
+	// Nothing loaded for the previous account may land in the new one
+	h.CancelAccountLoads()
+	h.Client = client
+	h.SyncClient = api.NewSyncClient(client)
+	h.Cache, h.Outbox = nil, nil
+	if dataDir, err := config.DataDir(); err == nil {
+		h.Cache = cache.NewStore(dataDir)
+		h.Outbox = cache.NewOutbox(dataDir)
+	}
+	h.resetAccountData()
+
+	if tab, ok := tabForView(h.Config.DefaultViewFor(profile)); ok {
+		h.switchToTab(tab)
+	}
+
+	restored := h.restoreCache()
+	h.Loading = true
+	load := h.LoadInitialData()
+	if h.Outbox != nil && h.Outbox.Len() > 0 {
+		load = tea.Sequence(h.flushOutbox(), load)
+	}
+
+	h.StatusMsg = fmt.Sprintf("Switched to profile %s", profile)
+	return tea.Batch(restored, load)
+}
+
+// resetAccountData forgets everything loaded for the current account.
+func (h *Handler) resetAccountData() {
+	h.clearSelection()
+	if h.ShowDetailPanel {
+		h.ShowDetailPanel = false
+		h.DetailComp.Hide()
+	}
+
+	h.Projects = nil
+	h.Tasks = nil
+	h.AllTasks = nil
+	h.Sections = nil
+	h.AllSections = nil
+	h.Labels = nil
+	h.Filters = nil
+	h.Reminders = nil
+	h.Comments = nil
+	h.CompletedTasks = nil
+	h.SearchResults = []api.Task{}
+	h.TasksByDate = nil
+	h.DeadlinesByDate = nil
+	h.CommentCache = nil
+	h.ReminderCache = nil
+	h.ProductivityStats = nil
+	h.StatsError = ""
+	h.User = nil
+	h.Collaborators = nil
+
+	h.CurrentProject = nil
+	h.CurrentLabel = nil
+	h.CurrentFilter = nil
+	h.LastSelectedTask = nil
+	h.PomodoroTask = nil
+	h.TaskCursor = 0
+	h.ProjectCursor = 0
+	h.SidebarCursor = 0
+	h.LastDataFetch = time.Time{}
+
+	// Undoing would send the previous account's changes to the new one
+	h.UndoHistory = state.UndoHistory{}
+	h.NotifiedTasks = make(map[string]bool)
+}
+
+// tabForView returns the tab of a default_view name.
+func tabForView(viewName string) (state.Tab, bool) {
+	switch viewName {
+	case "inbox":
+		return state.TabInbox, true
+	case "today":
+		return state.TabToday, true
+	case "upcoming":
+		return state.TabUpcoming, true
+	case "labels":
+		return state.TabLabels, true
+	case "calendar":
+		return state.TabCalendar, true
+	case "projects":
+		return state.TabProjects, true
+	case "filters":
+		return state.TabFilters, true
+	}
+	return 0, false
+}
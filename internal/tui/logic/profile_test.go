@@ -0,0 +1,106 @@
+package logic
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/config"
+	"github.com/hy4ri/todoist-tui/internal/fake"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+)
+
+func TestSwitchProfile(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	t.Cleanup(func() { config.SetProfile(config.DefaultProfile) })
+
+	h, personal := newFakeHandler(t)
+	milk := personal.AddTask(api.Task{Content: "Buy milk", ProjectID: "p1"})
+	loadFakeProject(t, h)
+	h.UndoHistory.Record(state.UndoEntry{Description: "Complete task", Undo: []api.SyncCommand{{Type: "item_uncomplete"}}})
+
+	work := fake.New()
+	work.AddTask(api.Task{Content: "Ship release"})
+	workClient := newFakeClient(t, work)
+
+	h.Config = &config.Config{Profiles: map[string]config.ProfileConfig{
+		"work": {DefaultView: "today"},
+	}}
+	h.NewClient = func() (*api.Client, error) {
+		return workClient, nil
+	}
+
+	handleProfileCommand(h, []string{"unknown"})
+	if h.StatusMsg != "Unknown profile: unknown" {
+		t.Errorf("StatusMsg = %q", h.StatusMsg)
+	}
+
+	// A sync and a change of the previous account still running when the
+	// profile changes
+	h.SyncClient = api.NewSyncClient(h.Client)
+	stale := h.syncData()
+	h.TaskCursor = 0
+	pending := h.handleComplete()
+
+	cmd := handleProfileCommand(h, []string{"work"})
+	if cmd == nil {
+		t.Fatal("expected a load command")
+	}
+	if config.CurrentProfile() != "work" {
+		t.Errorf("active profile = %q", config.CurrentProfile())
+	}
+	if len(h.AllTasks) != 0 || h.CurrentProject != nil || len(h.UndoHistory.Undos()) != 0 {
+		t.Error("data of the previous account was kept")
+	}
+	if h.CurrentTab != state.TabToday {
+		t.Errorf("tab = %v, want the profile's default view", h.CurrentTab)
+	}
+	if _, err := os.Stat(filepath.Join(dataHome, "todoist-tui", "profiles", "work")); err != nil {
+		t.Errorf("profile data directory: %v", err)
+	}
+
+	h.Update(cmd())
+	if h.Err != nil {
+		t.Fatalf("load failed: %v", h.Err)
+	}
+	if len(h.AllTasks) != 1 || h.AllTasks[0].Content != "Ship release" {
+		t.Errorf("unexpected tasks after switching: %+v", h.AllTasks)
+	}
+
+	h.Update(stale())
+	if len(h.AllTasks) != 1 || h.AllTasks[0].Content != "Ship release" {
+		t.Errorf("a sync of the previous account replaced the tasks: %+v", h.AllTasks)
+	}
+	h.Update(pending())
+	if got, _ := personal.Task(milk.ID); !got.Checked {
+		t.Error("the change was not sent to the previous account")
+	}
+	if len(h.UndoHistory.Undos()) != 0 || len(h.AllTasks) != 1 {
+		t.Errorf("a change of the previous account landed in the new one: %+v", h.UndoHistory.Undos())
+	}
+	if snap, err := h.Cache.Load(); err != nil || snap == nil || len(snap.Tasks) != 1 || snap.Tasks[0].Content != "Ship release" {
+		t.Errorf("unexpected cache of the new profile: %+v, %v", snap, err)
+	}
+}
+
+func TestSwitchProfile_ClientError(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Cleanup(func() { config.SetProfile(config.DefaultProfile) })
+
+	h, _ := newFakeHandler(t)
+	client := h.Client
+	h.Config = &config.Config{Profiles: map[string]config.ProfileConfig{"work": {}}}
+	h.NewClient = func() (*api.Client, error) {
+		return nil, errors.New("no token")
+	}
+
+	if cmd := handleProfileCommand(h, []string{"work"}); cmd != nil {
+		t.Error("expected no command")
+	}
+	if config.CurrentProfile() != config.DefaultProfile || h.Client != client {
+		t.Error("a failed switch changed the account")
+	}
+}
@@ -0,0 +1,102 @@
+package logic
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
+)
+
+// nextDue returns the due date a recurring task moves to when this
+// occurrence is completed on now, with its local parse. It reports false
+// if the task doesn't recur or its rule can't be read locally.
+func nextDue(t api.Task, now time.Time) (*api.Due, time.Time, bool) {
+	if t.Due == nil || !t.Due.IsRecurring {
+		return nil, time.Time{}, false
+	}
+	r, ok := utils.ParseRecurrence(t.Due.String)
+	if !ok {
+		return nil, time.Time{}, false
+	}
+
+	from := now
+	if !r.AfterCompletion && len(t.Due.Date) >= 10 {
+		if current, err := time.ParseInLocation(utils.DateLayout, t.Due.Date[:10], time.Local); err == nil {
+			from = current
+		}
+	}
+	next := r.Next(from)
+	// Skip the occurrences missed while the task was overdue
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	for !next.After(today) {
+		next = r.Next(next)
+	}
+
+	due := copyDue(t.Due)
+	due.Date = next.Format(utils.DateLayout)
+	parsed := next
+	if t.Due.Datetime != nil && *t.Due.Datetime != "" {
+		if at, err := time.Parse(time.RFC3339, *t.Due.Datetime); err == nil {
+			at = at.Local()
+			parsed = time.Date(next.Year(), next.Month(), next.Day(), at.Hour(), at.Minute(), 0, 0, time.Local)
+			datetime := parsed.UTC().Format(time.RFC3339)
+			due.Datetime = &datetime
+		}
+	}
+	return due, parsed, true
+}
+
+// handleCompleteForever completes the selected tasks, or the task under the
+// cursor, for good: recurring tasks are closed instead of moving to their
+// next occurrence.
+func (h *Handler) handleCompleteForever() tea.Cmd {
+	if h.CurrentTab == state.TabProjects && h.FocusedPane != state.PaneMain {
+		return nil
+	}
+	if h.CurrentView == state.ViewLabels && h.CurrentLabel == nil {
+		return nil
+	}
+
+	var targets []api.Task
+	if len(h.SelectedTaskIDs) > 0 {
+		for _, t := range h.Tasks {
+			if h.SelectedTaskIDs[t.ID] && !t.Checked {
+				targets = append(targets, t)
+			}
+		}
+	} else if task := h.getSelectedTask(); task != nil && !task.Checked {
+		targets = append(targets, *task)
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	ids := make(map[string]bool, len(targets))
+	var undo, redo []api.SyncCommand
+	for _, t := range targets {
+		ids[t.ID] = true
+		undo = append(undo, api.ReopenTaskCommand(t.ID))
+		redo = append(redo, api.CompleteTaskCommand(t.ID, ""))
+	}
+	entry := undoEntry(describeChange("Complete permanently", targets), undo, redo)
+
+	// Optimistic update
+	h.AllTasks = slices.DeleteFunc(h.AllTasks, func(t api.Task) bool { return ids[t.ID] })
+	h.Tasks = slices.DeleteFunc(h.Tasks, func(t api.Task) bool { return ids[t.ID] })
+	h.clearSelection()
+	if h.TaskCursor >= len(h.Tasks) {
+		h.TaskCursor = max(0, len(h.Tasks)-1)
+	}
+	h.rebuildSidebarCounts()
+
+	h.StatusMsg = describeChange("Completed", targets) + " permanently"
+
+	cmds := make([]api.SyncCommand, len(targets))
+	for i, t := range targets {
+		cmds[i] = api.CompleteTaskCommand(t.ID, "")
+	}
+	return h.undoable(entry, h.sendBulk(cmds, taskCompletedMsg{id: targets[0].ID}))
+}
@@ -0,0 +1,87 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+func TestNextDue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local) // Tuesday
+
+	tests := []struct {
+		rule string
+		date string
+		want string
+	}{
+		{"every monday", "2026-03-09", "2026-03-16"},
+		{"every day", "2026-03-10", "2026-03-11"},
+		{"every day", "2026-03-01", "2026-03-11"}, // Overdue occurrences are skipped
+		{"every! 3 days", "2026-03-01", "2026-03-13"},
+	}
+	for _, tt := range tests {
+		task := api.Task{Due: &api.Due{String: tt.rule, Date: tt.date, IsRecurring: true}}
+		due, _, ok := nextDue(task, now)
+		if !ok || due.Date != tt.want || due.String != tt.rule {
+			t.Errorf("nextDue(%q from %s) = %+v, %v, want %s", tt.rule, tt.date, due, ok, tt.want)
+		}
+	}
+
+	if _, _, ok := nextDue(api.Task{Due: &api.Due{String: "every 3rd friday", Date: "2026-03-20", IsRecurring: true}}, now); ok {
+		t.Error("expected no local next date for an unsupported rule")
+	}
+}
+
+func TestE2E_CompleteForever(t *testing.T) {
+	h, srv := newFakeHandler(t)
+	task := srv.AddTask(api.Task{Content: "Water the plants", ProjectID: "p1",
+		Due: &api.Due{String: "every day", Date: "2030-01-01", IsRecurring: true}})
+
+	loadFakeProject(t, h)
+
+	cmd := h.handleCompleteForever()
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	if len(h.Tasks) != 0 {
+		t.Errorf("expected the task to be removed optimistically, got %d tasks", len(h.Tasks))
+	}
+	cmd()
+	got, _ := srv.Task(task.ID)
+	if !got.Checked || got.Due == nil || got.Due.Date != "2030-01-01" {
+		t.Errorf("expected the task completed without moving, got checked=%v due=%+v", got.Checked, got.Due)
+	}
+}
+
+func TestCompleteRecurringMovesToNextDate(t *testing.T) {
+	h := newTasksHandler(api.Task{ID: "t1", Content: "Weekly sync", ProjectID: "p1",
+		Due: &api.Due{String: "every week", Date: "2030-01-07", IsRecurring: true}})
+
+	if h.handleComplete() == nil {
+		t.Fatal("expected a command")
+	}
+	if len(h.Tasks) != 1 || h.Tasks[0].Due.Date != "2030-01-14" {
+		t.Fatalf("expected the task to move to its next date, got %+v", h.Tasks)
+	}
+	if want := "Completed 'Weekly sync', next on Mon Jan 14"; h.StatusMsg != want {
+		t.Errorf("status = %q, want %q", h.StatusMsg, want)
+	}
+}
+
+func TestRescheduleRecurringKeepsRule(t *testing.T) {
+	h := newTasksHandler(api.Task{ID: "t1", Content: "Weekly sync", ProjectID: "p1",
+		Due: &api.Due{String: "every monday", Date: "2030-01-07", IsRecurring: true}})
+
+	if h.handleMoveTaskDate(1, "") == nil {
+		t.Fatal("expected a command")
+	}
+	if due := h.Tasks[0].Due; due.Date != "2030-01-08" || !due.IsRecurring || due.String != "every monday" {
+		t.Errorf("expected the occurrence moved with its rule kept, got %+v", due)
+	}
+
+	args := rescheduleCommand("t1", h.Tasks[0].Due, "2030-01-08").Args.(map[string]interface{})
+	if due, _ := args["due"].(map[string]interface{}); due["date"] != "2030-01-08" || due["string"] != "every monday" {
+		t.Errorf("expected the rule to be sent with the new date, got %+v", args["due"])
+	}
+}
@@ -0,0 +1,361 @@
+package logic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+)
+
+// historyAppliedMsg carries the result of replaying undo or redo commands.
+type historyAppliedMsg struct {
+	entries []state.UndoEntry
+	redo    bool
+	// mapping maps IDs of recreated tasks to the IDs the server gave them.
+	mapping map[string]string
+	// sent counts commands the server processed; failed those it rejected.
+	sent   int
+	failed int
+	err    error
+}
+
+// undoableMsg is the result of a change that succeeded or was queued
+// offline, along with its undo history entry.
+type undoableMsg struct {
+	entry state.UndoEntry
+	msg   tea.Msg
+}
+
+// undoEntry describes a change for the undo history.
+func undoEntry(description string, undo, redo []api.SyncCommand) state.UndoEntry {
+	return state.UndoEntry{
+		Description: description,
+		Undo:        undo,
+		Redo:        redo,
+		At:          time.Now(),
+	}
+}
+
+// undoable records entry in the undo history once cmd, which applies the
+// change, succeeds or queues it offline. A failed or rejected change is not
+// recorded, since there is nothing to undo, and neither is one that finishes
+// after a profile switch, as cmd is an accountChange.
+func (h *Handler) undoable(entry state.UndoEntry, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return h.accountChange(func() tea.Msg {
+		msg := cmd()
+		switch msg.(type) {
+		case errMsg, bulkRejectedMsg:
+			return msg
+		}
+		return undoableMsg{entry: entry, msg: msg}
+	})
+}
+
+// handleUndoable records a change that went through and handles its result.
+func (h *Handler) handleUndoable(msg undoableMsg) tea.Cmd {
+	h.UndoHistory.Record(msg.entry)
+	return h.Update(msg.msg)
+}
+
+// recordCreated records the creation of task so it can be undone by deleting it.
+func (h *Handler) recordCreated(task *api.Task) {
+	if task == nil {
+		return
+	}
+	restore := api.RestoreTaskCommand(*task)
+	h.UndoHistory.Record(state.UndoEntry{
+		Description: fmt.Sprintf("Add '%s'", task.Content),
+		Undo:        []api.SyncCommand{api.DeleteTaskCommand(task.ID)},
+		Redo:        []api.SyncCommand{restore},
+		Restores:    map[string]string{restore.TempID: task.ID},
+	})
+}
+
+// describeChange returns a history description such as "Complete 'Buy milk'"
+// or "Complete 3 tasks".
+func describeChange(verb string, tasks []api.Task) string {
+	if len(tasks) == 1 {
+		return fmt.Sprintf("%s '%s'", verb, tasks[0].Content)
+	}
+	return fmt.Sprintf("%s %d tasks", verb, len(tasks))
+}
+
+// moveBackCommand builds a command that returns t to where it was, under its
+// parent, in its section, or at the root of its project.
+func moveBackCommand(t api.Task) api.SyncCommand {
+	projectID := t.ProjectID
+	return api.MoveTaskCommand(t.ID, t.SectionID, &projectID, t.ParentID)
+}
+
+// toggleCompleteCommands returns the commands that undo and redo toggling
+// the completion of t. Completing a recurring task only moves it to its next
+// occurrence, so undoing that restores the previous due date instead.
+func toggleCompleteCommands(t api.Task) (undo, redo api.SyncCommand) {
+	switch {
+	case t.Checked:
+		return api.CloseTaskCommand(t.ID), api.ReopenTaskCommand(t.ID)
+	case t.Due != nil && t.Due.IsRecurring:
+		return api.SetDueCommand(t.ID, copyDue(t.Due)), api.CloseTaskCommand(t.ID)
+	default:
+		return api.ReopenTaskCommand(t.ID), api.CloseTaskCommand(t.ID)
+	}
+}
+
+// copyDue returns a copy of due, which is otherwise shared between the
+// AllTasks and Tasks slices and modified in place by optimistic updates.
+func copyDue(due *api.Due) *api.Due {
+	if due == nil {
+		return nil
+	}
+	d := *due
+	return &d
+}
+
+// restoreTasksCommands builds commands that recreate deleted tasks together
+// with their subtasks, which the server deletes along with their parent.
+// Parents are created before their children and referenced by temp ID.
+func restoreTasksCommands(deleted []api.Task, allTasks []api.Task) ([]api.SyncCommand, map[string]string) {
+	children := make(map[string][]api.Task)
+	for _, t := range allTasks {
+		if t.ParentID != nil {
+			children[*t.ParentID] = append(children[*t.ParentID], t)
+		}
+	}
+
+	var cmds []api.SyncCommand
+	restores := make(map[string]string)
+	tempIDs := make(map[string]string)
+
+	var restore func(t api.Task)
+	restore = func(t api.Task) {
+		if _, done := tempIDs[t.ID]; done {
+			return
+		}
+		if t.ParentID != nil {
+			if tempID, ok := tempIDs[*t.ParentID]; ok {
+				t.ParentID = &tempID
+			}
+		}
+		cmd := api.RestoreTaskCommand(t)
+		cmds = append(cmds, cmd)
+		tempIDs[t.ID] = cmd.TempID
+		restores[cmd.TempID] = t.ID
+
+		for _, child := range children[t.ID] {
+			restore(child)
+		}
+	}
+
+	deletedIDs := make(map[string]bool)
+	for _, t := range deleted {
+		deletedIDs[t.ID] = true
+	}
+	// Start from tasks whose parent is not deleted as well, so the tree is
+	// recreated top-down
+	for _, t := range deleted {
+		if t.ParentID == nil || !deletedIDs[*t.ParentID] {
+			restore(t)
+		}
+	}
+
+	return cmds, restores
+}
+
+// handleUndo reverts the last n changes.
+func (h *Handler) handleUndo(n int) tea.Cmd {
+	entries := h.UndoHistory.TakeUndo(n)
+	if len(entries) == 0 {
+		h.StatusMsg = "Nothing to undo"
+		return nil
+	}
+	h.Loading = true
+	h.StatusMsg = "Undoing..."
+	return h.applyHistory(entries, false)
+}
+
+// handleRedo re-applies the last n undone changes.
+func (h *Handler) handleRedo(n int) tea.Cmd {
+	entries := h.UndoHistory.TakeRedo(n)
+	if len(entries) == 0 {
+		h.StatusMsg = "Nothing to redo"
+		return nil
+	}
+	h.Loading = true
+	h.StatusMsg = "Redoing..."
+	return h.applyHistory(entries, true)
+}
+
+// applyHistory sends the undo (or redo) commands of entries in order.
+func (h *Handler) applyHistory(entries []state.UndoEntry, redo bool) tea.Cmd {
+	ctx, client := h.Context(), h.Client
+	return h.accountChange(func() tea.Msg {
+		var cmds []api.SyncCommand
+		// restores maps the temp IDs actually sent to the task they recreate
+		restores := make(map[string]string)
+
+		for _, e := range entries {
+			list := e.Undo
+			if redo {
+				list = e.Redo
+			}
+			// Commands may have been sent before; fresh UUIDs keep the server
+			// from discarding them as duplicates
+			renewed, tempIDs := api.RenewCommands(list)
+			for oldTempID, newTempID := range tempIDs {
+				if taskID, ok := e.Restores[oldTempID]; ok {
+					restores[newTempID] = taskID
+				}
+			}
+			cmds = append(cmds, renewed...)
+		}
+
+		msg := historyAppliedMsg{entries: entries, redo: redo, mapping: make(map[string]string)}
+		resolved := make(map[string]string)
+
+		for len(cmds) > 0 {
+			n := min(len(cmds), api.MaxCommandsPerSync)
+			batch := make([]api.SyncCommand, n)
+			for i, cmd := range cmds[:n] {
+				batch[i] = api.ReplaceCommandIDs(cmd, resolved)
+			}
+
+			resp, err := client.ExecuteCommands(ctx, batch)
+			if err != nil {
+				msg.err = err
+				return msg
+			}
+
+			for tempID, realID := range resp.TempIDMapping {
+				resolved[tempID] = realID
+				if taskID, ok := restores[tempID]; ok {
+					msg.mapping[taskID] = realID
+				}
+			}
+			for _, cmd := range batch {
+				if cmdErr := resp.CommandError(cmd.UUID); cmdErr != nil {
+					msg.failed++
+					if msg.err == nil {
+						msg.err = cmdErr
+					}
+				}
+			}
+			msg.sent += n
+			cmds = cmds[n:]
+		}
+
+		return msg
+	})
+}
+
+// handleHistoryApplied moves replayed entries to the opposite stack and
+// refreshes from the server.
+func (h *Handler) handleHistoryApplied(msg historyAppliedMsg) tea.Cmd {
+	h.Loading = false
+
+	verb := "Undo"
+	if msg.redo {
+		verb = "Redo"
+	}
+
+	// Nothing was processed: put the entries back where they came from
+	if msg.sent == 0 {
+		restored := make([]state.UndoEntry, len(msg.entries))
+		for i, e := range msg.entries {
+			restored[len(msg.entries)-1-i] = e
+		}
+		if msg.redo {
+			h.UndoHistory.PushRedo(restored...)
+		} else {
+			h.UndoHistory.PushUndo(restored...)
+		}
+		h.StatusMsg = fmt.Sprintf("%s failed: %v", verb, msg.err)
+		return nil
+	}
+
+	if msg.redo {
+		h.UndoHistory.PushUndo(msg.entries...)
+	} else {
+		h.UndoHistory.PushRedo(msg.entries...)
+	}
+	h.UndoHistory.Remap(msg.mapping)
+
+	switch {
+	case msg.failed > 0:
+		h.StatusMsg = fmt.Sprintf("%s: %d change(s) failed: %v", verb, msg.failed, msg.err)
+	case msg.err != nil:
+		h.StatusMsg = fmt.Sprintf("%s only partially applied: %v", verb, msg.err)
+	case len(msg.entries) == 1:
+		h.StatusMsg = fmt.Sprintf("%s: %s", verb, msg.entries[0].Description)
+	default:
+		h.StatusMsg = fmt.Sprintf("%s: %d changes", verb, len(msg.entries))
+	}
+
+	h.Loading = true
+	return h.syncData()
+}
+
+// parseHistoryCount parses the optional count argument of :undo and :redo.
+func parseHistoryCount(args []string) (int, error) {
+	if len(args) == 0 {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid count: %s", args[0])
+	}
+	return n, nil
+}
+
+func handleUndoCommand(h *Handler, args []string) tea.Cmd {
+	n, err := parseHistoryCount(args)
+	if err != nil {
+		h.StatusMsg = "Usage: :undo [N]"
+		return nil
+	}
+	return h.handleUndo(n)
+}
+
+func handleRedoCommand(h *Handler, args []string) tea.Cmd {
+	n, err := parseHistoryCount(args)
+	if err != nil {
+		h.StatusMsg = "Usage: :redo [N]"
+		return nil
+	}
+	return h.handleRedo(n)
+}
+
+func handleHistoryCommand(h *Handler, args []string) tea.Cmd {
+	undos := h.UndoHistory.Undos()
+	redos := h.UndoHistory.Redos()
+	if len(undos) == 0 && len(redos) == 0 {
+		h.StatusMsg = "History is empty"
+		return nil
+	}
+
+	const maxShown = 5
+	var parts []string
+	for i, e := range undos {
+		if i == maxShown {
+			parts = append(parts, fmt.Sprintf("+%d more", len(undos)-maxShown))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%d. %s (%s)", i+1, e.Description, e.At.Format(time.Kitchen)))
+	}
+
+	msg := "Undo: " + strings.Join(parts, " · ")
+	if len(undos) == 0 {
+		msg = "Undo: none"
+	}
+	if len(redos) > 0 {
+		msg += fmt.Sprintf(" | Redo: %d (next: %s)", len(redos), redos[0].Description)
+	}
+	h.StatusMsg = msg
+	return nil
+}
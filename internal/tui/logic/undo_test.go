@@ -0,0 +1,184 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/components"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+)
+
+// commandRecorder is a fake /sync endpoint that accepts every command.
+type commandRecorder struct {
+	mu       sync.Mutex
+	commands []api.SyncCommand
+	created  int
+}
+
+func (rec *commandRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	if r.Form.Get("commands") == "" {
+		// Read request from the refresh after undo
+		json.NewEncoder(w).Encode(map[string]interface{}{"sync_token": "t", "full_sync": true})
+		return
+	}
+
+	var cmds []api.SyncCommand
+	json.Unmarshal([]byte(r.Form.Get("commands")), &cmds)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	mapping := map[string]string{}
+	status := map[string]string{}
+	for _, cmd := range cmds {
+		if cmd.TempID != "" {
+			rec.created++
+			mapping[cmd.TempID] = fmt.Sprintf("new-%d", rec.created)
+		}
+		status[cmd.UUID] = "ok"
+		rec.commands = append(rec.commands, cmd)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"temp_id_mapping": mapping,
+		"sync_status":     status,
+	})
+}
+
+func (rec *commandRecorder) take() []api.SyncCommand {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	cmds := rec.commands
+	rec.commands = nil
+	return cmds
+}
+
+func TestUndoRedoDelete(t *testing.T) {
+	rec := &commandRecorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/sync" {
+			rec.ServeHTTP(w, r)
+			return
+		}
+		// REST delete
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := api.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+
+	parentID := "parent"
+	tasks := []api.Task{
+		{ID: "parent", Content: "Parent", ProjectID: "p1", Priority: 4},
+		{ID: "child", Content: "Child", ProjectID: "p1", ParentID: &parentID, ChildOrder: 2},
+	}
+	s := &state.State{
+		Client:     client,
+		SyncClient: api.NewSyncClient(client),
+		Tasks:      []api.Task{tasks[0]},
+		AllTasks:   append([]api.Task(nil), tasks...),
+		SelectionState: state.SelectionState{
+			SelectedTaskIDs: make(map[string]bool),
+		},
+		CurrentTab:  state.TabInbox,
+		FocusedPane: state.PaneMain,
+		CurrentView: state.ViewInbox,
+		SidebarComp: components.NewSidebar(),
+	}
+	h := NewHandler(s)
+
+	cmd := h.handleDelete()
+	if cmd == nil {
+		t.Fatal("expected a delete command")
+	}
+	h.Update(cmd())
+	if sent := rec.take(); len(sent) != 1 || sent[0].Type != "item_delete" {
+		t.Fatalf("expected the delete to be sent as one item_delete command, got %+v", sent)
+	}
+
+	// Undo recreates the parent and its subtask under the new parent
+	h.Update(h.handleUndo(1)())
+	recreated := rec.take()
+	if len(recreated) != 2 || recreated[0].Type != "item_add" || recreated[1].Type != "item_add" {
+		t.Fatalf("expected two item_add commands, got %+v", recreated)
+	}
+	childArgs := recreated[1].Args.(map[string]interface{})
+	if childArgs["parent_id"] != recreated[0].TempID {
+		t.Errorf("expected subtask to reference the recreated parent, got %v", childArgs["parent_id"])
+	}
+	if len(s.UndoHistory.Redos()) != 1 {
+		t.Fatalf("expected one redo entry, got %d", len(s.UndoHistory.Redos()))
+	}
+
+	// Redo deletes the recreated task by its new ID
+	h.Update(h.handleRedo(1)())
+	deleted := rec.take()
+	if len(deleted) != 1 || deleted[0].Type != "item_delete" {
+		t.Fatalf("expected one item_delete command, got %+v", deleted)
+	}
+	if id := deleted[0].Args.(map[string]interface{})["id"]; id != "new-1" {
+		t.Errorf("expected redo to delete new-1, got %v", id)
+	}
+
+	// Undoing again sends fresh commands rather than replaying the old UUIDs
+	h.Update(h.handleUndo(1)())
+	again := rec.take()
+	if len(again) != 2 || again[0].UUID == recreated[0].UUID || again[0].TempID == recreated[0].TempID {
+		t.Errorf("expected renewed commands, got %+v", again)
+	}
+}
+
+func TestHandleUndo_Count(t *testing.T) {
+	s := &state.State{}
+	h := NewHandler(s)
+
+	for i := 1; i <= 3; i++ {
+		s.UndoHistory.Record(undoEntry(fmt.Sprintf("change %d", i),
+			[]api.SyncCommand{api.ReopenTaskCommand("t")},
+			[]api.SyncCommand{api.CloseTaskCommand("t")}))
+	}
+
+	entries := s.UndoHistory.TakeUndo(2)
+	if len(entries) != 2 || entries[0].Description != "change 3" || entries[1].Description != "change 2" {
+		t.Fatalf("expected the two most recent changes, got %+v", entries)
+	}
+
+	h.handleHistoryApplied(historyAppliedMsg{entries: entries, sent: 2})
+	redos := s.UndoHistory.Redos()
+	if len(redos) != 2 || redos[0].Description != "change 2" {
+		t.Errorf("expected change 2 to be redone first, got %+v", redos)
+	}
+
+	// A new change clears the redo stack
+	s.UndoHistory.Record(undoEntry("change 4", []api.SyncCommand{api.ReopenTaskCommand("t")}, nil))
+	if len(s.UndoHistory.Redos()) != 0 {
+		t.Error("expected redo stack to be cleared")
+	}
+}
+
+func TestUndo_RecordsOnlyAppliedChanges(t *testing.T) {
+	h, srv := newFakeHandler(t)
+	task := srv.AddTask(api.Task{Content: "Write report", ProjectID: "p1"})
+	h.Tasks = []api.Task{task, {ID: "missing", Content: "Gone", ProjectID: "p1"}}
+	h.AllTasks = append([]api.Task(nil), h.Tasks...)
+
+	// The server doesn't know the second task and rejects its completion
+	h.TaskCursor = 1
+	if _, ok := changeResult(t, h.handleComplete()()).(bulkRejectedMsg); !ok {
+		t.Fatal("expected the completion to be rejected")
+	}
+	if len(h.UndoHistory.Undos()) != 0 {
+		t.Fatalf("expected a rejected change not to be recorded, got %+v", h.UndoHistory.Undos())
+	}
+
+	h.TaskCursor = 0
+	h.Update(h.handleComplete()())
+	if undos := h.UndoHistory.Undos(); len(undos) != 1 || undos[0].Description != "Complete 'Write report'" {
+		t.Errorf("expected the completion to be recorded, got %+v", undos)
+	}
+}
@@ -1,6 +1,8 @@
 package logic
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"time"
@@ -12,8 +14,10 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/gen2brain/beeep"
 	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/control"
 	"github.com/hy4ri/todoist-tui/internal/tui/components"
 	"github.com/hy4ri/todoist-tui/internal/tui/state"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
 	"github.com/hy4ri/todoist-tui/internal/tui/views"
 )
 
@@ -49,9 +53,13 @@ func (h *Handler) Update(msg tea.Msg) tea.Cmd {
 		return h.handleCheckDue(time.Time(msg))
 
 	case errMsg:
+		// Cancelled requests were abandoned on purpose, e.g. on a tab switch
+		if errors.Is(msg.err, context.Canceled) {
+			return nil
+		}
 		h.Loading = false
 		h.Err = msg.err
-		h.StatusMsg = msg.err.Error()
+		h.StatusMsg = utils.ErrorMessage(msg.err)
 		return nil
 
 	case statusMsg:
@@ -61,6 +69,27 @@ func (h *Handler) Update(msg tea.Msg) tea.Cmd {
 	case dataLoadedMsg:
 		return h.handleDataLoaded(msg)
 
+	case viewLoadedMsg:
+		if msg.ctx.Err() != nil {
+			return nil
+		}
+		return h.Update(msg.msg)
+
+	case filterPageMsg:
+		return h.handleFilterPage(msg)
+
+	case outboxQueuedMsg:
+		return h.handleOutboxQueued(msg)
+
+	case bulkRejectedMsg:
+		return h.handleBulkRejected(msg)
+
+	case outboxRetryMsg:
+		return h.handleOutboxRetry()
+
+	case outboxFlushedMsg:
+		return h.handleOutboxFlushed(msg)
+
 	case completedTasksLoadedMsg:
 		h.Loading = false
 		h.CompletedTasks = msg
@@ -73,6 +102,9 @@ func (h *Handler) Update(msg tea.Msg) tea.Cmd {
 		h.Filters = msg.filters
 		return nil
 
+	case collaboratorsLoadedMsg:
+		return h.handleCollaboratorsLoaded(msg)
+
 	case filterCreatedMsg:
 		h.Loading = false
 		h.StatusMsg = "Filter created: " + msg.filter.Name
@@ -93,8 +125,9 @@ func (h *Handler) Update(msg tea.Msg) tea.Cmd {
 
 	case quickAddTaskCreatedMsg:
 		// Task added via Quick Add - refresh but keep popup open
+		h.recordCreated(msg.task)
 		h.StatusMsg = "Task added!"
-		return h.refreshTasks()
+		return h.syncData()
 
 	case projectCreatedMsg, projectUpdatedMsg, projectDeletedMsg:
 		return h.handleProjectMsgs(msg)
@@ -144,6 +177,9 @@ func (h *Handler) Update(msg tea.Msg) tea.Cmd {
 		h.CommentInput.Prompt = ""
 		return textarea.Blink
 
+	case components.ToggleReactionMsg:
+		return h.handleToggleReaction(msg.CommentID, msg.Emoji)
+
 	case components.DeleteCommentMsg:
 		// Find comment object for context
 		for i := range h.Comments {
@@ -156,16 +192,17 @@ func (h *Handler) Update(msg tea.Msg) tea.Cmd {
 		return nil
 
 	case subtaskCreatedMsg:
+		h.recordCreated(msg.task)
 		h.Loading = false
 		h.StatusMsg = "Subtask created"
 		// Reload current view to show subtask
 		return func() tea.Msg { return refreshMsg{} }
 
-	case undoCompletedMsg:
-		h.Loading = false
-		h.StatusMsg = "Undo successful"
-		// Reload current view
-		return func() tea.Msg { return refreshMsg{} }
+	case historyAppliedMsg:
+		return h.handleHistoryApplied(msg)
+
+	case undoableMsg:
+		return h.handleUndoable(msg)
 
 	case searchRefreshMsg:
 		h.Loading = false
@@ -181,6 +218,15 @@ func (h *Handler) Update(msg tea.Msg) tea.Cmd {
 	case refreshMsg:
 		return h.handleRefresh(msg.Force)
 
+	case control.Msg:
+		return h.handleControl(msg)
+
+	case importParsedMsg:
+		return h.handleImportParsed(msg)
+
+	case importFinishedMsg:
+		return h.handleImportFinished(msg)
+
 	case commentsLoadedMsg:
 		h.Comments = msg.comments
 		// Store in cache for instant retrieval next time
@@ -356,15 +402,19 @@ func (h *Handler) handleDataLoaded(msg dataLoadedMsg) tea.Cmd {
 
 	dataChanged := false
 
-	if len(msg.allTasks) > 0 {
+	if len(msg.allTasks) > 0 || msg.synced {
 		h.AllTasks = msg.allTasks
 		dataChanged = true
 		h.TasksByDate = make(map[string][]api.Task)
+		h.DeadlinesByDate = make(map[string][]api.Task)
 		h.LastDataFetch = time.Now() // Track when data was fetched
 
 		// Optimization: Pre-parse task dates and group by date
 		for i := range h.AllTasks {
 			t := &h.AllTasks[i]
+			if t.Deadline != nil && !t.Checked {
+				h.DeadlinesByDate[t.Deadline.Date] = append(h.DeadlinesByDate[t.Deadline.Date], *t)
+			}
 			if t.Due != nil {
 				dateStr := t.Due.Date
 				if len(dateStr) > 10 {
@@ -390,7 +440,7 @@ func (h *Handler) handleDataLoaded(msg dataLoadedMsg) tea.Cmd {
 		}
 	}
 
-	if len(msg.projects) > 0 {
+	if len(msg.projects) > 0 || msg.synced {
 		h.Projects = msg.projects
 	}
 
@@ -415,9 +465,12 @@ func (h *Handler) handleDataLoaded(msg dataLoadedMsg) tea.Cmd {
 			h.SelectedTaskIDs = valid
 		}
 	}
-	if len(msg.labels) > 0 {
+	if len(msg.labels) > 0 || msg.synced {
 		h.Labels = msg.labels
 	}
+	if msg.synced {
+		h.Filters = msg.filters
+	}
 	if msg.sections != nil {
 		h.Sections = msg.sections
 		// Sort sections by SectionOrder
@@ -425,7 +478,7 @@ func (h *Handler) handleDataLoaded(msg dataLoadedMsg) tea.Cmd {
 			return h.Sections[i].SectionOrder < h.Sections[j].SectionOrder
 		})
 	}
-	if len(msg.allSections) > 0 {
+	if len(msg.allSections) > 0 || msg.synced {
 		h.AllSections = msg.allSections
 	}
 	if msg.stats != nil {
@@ -437,9 +490,18 @@ func (h *Handler) handleDataLoaded(msg dataLoadedMsg) tea.Cmd {
 		h.StatsError = ""
 	}
 
-	if len(msg.reminders) > 0 {
+	if len(msg.reminders) > 0 || msg.synced {
 		h.Reminders = msg.reminders
 	}
+	if msg.user != nil {
+		h.User = msg.user
+	}
+
+	// Incremental syncs only refresh the cache; derive the visible list from it
+	if msg.synced && msg.tasks == nil {
+		h.refilterCurrentView()
+		dataChanged = true
+	}
 
 	// Restore cursor position if we have a task ID to restore to
 	if h.RestoreCursorToTaskID != "" {
@@ -456,15 +518,16 @@ func (h *Handler) handleDataLoaded(msg dataLoadedMsg) tea.Cmd {
 		h.DataVersion++
 	}
 
-	return nil
+	return h.loadSharedCollaborators()
 }
 
 func (h *Handler) handleTaskMsgs(msg tea.Msg) tea.Cmd {
 	h.Loading = false
-	switch msg.(type) {
+	switch msg := msg.(type) {
 	case taskDeletedMsg:
 		h.StatusMsg = "Task deleted"
 	case taskCreatedMsg:
+		h.recordCreated(msg.task)
 		h.StatusMsg = "Task saved"
 		h.CurrentView = h.PreviousView
 		h.TaskForm = nil
@@ -536,62 +599,15 @@ func (h *Handler) handleRefresh(force bool) tea.Cmd {
 	// Manual refresh (force=true) always bypasses cache
 	dataIsFresh := !force && len(h.AllTasks) > 0 && time.Since(h.LastDataFetch) < 30*time.Second
 
-	switch h.CurrentTab {
-	case state.TabInbox:
-		if force || !dataIsFresh {
-			h.Loading = true
-			return h.refreshTasks()
-		}
-		return h.loadInboxTasks()
-	case state.TabProjects:
-		if h.CurrentProject != nil {
-			if dataIsFresh {
-				// Use cached data for instant filtering
-				return h.filterProjectTasks(h.CurrentProject.ID)
-			}
-			// Reload current project from API
-			h.Loading = true
-			h.Sections = nil // Clear sections to ensure clean reload
-			return h.loadProjectTasks(h.CurrentProject.ID)
-		}
-		// Just reload project list
-		h.Loading = true
-		return h.loadProjects()
-	case state.TabUpcoming:
-		if dataIsFresh {
-			return h.filterUpcomingTasks()
-		}
-		h.Loading = true
-		return h.loadUpcomingTasks()
-	case state.TabCalendar:
-		if dataIsFresh {
-			return h.filterCalendarTasks()
-		}
-		h.Loading = true
-		return h.loadAllTasks()
-	case state.TabLabels:
-		if h.CurrentLabel != nil {
-			if dataIsFresh {
-				return h.filterLabelTasks(h.CurrentLabel.Name)
-			}
-			h.Loading = true
-			return h.loadLabelTasks(h.CurrentLabel.Name)
-		}
-		h.Loading = true
-		return h.loadLabels()
-	case state.TabToday:
-		if dataIsFresh {
-			return h.filterTodayTasks()
-		}
-		h.Loading = true
-		return h.loadTodayTasks()
-	default:
-		if dataIsFresh {
-			return h.filterTodayTasks()
-		}
-		h.Loading = true
-		return h.loadTodayTasks()
+	if dataIsFresh {
+		// Use cached data for instant filtering
+		h.refilterCurrentView()
+		return nil
 	}
+
+	// Incremental sync only transfers what changed since the last sync token
+	h.Loading = true
+	return h.syncData()
 }
 
 // updateStatsOnCompletion updates the productivity stats when a task is completed.
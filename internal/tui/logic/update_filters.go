@@ -1,23 +1,28 @@
 package logic
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/filterquery"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
 )
 
 // loadFilters loads filters from API.
 func (h *Handler) loadFilters() tea.Cmd {
-	return func() tea.Msg {
-		filters, err := h.Client.GetFilters()
+	client := h.Client
+	return h.accountLoad(func(ctx context.Context) tea.Msg {
+		filters, err := client.GetFilters(ctx)
 		if err != nil {
 			return errMsg{err}
 		}
 		return filtersLoadedMsg{filters: filters}
-	}
+	})
 }
 
 type filtersLoadedMsg struct {
@@ -31,13 +36,7 @@ func (h *Handler) runFilter(filter *api.Filter) tea.Cmd {
 	h.StatusMsg = fmt.Sprintf("Running filter: %s", filter.Name)
 	h.Tasks = nil // Clear current tasks
 
-	return func() tea.Msg {
-		tasks, err := h.Client.GetTasksByFilter(filter.Query)
-		if err != nil {
-			return errMsg{err}
-		}
-		return dataLoadedMsg{tasks: tasks}
-	}
+	return h.filterTasks(filter.Query)
 }
 
 // runAdHocFilter runs a custom query string.
@@ -51,13 +50,91 @@ func (h *Handler) runAdHocFilter(query string) tea.Cmd {
 	h.StatusMsg = fmt.Sprintf("Running query: %s", query)
 	h.Tasks = nil
 
-	return func() tea.Msg {
-		tasks, err := h.Client.GetTasksByFilter(query)
-		if err != nil {
-			return errMsg{err}
+	return h.filterTasks(query)
+}
+
+// filterTasks evaluates query against the loaded tasks, falling back to the
+// server for queries the local engine cannot answer or before any tasks
+// have been loaded.
+func (h *Handler) filterTasks(query string) tea.Cmd {
+	if h.AllTasks != nil {
+		ctx := filterquery.Context{
+			Projects:      h.Projects,
+			Sections:      h.AllSections,
+			Collaborators: h.allCollaborators(),
+		}
+		if h.User != nil {
+			ctx.UserID = h.User.ID
+		}
+		tasks, err := filterquery.Apply(query, h.AllTasks, ctx)
+		if err == nil {
+			return func() tea.Msg {
+				return dataLoadedMsg{tasks: tasks}
+			}
+		}
+	}
+
+	return h.streamFilter(h.CurrentFilter, query)
+}
+
+// filterPageSize is the page size used when the server answers a filter, so
+// the first tasks show up before the rest have arrived.
+const filterPageSize = 50
+
+// filterPageMsg delivers one page of a filter answered by the server.
+type filterPageMsg struct {
+	filter *api.Filter // Filter the page belongs to
+	tasks  []api.Task
+	first  bool
+	next   tea.Cmd // Fetches the following page; nil after the last one
+	stop   func()  // Abandons the remaining pages
+}
+
+// streamFilter fetches the results of query from the server page by page.
+func (h *Handler) streamFilter(filter *api.Filter, query string) tea.Cmd {
+	next, stop := iter.Pull2(h.Client.FilterTaskPages(h.ViewContext(), query, api.WithPageSize(filterPageSize)))
+
+	var fetch func(first bool) tea.Cmd
+	fetch = func(first bool) tea.Cmd {
+		return func() tea.Msg {
+			page, err, ok := next()
+			if err != nil {
+				stop()
+				return errMsg{err}
+			}
+			if !ok {
+				stop()
+				return filterPageMsg{filter: filter, first: first}
+			}
+			return filterPageMsg{filter: filter, tasks: page, first: first, next: fetch(false), stop: stop}
 		}
-		return dataLoadedMsg{tasks: tasks}
 	}
+	return fetch(true)
+}
+
+// handleFilterPage shows a page of filter results and asks for the next one.
+// Pages of a filter that is no longer shown are dropped.
+func (h *Handler) handleFilterPage(msg filterPageMsg) tea.Cmd {
+	if msg.filter != h.CurrentFilter || h.CurrentTab != state.TabFilters {
+		if msg.stop != nil {
+			msg.stop()
+		}
+		return nil
+	}
+
+	tasks := make([]api.Task, 0, len(h.Tasks)+len(msg.tasks))
+	if !msg.first {
+		tasks = append(tasks, h.Tasks...)
+	}
+	tasks = append(tasks, msg.tasks...)
+	loaded := h.handleDataLoaded(dataLoadedMsg{tasks: tasks})
+
+	if msg.next == nil {
+		h.StatusMsg = fmt.Sprintf("%s: %d tasks", h.CurrentFilter.Name, len(h.Tasks))
+		return loaded
+	}
+	h.StatusMsg = fmt.Sprintf("Loaded %d tasks...", len(h.Tasks))
+	return tea.Batch(loaded, msg.next)
 }
 
 // getVisibleFilters returns filters matching the search query.
@@ -231,13 +308,14 @@ func (h *Handler) submitFilterForm() tea.Cmd {
 	h.Loading = true
 	h.StatusMsg = "Creating filter..."
 
-	return func() tea.Msg {
-		filter, err := h.Client.CreateFilter(name, query, color)
+	ctx, client := h.Context(), h.Client
+	return h.accountChange(func() tea.Msg {
+		filter, err := client.CreateFilter(ctx, name, query, color)
 		if err != nil {
 			return errMsg{err}
 		}
 		return filterCreatedMsg{filter: filter}
-	}
+	})
 }
 
 type filterCreatedMsg struct {
@@ -261,13 +339,14 @@ func (h *Handler) handleDeleteFilterConfirmKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		// Delete via API
 		h.Loading = true
 		h.StatusMsg = "Deleting filter..."
-		return func() tea.Msg {
-			err := h.Client.DeleteFilter(filterID)
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
+			err := client.DeleteFilter(ctx, filterID)
 			if err != nil {
 				return errMsg{err}
 			}
 			return filterDeletedMsg{filterID: filterID}
-		}
+		})
 	case "n", "N", "esc":
 		h.ConfirmDeleteFilter = false
 		h.EditingFilter = nil
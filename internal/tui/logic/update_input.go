@@ -301,6 +301,8 @@ func (h *Handler) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		return h.handleBack()
 	case "complete":
 		return h.handleComplete()
+	case "complete_forever":
+		return h.handleCompleteForever()
 	case "delete":
 		return h.handleDelete()
 	case "add":
@@ -362,7 +364,9 @@ func (h *Handler) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 	case "add_subtask":
 		return h.handleAddSubtask()
 	case "undo":
-		return h.handleUndo()
+		return h.handleUndo(1)
+	case "redo":
+		return h.handleRedo(1)
 	case "manage_sections":
 		if h.CurrentTab == state.TabProjects && len(h.Projects) > 0 {
 			h.PreviousView = h.CurrentView
@@ -403,7 +407,7 @@ func (h *Handler) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			h.CommentInput.SetHeight(3)
 			h.CommentInput.ShowLineNumbers = false
 			h.CommentInput.Prompt = ""
-			return nil
+			return h.loadProjectCollaborators(h.SelectedTask.ProjectID)
 		}
 	case "toggle_select":
 		return h.handleToggleSelect()
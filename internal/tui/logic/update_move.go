@@ -188,6 +188,17 @@ func (h *Handler) executeMoveToProject(target state.MoveTarget) tea.Cmd {
 		return nil
 	}
 
+	var undo, redo []api.SyncCommand
+	for _, t := range tasksToMove {
+		undo = append(undo, moveBackCommand(t))
+		if target.IsSection {
+			redo = append(redo, api.MoveTaskCommand(t.ID, &target.ID, &target.ProjectID, nil))
+		} else {
+			redo = append(redo, api.MoveTaskCommand(t.ID, nil, &target.ID, nil))
+		}
+	}
+	entry := undoEntry(describeChange("Move", tasksToMove), undo, redo)
+
 	// --- Optimistic Update ---
 	idsToRemove := make(map[string]bool)
 	for _, t := range tasksToMove {
@@ -219,7 +230,8 @@ func (h *Handler) executeMoveToProject(target state.MoveTarget) tea.Cmd {
 	h.rebuildSidebarCounts()
 
 	// --- Background API Call ---
-	return func() tea.Msg {
+	ctx, client, outbox := h.Context(), h.Client, h.Outbox
+	return h.undoable(entry, func() tea.Msg {
 		ids := make([]string, len(tasksToMove))
 		for i, t := range tasksToMove {
 			ids[i] = t.ID
@@ -233,13 +245,20 @@ func (h *Handler) executeMoveToProject(target state.MoveTarget) tea.Cmd {
 			targetProjectID = target.ID
 		}
 
-		err := h.Client.MoveTasksBatch(ids, targetProjectID, targetSectionID)
+		err := client.MoveTasksBatch(ctx, ids, targetProjectID, targetSectionID)
+		if api.IsNetworkError(err) {
+			var cmds []api.SyncCommand
+			for _, id := range ids {
+				cmds = append(cmds, api.MoveTaskCommand(id, &targetSectionID, &targetProjectID, nil))
+			}
+			return queueOffline(outbox, err, cmds...)
+		}
 		if err != nil {
-			// Trigger refresh on failure to ensure state consistency
-			return refreshMsg{Force: true}
+			// Reported and refreshed to undo the optimistic update
+			return bulkRejectedMsg{count: len(ids), err: err}
 		}
 
 		// Success - everything already updated optimistically
 		return statusMsg{msg: fmt.Sprintf("Moved %d tasks to %s", len(tasksToMove), target.Name)}
-	}
+	})
 }
@@ -1,6 +1,7 @@
 package logic
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -71,8 +72,9 @@ func (h *Handler) handleProjectInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			h.ProjectInput.Reset()
 			h.Loading = true
 
-			return func() tea.Msg {
-				project, err := h.Client.CreateProject(api.CreateProjectRequest{
+			ctx, client := h.Context(), h.Client
+			return h.accountChange(func() tea.Msg {
+				project, err := client.CreateProject(ctx, api.CreateProjectRequest{
 					Name:  name,
 					Color: color, // Removed &
 				})
@@ -81,7 +83,7 @@ func (h *Handler) handleProjectInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 				}
 				// Refresh projects after creation
 				return projectCreatedMsg{project: project}
-			}
+			})
 		}
 		return nil
 	}
@@ -150,15 +152,16 @@ func (h *Handler) handleProjectEditKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		h.ProjectInput.Reset()
 		h.Loading = true
 
-		return func() tea.Msg {
-			project, err := h.Client.UpdateProject(projectID, api.UpdateProjectRequest{
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
+			project, err := client.UpdateProject(ctx, projectID, api.UpdateProjectRequest{
 				Name: &name,
 			})
 			if err != nil {
 				return errMsg{err}
 			}
 			return projectUpdatedMsg{project: project}
-		}
+		})
 
 	default:
 		// Update text input
@@ -183,13 +186,14 @@ func (h *Handler) handleDeleteConfirmKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		h.EditingProject = nil
 		h.Loading = true
 
-		return func() tea.Msg {
-			err := h.Client.DeleteProject(projectID)
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
+			err := client.DeleteProject(ctx, projectID)
 			if err != nil {
 				return errMsg{err}
 			}
 			return projectDeletedMsg{id: projectID}
-		}
+		})
 
 	case "n", "N", "esc":
 		// Cancel delete
@@ -253,8 +257,9 @@ func (h *Handler) handleLabelInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			h.LabelInput.Reset()
 			h.Loading = true
 
-			return func() tea.Msg {
-				label, err := h.Client.CreateLabel(api.CreateLabelRequest{
+			ctx, client := h.Context(), h.Client
+			return h.accountChange(func() tea.Msg {
+				label, err := client.CreateLabel(ctx, api.CreateLabelRequest{
 					Name:  name,
 					Color: color, // Removed &
 				})
@@ -262,7 +267,7 @@ func (h *Handler) handleLabelInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 					return errMsg{err}
 				}
 				return labelCreatedMsg{label: label}
-			}
+			})
 		}
 		return nil
 	}
@@ -328,15 +333,16 @@ func (h *Handler) handleLabelEditKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		h.LabelInput.Reset()
 		h.Loading = true
 
-		return func() tea.Msg {
-			label, err := h.Client.UpdateLabel(labelID, api.UpdateLabelRequest{
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
+			label, err := client.UpdateLabel(ctx, labelID, api.UpdateLabelRequest{
 				Name: &name,
 			})
 			if err != nil {
 				return errMsg{err}
 			}
 			return labelUpdatedMsg{label: label}
-		}
+		})
 
 	default:
 		var cmd tea.Cmd
@@ -359,12 +365,13 @@ func (h *Handler) handleLabelDeleteConfirmKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		h.EditingLabel = nil
 		h.Loading = true
 
-		return func() tea.Msg {
-			if err := h.Client.DeleteLabel(labelID); err != nil {
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
+			if err := client.DeleteLabel(ctx, labelID); err != nil {
 				return errMsg{err}
 			}
 			return labelDeletedMsg{id: labelID}
-		}
+		})
 
 	case "n", "N", "esc":
 		h.ConfirmDeleteLabel = false
@@ -403,8 +410,9 @@ func (h *Handler) handleSectionInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			h.SectionInput.Reset()
 			h.Loading = true
 
-			return func() tea.Msg {
-				section, err := h.Client.CreateSection(api.CreateSectionRequest{
+			ctx, client := h.Context(), h.Client
+			return h.accountChange(func() tea.Msg {
+				section, err := client.CreateSection(ctx, api.CreateSectionRequest{
 					ProjectID: projectID,
 					Name:      name,
 				})
@@ -412,7 +420,7 @@ func (h *Handler) handleSectionInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 					return errMsg{err}
 				}
 				return sectionCreatedMsg{section: section}
-			}
+			})
 		}
 		h.IsCreatingSection = false
 		return nil
@@ -448,15 +456,16 @@ func (h *Handler) handleSectionEditKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		h.SectionInput.Reset()
 		h.Loading = true
 
-		return func() tea.Msg {
-			section, err := h.Client.UpdateSection(sectionID, api.UpdateSectionRequest{
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
+			section, err := client.UpdateSection(ctx, sectionID, api.UpdateSectionRequest{
 				Name: name,
 			})
 			if err != nil {
 				return errMsg{err}
 			}
 			return sectionUpdatedMsg{section: section}
-		}
+		})
 
 	default:
 		var cmd tea.Cmd
@@ -479,12 +488,13 @@ func (h *Handler) handleSectionDeleteConfirmKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		h.EditingSection = nil
 		h.Loading = true
 
-		return func() tea.Msg {
-			if err := h.Client.DeleteSection(sectionID); err != nil {
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
+			if err := client.DeleteSection(ctx, sectionID); err != nil {
 				return errMsg{err}
 			}
 			return sectionDeletedMsg{id: sectionID}
-		}
+		})
 
 	case "n", "N", "esc":
 		h.ConfirmDeleteSection = false
@@ -650,8 +660,8 @@ func (h *Handler) handleMoveTaskKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			return nil
 		}
 
-		var cmds []tea.Cmd
-		movedCount := 0
+		var moved []api.Task
+		var undo, redo []api.SyncCommand
 
 		for _, task := range tasksToMove {
 			// Skip if already in section
@@ -659,7 +669,9 @@ func (h *Handler) handleMoveTaskKeyMsg(msg tea.KeyMsg) tea.Cmd {
 				continue
 			}
 
-			movedCount++
+			moved = append(moved, *task)
+			undo = append(undo, moveBackCommand(*task))
+			redo = append(redo, api.MoveTaskCommand(task.ID, &sectionID, nil, nil))
 
 			// Optimistic update
 			safeSectionID := sectionID
@@ -672,22 +684,9 @@ func (h *Handler) handleMoveTaskKeyMsg(msg tea.KeyMsg) tea.Cmd {
 					break
 				}
 			}
-
-			// Capture loop variables
-			tID := task.ID
-			sID := sectionID
-			taskCopy := *task
-
-			cmds = append(cmds, func() tea.Msg {
-				err := h.Client.MoveTask(tID, &sID, nil, nil)
-				if err != nil {
-					return errMsg{err}
-				}
-				return taskUpdatedMsg{task: &taskCopy}
-			})
 		}
 
-		if movedCount == 0 {
+		if len(moved) == 0 {
 			h.StatusMsg = "Tasks already in this section"
 			return nil
 		}
@@ -695,7 +694,7 @@ func (h *Handler) handleMoveTaskKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		// Clear selection after move
 		h.clearSelection()
 
-		return tea.Batch(cmds...)
+		return h.undoable(undoEntry(describeChange("Move", moved), undo, redo), h.sendBulk(redo, taskUpdatedMsg{}))
 
 	}
 	return nil
@@ -785,16 +784,17 @@ func (h *Handler) handleSubtaskInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		h.SubtaskInput.Reset()
 		h.Loading = true
 
-		return func() tea.Msg {
-			_, err := h.Client.CreateTask(api.CreateTaskRequest{
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
+			task, err := client.CreateTask(ctx, api.CreateTaskRequest{
 				Content:  content,
 				ParentID: parentID,
 			})
 			if err != nil {
 				return errMsg{err}
 			}
-			return subtaskCreatedMsg{}
-		}
+			return subtaskCreatedMsg{task: task}
+		})
 
 	default:
 		// Update text input
@@ -853,19 +853,22 @@ func (h *Handler) handleSearchKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		// Complete task from search results
 		if len(h.SearchResults) > 0 && h.TaskCursor < len(h.SearchResults) {
 			task := &h.SearchResults[h.TaskCursor]
+			undo, redo := toggleCompleteCommands(*task)
+			entry := undoEntry(describeChange("Complete", []api.Task{*task}), []api.SyncCommand{undo}, []api.SyncCommand{redo})
 			h.Loading = true
-			return func() tea.Msg {
+			ctx, client := h.Context(), h.Client
+			return h.undoable(entry, func() tea.Msg {
 				var err error
 				if task.Checked {
-					err = h.Client.ReopenTask(task.ID)
+					err = client.ReopenTask(ctx, task.ID)
 				} else {
-					err = h.Client.CloseTask(task.ID)
+					err = client.CloseTask(ctx, task.ID)
 				}
 				if err != nil {
 					return errMsg{err}
 				}
 				return searchRefreshMsg{}
-			}
+			})
 		}
 		return nil
 	}
@@ -911,13 +914,14 @@ func (h *Handler) filterSearchResults() {
 // refreshSearchResults reloads all tasks and returns a searchResultsLoadedMsg so
 // the main-goroutine message handler can update shared state without a data race.
 func (h *Handler) refreshSearchResults() tea.Cmd {
-	return func() tea.Msg {
-		tasks, err := h.Client.GetTasks(api.TaskFilter{})
+	client := h.Client
+	return h.accountLoad(func(ctx context.Context) tea.Msg {
+		tasks, err := client.GetTasks(ctx, api.TaskFilter{})
 		if err != nil {
 			return errMsg{err}
 		}
 		return searchResultsLoadedMsg{tasks: tasks}
-	}
+	})
 }
 
 // handlePriority sets task priority. When tasks are multi-selected the priority
@@ -943,13 +947,19 @@ func (h *Handler) handlePriority(action string) tea.Cmd {
 	// --- Multi-select branch ---
 	if len(h.SelectedTaskIDs) > 0 {
 		var taskIDs []string
+		var changed []api.Task
+		var undo, redo []api.SyncCommand
 		for i := range h.Tasks {
 			if h.SelectedTaskIDs[h.Tasks[i].ID] {
 				taskIDs = append(taskIDs, h.Tasks[i].ID)
+				changed = append(changed, h.Tasks[i])
+				undo = append(undo, api.UpdateTaskCommand(h.Tasks[i].ID, api.UpdateTaskRequest{Priority: api.IntPtr(h.Tasks[i].Priority)}))
+				redo = append(redo, api.UpdateTaskCommand(h.Tasks[i].ID, api.UpdateTaskRequest{Priority: api.IntPtr(priority)}))
 				// Optimistic update in Tasks
 				h.Tasks[i].Priority = priority
 			}
 		}
+		entry := undoEntry(describeChange("Set priority on", changed), undo, redo)
 		// Optimistic update in AllTasks
 		for i := range h.AllTasks {
 			if h.SelectedTaskIDs[h.AllTasks[i].ID] {
@@ -961,24 +971,11 @@ func (h *Handler) handlePriority(action string) tea.Cmd {
 		h.refilterCurrentView()
 		h.StatusMsg = fmt.Sprintf("Set priority %d on %d tasks", priority, len(taskIDs))
 
-		return func() tea.Msg {
-			type result struct{ err error }
-			results := make(chan result, len(taskIDs))
-			sem := make(chan struct{}, maxConcurrentRequests)
-			p := priority // capture
-			for _, id := range taskIDs {
-				sem <- struct{}{}
-				go func(tid string) {
-					defer func() { <-sem }()
-					_, err := h.Client.UpdateTask(tid, api.UpdateTaskRequest{Priority: &p})
-					results <- result{err: err}
-				}(id)
-			}
-			for range taskIDs {
-				<-results
-			}
-			return taskUpdatedMsg{}
+		cmds := make([]api.SyncCommand, len(taskIDs))
+		for i, id := range taskIDs {
+			cmds[i] = api.UpdateTaskCommand(id, api.UpdateTaskRequest{Priority: api.IntPtr(priority)})
 		}
+		return h.undoable(entry, h.sendBulk(cmds, taskUpdatedMsg{}))
 	}
 
 	// --- Single-task (cursor) branch ---
@@ -987,6 +984,10 @@ func (h *Handler) handlePriority(action string) tea.Cmd {
 		return nil
 	}
 
+	entry := undoEntry(describeChange("Set priority on", []api.Task{*task}),
+		[]api.SyncCommand{api.UpdateTaskCommand(task.ID, api.UpdateTaskRequest{Priority: api.IntPtr(task.Priority)})},
+		[]api.SyncCommand{api.UpdateTaskCommand(task.ID, api.UpdateTaskRequest{Priority: api.IntPtr(priority)})})
+
 	// Optimistic update
 	task.Priority = priority
 
@@ -1002,15 +1003,17 @@ func (h *Handler) handlePriority(action string) tea.Cmd {
 	h.StatusMsg = fmt.Sprintf("Set priority %d", priority)
 
 	taskID := task.ID
-	return func() tea.Msg {
-		_, err := h.Client.UpdateTask(taskID, api.UpdateTaskRequest{
+	ctx, client, outbox := h.Context(), h.Client, h.Outbox
+	return h.undoable(entry, func() tea.Msg {
+		req := api.UpdateTaskRequest{
 			Priority: &priority,
-		})
+		}
+		_, err := client.UpdateTask(ctx, taskID, req)
 		if err != nil {
-			return errMsg{err}
+			return queueOffline(outbox, err, api.UpdateTaskCommand(taskID, req))
 		}
 		return taskUpdatedMsg{}
-	}
+	})
 }
 
 // handleDueToday sets the task due date to today.
@@ -1032,6 +1035,10 @@ func (h *Handler) handleDueToday() tea.Cmd {
 
 	dueString := "today"
 
+	entry := undoEntry(describeChange("Move to today", []api.Task{*task}),
+		[]api.SyncCommand{api.SetDueCommand(task.ID, copyDue(task.Due))},
+		[]api.SyncCommand{api.UpdateTaskCommand(task.ID, api.UpdateTaskRequest{DueString: &dueString})})
+
 	// Optimistic update
 	now := time.Now()
 	dateStr := now.Format("2006-01-02")
@@ -1066,15 +1073,17 @@ func (h *Handler) handleDueToday() tea.Cmd {
 
 	h.StatusMsg = "Moving to today..."
 
-	return func() tea.Msg {
-		_, err := h.Client.UpdateTask(task.ID, api.UpdateTaskRequest{
+	ctx, client, outbox := h.Context(), h.Client, h.Outbox
+	return h.undoable(entry, func() tea.Msg {
+		req := api.UpdateTaskRequest{
 			DueString: &dueString,
-		})
+		}
+		_, err := client.UpdateTask(ctx, task.ID, req)
 		if err != nil {
-			return errMsg{err}
+			return queueOffline(outbox, err, api.UpdateTaskCommand(task.ID, req))
 		}
 		return taskUpdatedMsg{}
-	}
+	})
 }
 
 // handleDueTomorrow sets the task due date to tomorrow.
@@ -1096,6 +1105,10 @@ func (h *Handler) handleDueTomorrow() tea.Cmd {
 
 	dueString := "tomorrow"
 
+	entry := undoEntry(describeChange("Move to tomorrow", []api.Task{*task}),
+		[]api.SyncCommand{api.SetDueCommand(task.ID, copyDue(task.Due))},
+		[]api.SyncCommand{api.UpdateTaskCommand(task.ID, api.UpdateTaskRequest{DueString: &dueString})})
+
 	// Optimistic update
 	tomorrow := time.Now().AddDate(0, 0, 1)
 	dateStr := tomorrow.Format("2006-01-02")
@@ -1129,29 +1142,32 @@ func (h *Handler) handleDueTomorrow() tea.Cmd {
 
 	h.StatusMsg = "Moving to tomorrow..."
 
-	return func() tea.Msg {
-		_, err := h.Client.UpdateTask(task.ID, api.UpdateTaskRequest{
+	ctx, client, outbox := h.Context(), h.Client, h.Outbox
+	return h.undoable(entry, func() tea.Msg {
+		req := api.UpdateTaskRequest{
 			DueString: &dueString,
-		})
+		}
+		_, err := client.UpdateTask(ctx, task.ID, req)
 		if err != nil {
-			return errMsg{err}
+			return queueOffline(outbox, err, api.UpdateTaskCommand(task.ID, req))
 		}
 		// Return taskUpdatedMsg to trigger eventual consistency refresh
 		return taskUpdatedMsg{}
-	}
+	})
 }
 
 // loadProjectTasks loads tasks for a specific project.
 func (h *Handler) loadProjectTasks(projectID string) tea.Cmd {
-	return func() tea.Msg {
-		tasks, err := h.Client.GetTasks(api.TaskFilter{
+	client := h.Client
+	return h.viewLoad(func(ctx context.Context) tea.Msg {
+		tasks, err := client.GetTasks(ctx, api.TaskFilter{
 			ProjectID: projectID,
 		})
 		if err != nil {
 			return errMsg{err}
 		}
 
-		sections, err := h.Client.GetSections(projectID)
+		sections, err := client.GetSections(ctx, projectID)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -1160,7 +1176,7 @@ func (h *Handler) loadProjectTasks(projectID string) tea.Cmd {
 			tasks:    tasks,
 			sections: sections,
 		}
-	}
+	})
 }
 
 // filterProjectTasks filters cached tasks and sections for a project.
@@ -1190,81 +1206,27 @@ func (h *Handler) filterProjectTasks(projectID string) tea.Cmd {
 	return nil
 }
 
-// refreshTasks refreshes the current task list.
-func (h *Handler) refreshTasks() tea.Cmd {
-	return func() tea.Msg {
-		// Always fetch all tasks to keep the cache fresh
-		allTasks, err := h.Client.GetTasks(api.TaskFilter{})
+// syncData performs an incremental sync and refreshes the cache from the result.
+// The current view is re-filtered locally once the data arrives.
+func (h *Handler) syncData() tea.Cmd {
+	syncClient, store := h.SyncClient, h.Cache
+	return h.accountLoad(func(ctx context.Context) tea.Msg {
+		result, err := syncClient.Sync(ctx)
 		if err != nil {
 			return errMsg{err}
 		}
-
-		var filteredTasks []api.Task
-		switch h.CurrentView {
-		case state.ViewProject:
-			if h.CurrentProject != nil {
-				for _, t := range allTasks {
-					if t.ProjectID == h.CurrentProject.ID {
-						filteredTasks = append(filteredTasks, t)
-					}
-				}
-			}
-		case state.ViewLabels:
-			if h.CurrentLabel != nil {
-				for _, t := range allTasks {
-					for _, l := range t.Labels {
-						if l == h.CurrentLabel.Name {
-							filteredTasks = append(filteredTasks, t)
-							break
-						}
-					}
-				}
-			}
-		case state.ViewInbox:
-			var inboxID string
-			for _, p := range h.Projects {
-				if p.InboxProject {
-					inboxID = p.ID
-					break
-				}
-			}
-			for _, t := range allTasks {
-				if t.ProjectID == inboxID && !t.Checked && !t.IsDeleted {
-					filteredTasks = append(filteredTasks, t)
-				}
-			}
-		case state.ViewToday:
-			for _, t := range allTasks {
-				if t.IsDueToday() || t.IsOverdue() {
-					filteredTasks = append(filteredTasks, t)
-				}
-			}
-		default:
-			// Default to today | overdue for other views if appropriate
-			for _, t := range allTasks {
-				if t.IsDueToday() || t.IsOverdue() {
-					filteredTasks = append(filteredTasks, t)
-				}
-			}
-		}
-
+		saveCache(store, syncClient, result)
 		return dataLoadedMsg{
-			tasks:    filteredTasks,
-			allTasks: allTasks,
+			projects:    result.Projects,
+			allTasks:    result.Tasks,
+			allSections: result.Sections,
+			labels:      result.Labels,
+			filters:     result.Filters,
+			reminders:   result.Reminders,
+			user:        result.User,
+			synced:      true,
 		}
-	}
-}
-
-// loadTodayTasks loads today's tasks including overdue.
-func (h *Handler) loadTodayTasks() tea.Cmd {
-	return func() tea.Msg {
-		// Use filter endpoint for today | overdue
-		tasks, err := h.Client.GetTasksByFilter("today | overdue")
-		if err != nil {
-			return errMsg{err}
-		}
-		return dataLoadedMsg{tasks: tasks}
-	}
+	})
 }
 
 // loadInboxTasks filters cached tasks for inbox project (instant, no API call).
@@ -1347,29 +1309,6 @@ func (h *Handler) filterTodayTasks() tea.Cmd {
 	return nil
 }
 
-// loadUpcomingTasks loads all tasks with due dates for the upcoming view.
-func (h *Handler) loadUpcomingTasks() tea.Cmd {
-	return func() tea.Msg {
-		// Get all tasks
-		allTasks, err := h.Client.GetTasks(api.TaskFilter{})
-		if err != nil {
-			return errMsg{err}
-		}
-
-		// Filter to tasks with due dates
-		var upcoming []api.Task
-		for _, t := range allTasks {
-			if t.Due != nil {
-				upcoming = append(upcoming, t)
-			}
-		}
-		return dataLoadedMsg{
-			tasks:    upcoming,
-			allTasks: allTasks,
-		}
-	}
-}
-
 // filterUpcomingTasks filters cached tasks for upcoming view.
 func (h *Handler) filterUpcomingTasks() tea.Cmd {
 	var upcoming []api.Task
@@ -1383,48 +1322,40 @@ func (h *Handler) filterUpcomingTasks() tea.Cmd {
 	return nil
 }
 
-// loadAllTasks loads all tasks (for calendar view).
-func (h *Handler) loadAllTasks() tea.Cmd {
-	return func() tea.Msg {
-		allTasks, err := h.Client.GetTasks(api.TaskFilter{})
-		if err != nil {
-			return errMsg{err}
-		}
-		return dataLoadedMsg{allTasks: allTasks}
-	}
-}
-
 // loadProjects loads all projects.
 func (h *Handler) loadProjects() tea.Cmd {
-	return func() tea.Msg {
-		projects, err := h.Client.GetProjects()
+	client := h.Client
+	return h.accountLoad(func(ctx context.Context) tea.Msg {
+		projects, err := client.GetProjects(ctx)
 		if err != nil {
 			return errMsg{err}
 		}
 		return dataLoadedMsg{projects: projects}
-	}
+	})
 }
 
 // loadLabels loads all labels.
 func (h *Handler) loadLabels() tea.Cmd {
-	return func() tea.Msg {
-		labels, err := h.Client.GetLabels()
+	client := h.Client
+	return h.accountLoad(func(ctx context.Context) tea.Msg {
+		labels, err := client.GetLabels(ctx)
 		if err != nil {
 			return errMsg{err}
 		}
 		return dataLoadedMsg{labels: labels}
-	}
+	})
 }
 
 // loadLabelTasks loads tasks filtered by a specific label.
 func (h *Handler) loadLabelTasks(labelName string) tea.Cmd {
-	return func() tea.Msg {
-		tasks, err := h.Client.GetTasksByFilter("@" + labelName)
+	client := h.Client
+	return h.viewLoad(func(ctx context.Context) tea.Msg {
+		tasks, err := client.GetTasksByFilter(ctx, "@"+labelName)
 		if err != nil {
 			return errMsg{err}
 		}
 		return dataLoadedMsg{tasks: tasks}
-	}
+	})
 }
 
 // filterLabelTasks filters cached tasks for a label.
@@ -1491,13 +1422,14 @@ func (h *Handler) loadTaskComments() tea.Cmd {
 		return nil
 	}
 
-	return func() tea.Msg {
-		comments, err := h.Client.GetComments(taskID, "")
+	client := h.Client
+	return h.viewLoad(func(ctx context.Context) tea.Msg {
+		comments, err := client.GetComments(ctx, taskID, "")
 		if err != nil {
 			return errMsg{err}
 		}
 		return commentsLoadedMsg{comments: comments}
-	}
+	})
 }
 
 // buildSidebarItems constructs the sidebar items list with only projects (for Projects tab).
@@ -1589,8 +1521,9 @@ func (h *Handler) handleToggleFavorite() tea.Cmd {
 		h.StatusMsg = "Unfavoriting project..."
 	}
 
-	return func() tea.Msg {
-		updatedProject, err := h.Client.UpdateProject(projectID, api.UpdateProjectRequest{
+	ctx, client := h.Context(), h.Client
+	return h.accountChange(func() tea.Msg {
+		updatedProject, err := client.UpdateProject(ctx, projectID, api.UpdateProjectRequest{
 			Name:       &pName,
 			IsFavorite: api.BoolPtr(newStatus),
 		})
@@ -1598,5 +1531,5 @@ func (h *Handler) handleToggleFavorite() tea.Cmd {
 			return errMsg{err}
 		}
 		return projectUpdatedMsg{project: updatedProject}
-	}
+	})
 }
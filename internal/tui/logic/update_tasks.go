@@ -1,6 +1,7 @@
 package logic
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"sort"
@@ -15,8 +16,6 @@ import (
 	"github.com/hy4ri/todoist-tui/internal/api"
 )
 
-const maxConcurrentRequests = 5
-
 func (h *Handler) sortTasks() {
 
 	// Use hierarchical sorting for Project/Inbox views to respect ChildOrder and Tree structure
@@ -144,20 +143,28 @@ func (h *Handler) handleComplete() tea.Cmd {
 		return nil
 	}
 
-	// Store last action for undo (just the first one for simplicity/legacy support)
-	if len(tasksToComplete) == 1 {
-		t := tasksToComplete[0]
-		if t.Checked {
-			h.State.LastAction = &state.LastAction{Type: "uncomplete", TaskID: t.ID}
-		} else {
-			h.State.LastAction = &state.LastAction{Type: "complete", TaskID: t.ID}
-		}
+	// Record for undo
+	var undo, redo []api.SyncCommand
+	for _, t := range tasksToComplete {
+		u, r := toggleCompleteCommands(t)
+		undo = append(undo, u)
+		redo = append(redo, r)
 	}
+	entry := undoEntry(describeChange("Complete", tasksToComplete), undo, redo)
 
 	// --- Optimistic Update ---
 
+	// Recurring tasks move to their next occurrence rather than going away
+	now := time.Now()
 	idsToRemove := make(map[string]bool)
+	nextDues := make(map[string]*api.Due)
+	nextDates := make(map[string]time.Time)
 	for _, t := range tasksToComplete {
+		if due, parsed, ok := nextDue(t, now); ok && !t.Checked {
+			nextDues[t.ID] = due
+			nextDates[t.ID] = parsed
+			continue
+		}
 		idsToRemove[t.ID] = true
 	}
 
@@ -171,6 +178,23 @@ func (h *Handler) handleComplete() tea.Cmd {
 		return idsToRemove[t.ID]
 	})
 
+	if len(nextDues) > 0 {
+		moveToNext := func(t *api.Task) {
+			if due, ok := nextDues[t.ID]; ok {
+				parsed := nextDates[t.ID]
+				t.Due = copyDue(due)
+				t.ParsedDate = &parsed
+			}
+		}
+		for i := range h.AllTasks {
+			moveToNext(&h.AllTasks[i])
+		}
+		for i := range h.Tasks {
+			moveToNext(&h.Tasks[i])
+		}
+		h.refilterCurrentView()
+	}
+
 	// Clear Selection
 	h.clearSelection()
 
@@ -184,82 +208,24 @@ func (h *Handler) handleComplete() tea.Cmd {
 
 	// UI Feedback
 	h.StatusMsg = fmt.Sprintf("Completed %d tasks", len(tasksToComplete))
-	// Do NOT set h.Loading = true to keep UI responsive
-
-	// --- Background API Call ---
-	return func() tea.Msg {
-		// Concurrent processing
-		type result struct {
-			success bool
-			id      string
-			err     error
-		}
-		results := make(chan result, len(tasksToComplete))
-		sem := make(chan struct{}, maxConcurrentRequests)
-
-		for _, task := range tasksToComplete {
-			sem <- struct{}{}
-			go func(t api.Task) {
-				defer func() { <-sem }()
-				var err error
-				if t.Checked {
-					err = h.Client.ReopenTask(t.ID)
-				} else {
-					err = h.Client.CloseTask(t.ID)
-				}
-				results <- result{success: err == nil, id: t.ID, err: err}
-			}(task)
-		}
-
-		// Wait for results
-		failedCount := 0
-		for i := 0; i < len(tasksToComplete); i++ {
-			res := <-results
-			if !res.success {
-				failedCount++
-				// Refresh on failure is handled by returning taskUpdatedMsg below
-			}
-		}
-
-		if failedCount > 0 {
-			// Trigger a full refresh if something failed to ensure consistency
-			// We return a message that triggers refresh in update.go (like taskUpdatedMsg)
-			return taskUpdatedMsg{}
+	if len(tasksToComplete) == 1 {
+		t := tasksToComplete[0]
+		if next, ok := nextDates[t.ID]; ok {
+			h.StatusMsg = fmt.Sprintf("Completed '%s', next on %s", t.Content, next.Format("Mon Jan 2"))
 		}
-
-		// Success - UI is already updated.
-		return taskCompletedMsg{id: tasksToComplete[0].ID}
-	}
-}
-
-// handleUndo reverses the last undoable action.
-func (h *Handler) handleUndo() tea.Cmd {
-	if h.State.LastAction == nil {
-		h.StatusMsg = "Nothing to undo"
-		return nil
 	}
+	// Do NOT set h.Loading = true to keep UI responsive
 
-	action := h.State.LastAction
-	h.State.LastAction = nil
-	h.Loading = true
-
-	return func() tea.Msg {
-		var err error
-		switch action.Type {
-		case "complete":
-			// Was completed, so reopen it
-			err = h.Client.ReopenTask(action.TaskID)
-		case "uncomplete":
-			// Was reopened, so close it
-			err = h.Client.CloseTask(action.TaskID)
-		default:
-			return statusMsg{msg: "Unknown action"}
-		}
-		if err != nil {
-			return errMsg{err}
+	// --- Background API Call ---
+	cmds := make([]api.SyncCommand, len(tasksToComplete))
+	for i, t := range tasksToComplete {
+		if t.Checked {
+			cmds[i] = api.ReopenTaskCommand(t.ID)
+		} else {
+			cmds[i] = api.CloseTaskCommand(t.ID)
 		}
-		return undoCompletedMsg{}
 	}
+	return h.undoable(entry, h.sendBulk(cmds, taskCompletedMsg{id: tasksToComplete[0].ID}))
 }
 
 // handleToggleSelect toggles selection of the task under the cursor.
@@ -485,13 +451,25 @@ func (h *Handler) handleDelete() tea.Cmd {
 		return nil
 	}
 
-	// --- Optimistic Update ---
-
 	idsToRemove := make(map[string]bool)
 	for _, t := range tasksToDelete {
 		idsToRemove[t.ID] = true
 	}
 
+	// Record for undo: deleted tasks are recreated, including their subtasks
+	restoreCmds, restores := restoreTasksCommands(tasksToDelete, h.AllTasks)
+	var deleteCmds []api.SyncCommand
+	for _, t := range tasksToDelete {
+		// Subtasks go away with their parent
+		if t.ParentID == nil || !idsToRemove[*t.ParentID] {
+			deleteCmds = append(deleteCmds, api.DeleteTaskCommand(t.ID))
+		}
+	}
+	entry := undoEntry(describeChange("Delete", tasksToDelete), restoreCmds, deleteCmds)
+	entry.Restores = restores
+
+	// --- Optimistic Update ---
+
 	// Update AllTasks
 	h.AllTasks = slices.DeleteFunc(h.AllTasks, func(t api.Task) bool {
 		return idsToRemove[t.ID]
@@ -517,39 +495,11 @@ func (h *Handler) handleDelete() tea.Cmd {
 	h.StatusMsg = fmt.Sprintf("Deleted %d tasks", len(tasksToDelete))
 
 	// --- Background API Call ---
-	return func() tea.Msg {
-		type result struct {
-			success bool
-			id      string
-			err     error
-		}
-		results := make(chan result, len(tasksToDelete))
-		sem := make(chan struct{}, maxConcurrentRequests)
-
-		for _, task := range tasksToDelete {
-			sem <- struct{}{}
-			go func(t api.Task) {
-				defer func() { <-sem }()
-				err := h.Client.DeleteTask(t.ID)
-				results <- result{success: err == nil, id: t.ID, err: err}
-			}(task)
-		}
-
-		failedCount := 0
-		for i := 0; i < len(tasksToDelete); i++ {
-			res := <-results
-			if !res.success {
-				failedCount++
-			}
-		}
-
-		if failedCount > 0 {
-			// Trigger refresh on failure
-			return taskUpdatedMsg{}
-		}
-
-		return taskDeletedMsg{id: tasksToDelete[0].ID}
+	cmds := make([]api.SyncCommand, len(tasksToDelete))
+	for i, t := range tasksToDelete {
+		cmds[i] = api.DeleteTaskCommand(t.ID)
 	}
+	return h.undoable(entry, h.sendBulk(cmds, taskDeletedMsg{id: tasksToDelete[0].ID}))
 }
 
 // determineContextFromCursor identifies the project and section based on the current view and cursor position.
@@ -649,7 +599,7 @@ func (h *Handler) handleAddTaskFull() tea.Cmd {
 		h.TaskForm.SetDue("today")
 	}
 
-	return nil
+	return h.loadFormCollaborators()
 }
 
 // handleMoveTaskDate moves the task due date by the specified number of days.
@@ -661,16 +611,20 @@ func (h *Handler) handleMoveTaskDate(days int, preciseDate string) tea.Cmd {
 			task       *api.Task
 			allTaskIdx int
 			newDateStr string
-			updateReq  api.UpdateTaskRequest
+			cmd        api.SyncCommand
 		}
 
 		var updates []taskUpdate
+		var moved []api.Task
+		var undo, redo []api.SyncCommand
 
 		for i := range h.Tasks {
 			if !h.SelectedTaskIDs[h.Tasks[i].ID] {
 				continue
 			}
 			t := &h.Tasks[i]
+			moved = append(moved, *t)
+			undo = append(undo, api.SetDueCommand(t.ID, copyDue(t.Due)))
 
 			// Compute target date for this task
 			var newDateStr string
@@ -696,20 +650,15 @@ func (h *Handler) handleMoveTaskDate(days int, preciseDate string) tea.Cmd {
 				newDateStr = newDate.Format("2006-01-02")
 			}
 
-			// Build update request
-			var updateReq api.UpdateTaskRequest
+			// Build update command
+			var cmd api.SyncCommand
 			if newDateStr == "remove" {
 				noDate := "no date"
-				updateReq.DueString = &noDate
+				cmd = api.UpdateTaskCommand(t.ID, api.UpdateTaskRequest{DueString: &noDate})
 				// Optimistic: clear due on Tasks slice
 				t.Due = nil
 				t.ParsedDate = nil
 			} else {
-				updateReq.DueDate = &newDateStr
-				if t.Due != nil && t.Due.IsRecurring && t.Due.String != "" {
-					recurrence := t.Due.String
-					updateReq.DueString = &recurrence
-				}
 				// Optimistic: update due on Tasks slice
 				if t.Due == nil {
 					t.Due = &api.Due{}
@@ -719,6 +668,7 @@ func (h *Handler) handleMoveTaskDate(days int, preciseDate string) tea.Cmd {
 				if parsed, err := time.ParseInLocation("2006-01-02", newDateStr, time.Local); err == nil {
 					t.ParsedDate = &parsed
 				}
+				cmd = rescheduleCommand(t.ID, t.Due, newDateStr)
 			}
 
 			// Find index in AllTasks
@@ -730,13 +680,16 @@ func (h *Handler) handleMoveTaskDate(days int, preciseDate string) tea.Cmd {
 				}
 			}
 
-			updates = append(updates, taskUpdate{task: t, allTaskIdx: allIdx, newDateStr: newDateStr, updateReq: updateReq})
+			updates = append(updates, taskUpdate{task: t, allTaskIdx: allIdx, newDateStr: newDateStr, cmd: cmd})
+			redo = append(redo, cmd)
 		}
 
 		if len(updates) == 0 {
 			return nil
 		}
 
+		entry := undoEntry(describeChange("Reschedule", moved), undo, redo)
+
 		// Optimistic update AllTasks
 		for _, u := range updates {
 			if u.allTaskIdx < 0 {
@@ -766,23 +719,11 @@ func (h *Handler) handleMoveTaskDate(days int, preciseDate string) tea.Cmd {
 			h.StatusMsg = fmt.Sprintf("Rescheduled %d tasks", len(updates))
 		}
 
-		return func() tea.Msg {
-			type result struct{ err error }
-			results := make(chan result, len(updates))
-			sem := make(chan struct{}, maxConcurrentRequests)
-			for _, u := range updates {
-				sem <- struct{}{}
-				go func(req api.UpdateTaskRequest, tid string) {
-					defer func() { <-sem }()
-					_, err := h.Client.UpdateTask(tid, req)
-					results <- result{err: err}
-				}(u.updateReq, u.task.ID)
-			}
-			for range updates {
-				<-results
-			}
-			return taskUpdatedMsg{}
+		cmds := make([]api.SyncCommand, len(updates))
+		for i, u := range updates {
+			cmds[i] = u.cmd
 		}
+		return h.undoable(entry, h.sendBulk(cmds, taskUpdatedMsg{}))
 	}
 
 	// --- Single-task (cursor / detail-panel) branch ---
@@ -800,6 +741,8 @@ func (h *Handler) handleMoveTaskDate(days int, preciseDate string) tea.Cmd {
 	}
 
 	taskID := task.ID
+	before := *task
+	before.Due = copyDue(task.Due)
 
 	var newDateStr string
 
@@ -877,27 +820,45 @@ func (h *Handler) handleMoveTaskDate(days int, preciseDate string) tea.Cmd {
 		}
 	} else {
 		updateReq.DueDate = &newDateStr
-
-		// CRITICAL: Preserve recurring status
-		// If task is recurring, we MUST explicitly send the recurrence string.
-		// Otherwise the API might treat this as a one-off date assignment and convert it to non-recurring.
-		if task.Due != nil && task.Due.IsRecurring && task.Due.String != "" {
-			recurrence := task.Due.String
-			updateReq.DueString = &recurrence
-		}
+	}
+	cmd := api.UpdateTaskCommand(taskID, updateReq)
+	recurring := preciseDate != "remove" && task.Due != nil && task.Due.IsRecurring
+	if recurring {
+		cmd = rescheduleCommand(taskID, task.Due, newDateStr)
 	}
 
+	entry := undoEntry(describeChange("Reschedule", []api.Task{before}),
+		[]api.SyncCommand{api.SetDueCommand(taskID, before.Due)},
+		[]api.SyncCommand{cmd})
+
 	// Re-filter visible tasks so the task disappears from date-filtered views
 	h.refilterCurrentView()
 
-	return func() tea.Msg {
-		_, err := h.Client.UpdateTask(taskID, updateReq)
+	if recurring {
+		return h.undoable(entry, h.sendBulk([]api.SyncCommand{cmd}, taskUpdatedMsg{}))
+	}
+
+	ctx, client, outbox := h.Context(), h.Client, h.Outbox
+	return h.undoable(entry, func() tea.Msg {
+		_, err := client.UpdateTask(ctx, taskID, updateReq)
 		if err != nil {
-			return errMsg{err}
+			return queueOffline(outbox, err, api.UpdateTaskCommand(taskID, updateReq))
 		}
 		// Refresh tasks
 		return taskUpdatedMsg{}
+	})
+}
+
+// rescheduleCommand moves a task to date. A recurring task keeps its rule
+// and only this occurrence moves, which a plain due date would replace.
+func rescheduleCommand(id string, due *api.Due, date string) api.SyncCommand {
+	if due == nil || !due.IsRecurring {
+		return api.UpdateTaskCommand(id, api.UpdateTaskRequest{DueDate: &date})
 	}
+	moved := copyDue(due)
+	moved.Date = date
+	moved.Datetime = nil
+	return api.SetDueCommand(id, moved)
 }
 
 // handleReschedule handles smart rescheduling options.
@@ -1005,7 +966,7 @@ func (h *Handler) handleEdit() tea.Cmd {
 	h.TaskForm = state.NewEditTaskForm(task, h.Projects, h.Labels)
 	h.TaskForm.SetWidth(h.Width)
 
-	return nil
+	return h.loadFormCollaborators()
 }
 
 // handleNewProject opens the project creation input.
@@ -1016,6 +977,14 @@ func (h *Handler) submitForm() tea.Cmd {
 		h.StatusMsg = "Task name is required"
 		return nil
 	}
+	if _, ok := h.TaskForm.DurationValue(); !ok {
+		h.StatusMsg = "Unrecognized duration: " + h.TaskForm.Duration.Value()
+		return nil
+	}
+	if _, ok := h.TaskForm.DeadlineDate(); !ok {
+		h.StatusMsg = "Unrecognized deadline: " + h.TaskForm.Deadline.Value()
+		return nil
+	}
 
 	if h.Loading {
 		return nil
@@ -1046,6 +1015,19 @@ func (h *Handler) submitForm() tea.Cmd {
 	formParams := updateReq // Use the request object to get other fields if needed
 
 	if isEdit {
+		// Capture the undo entry before the local copy changes
+		var entry state.UndoEntry
+		for _, t := range h.AllTasks {
+			if t.ID == taskID {
+				before := t
+				before.Due = copyDue(t.Due)
+				entry = undoEntry(describeChange("Edit", []api.Task{before}),
+					[]api.SyncCommand{api.RevertTaskCommand(before)},
+					[]api.SyncCommand{api.UpdateTaskCommand(taskID, updateReq)})
+				break
+			}
+		}
+
 		// Find and update local task
 		updateLocalTask := func(t *api.Task) {
 			if formParams.Content != nil {
@@ -1067,6 +1049,26 @@ func (h *Handler) submitForm() tea.Cmd {
 			}
 			// Update Labels
 			t.Labels = h.TaskForm.Labels
+			switch {
+			case formParams.Duration == nil:
+			case *formParams.Duration == 0:
+				t.Duration = nil
+			case formParams.DurationUnit != nil:
+				t.Duration = &api.Duration{Amount: *formParams.Duration, Unit: *formParams.DurationUnit}
+			}
+			if formParams.DeadlineDate != nil {
+				t.Deadline = nil
+				if *formParams.DeadlineDate != "" {
+					t.Deadline = &api.Deadline{Date: *formParams.DeadlineDate}
+				}
+			}
+			if formParams.AssigneeID != nil {
+				t.ResponsibleUID = nil
+				if *formParams.AssigneeID != "" {
+					assignee := *formParams.AssigneeID
+					t.ResponsibleUID = &assignee
+				}
+			}
 
 			// Handle Due Date/Time
 			if formDate != "" {
@@ -1110,23 +1112,25 @@ func (h *Handler) submitForm() tea.Cmd {
 		h.refilterCurrentView()
 
 		h.TaskForm = nil
-		return func() tea.Msg {
-			_, err := h.Client.UpdateTask(taskID, updateReq)
+		ctx, client, outbox := h.Context(), h.Client, h.Outbox
+		return h.undoable(entry, func() tea.Msg {
+			_, err := client.UpdateTask(ctx, taskID, updateReq)
 			if err != nil {
-				return errMsg{err}
+				return queueOffline(outbox, err, api.UpdateTaskCommand(taskID, updateReq))
 			}
 			return taskUpdatedMsg{}
-		}
+		})
 	}
 
 	// Create new task
-	return func() tea.Msg {
-		_, err := h.Client.CreateTask(createReq)
+	ctx, client, outbox := h.Context(), h.Client, h.Outbox
+	return h.accountChange(func() tea.Msg {
+		task, err := client.CreateTask(ctx, createReq)
 		if err != nil {
-			return errMsg{err}
+			return queueOfflineCreate(outbox, err, api.AddTaskCommand(createReq), taskFromCreateRequest(createReq))
 		}
-		return taskCreatedMsg{}
-	}
+		return taskCreatedMsg{task: task}
+	})
 }
 
 // filterInboxTasks filters tasks for the inbox project.
@@ -1211,10 +1215,11 @@ func (h *Handler) handleSectionAddInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		h.SectionAddInput.Reset()
 		h.StatusMsg = "Adding task..."
 
-		return func() tea.Msg {
+		ctx, client := h.Context(), h.Client
+		return h.accountChange(func() tea.Msg {
 			// Send clean text to QuickAddTask for NLP (dates, priorities, labels)
 			// Do NOT append #ProjectName (fails with spaces in project names)
-			task, err := h.Client.QuickAddTask(content)
+			task, err := client.QuickAddTask(ctx, content)
 			if err != nil {
 				return errMsg{err}
 			}
@@ -1228,17 +1233,20 @@ func (h *Handler) handleSectionAddInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 				if targetSID != "" {
 					secPtr = &targetSID
 				}
-				if err := h.Client.MoveTask(task.ID, secPtr, &targetPID, nil); err != nil {
+				if err := client.MoveTask(ctx, task.ID, secPtr, &targetPID, nil); err != nil {
 					return errMsg{err}
 				}
+				task.ProjectID = targetPID
+				task.SectionID = secPtr
 			} else if targetSID != "" && (task.SectionID == nil || *task.SectionID != targetSID) {
-				if err := h.Client.MoveTask(task.ID, &targetSID, nil, nil); err != nil {
+				if err := client.MoveTask(ctx, task.ID, &targetSID, nil, nil); err != nil {
 					return errMsg{err}
 				}
+				task.SectionID = &targetSID
 			}
 
-			return quickAddTaskCreatedMsg{}
-		}
+			return quickAddTaskCreatedMsg{task: task}
+		})
 
 	default:
 		var cmd tea.Cmd
@@ -1252,7 +1260,8 @@ func (h *Handler) loadCompletedTasks() tea.Cmd {
 	h.Loading = true
 	h.StatusMsg = "Loading completed tasks..."
 
-	return func() tea.Msg {
+	client := h.Client
+	return h.viewLoad(func(ctx context.Context) tea.Msg {
 		params := api.CompletedTaskParams{
 			Limit:  h.CompletedLimit,
 			Offset: h.CompletedPage * h.CompletedLimit,
@@ -1273,13 +1282,13 @@ func (h *Handler) loadCompletedTasks() tea.Cmd {
 			params.Since = time.Now().AddDate(0, -1, 0).Format(time.RFC3339)
 		}
 
-		tasks, err := h.Client.GetCompletedTasks(params)
+		tasks, err := client.GetCompletedTasks(ctx, params)
 		if err != nil {
 			return errMsg{err}
 		}
 
 		return completedTasksLoadedMsg(tasks)
-	}
+	})
 }
 
 // handleIndent indents the selected task (makes it a subtask of the one above).
@@ -1384,6 +1393,10 @@ func (h *Handler) handleIndentSelect() tea.Cmd {
 	// Create pointer
 	parentIDPtr := &parentID
 
+	entry := undoEntry(describeChange("Indent", []api.Task{currentTask}),
+		[]api.SyncCommand{moveBackCommand(currentTask)},
+		[]api.SyncCommand{api.MoveTaskCommand(currentTask.ID, nil, nil, parentIDPtr)})
+
 	// Optimistic update
 	currentTask.ParentID = parentIDPtr
 	h.Tasks[taskIndex] = currentTask
@@ -1402,13 +1415,14 @@ func (h *Handler) handleIndentSelect() tea.Cmd {
 	h.IndentFilteredCandidates = nil
 	h.StatusMsg = fmt.Sprintf("Indented under '%s'", parentTask.Content)
 
-	return func() tea.Msg {
-		err := h.Client.MoveTask(currentTask.ID, nil, nil, parentIDPtr)
+	ctx, client, outbox := h.Context(), h.Client, h.Outbox
+	return h.undoable(entry, func() tea.Msg {
+		err := client.MoveTask(ctx, currentTask.ID, nil, nil, parentIDPtr)
 		if err != nil {
-			return errMsg{err}
+			return queueOffline(outbox, err, api.MoveTaskCommand(currentTask.ID, nil, nil, parentIDPtr))
 		}
 		return refreshMsg{Force: true}
-	}
+	})
 }
 
 // handleOutdent outdents the selected task (moves it up one level).
@@ -1473,6 +1487,11 @@ func (h *Handler) handleOutdent() tea.Cmd {
 		newParentID = nil
 	}
 
+	redo := api.MoveTaskCommand(currentTask.ID, nil, &currentTask.ProjectID, newParentID)
+	entry := undoEntry(describeChange("Outdent", []api.Task{currentTask}),
+		[]api.SyncCommand{moveBackCommand(currentTask)},
+		[]api.SyncCommand{redo})
+
 	// Optimistic update
 	currentTask.ParentID = newParentID
 	h.Tasks[taskIndex] = currentTask
@@ -1485,7 +1504,8 @@ func (h *Handler) handleOutdent() tea.Cmd {
 		}
 	}
 
-	return func() tea.Msg {
+	ctx, client, outbox := h.Context(), h.Client, h.Outbox
+	return h.undoable(entry, func() tea.Msg {
 		var pid string
 		var projectID *string
 		if newParentID != nil {
@@ -1497,13 +1517,13 @@ func (h *Handler) handleOutdent() tea.Cmd {
 			projectID = &pID
 		}
 
-		err := h.Client.MoveTask(currentTask.ID, nil, projectID, &pid)
+		err := client.MoveTask(ctx, currentTask.ID, nil, projectID, &pid)
 
 		if err != nil {
-			return errMsg{err}
+			return queueOffline(outbox, err, api.MoveTaskCommand(currentTask.ID, nil, projectID, &pid))
 		}
 		return refreshMsg{Force: true}
-	}
+	})
 }
 
 // updateIndentFilter filters the indent candidates based on input.
@@ -1545,13 +1565,14 @@ func (h *Handler) loadTaskDetails() tea.Cmd {
 
 // fetchReminders fetches reminders for a task.
 func (h *Handler) fetchReminders(taskID string) tea.Cmd {
-	return func() tea.Msg {
-		reminders, err := h.Client.GetRemindersForTask(taskID)
+	client := h.Client
+	return h.accountLoad(func(ctx context.Context) tea.Msg {
+		reminders, err := client.GetRemindersForTask(ctx, taskID)
 		if err != nil {
 			return errMsg{err}
 		}
 		return remindersFetchedMsg{taskID: taskID, reminders: reminders}
-	}
+	})
 }
 
 // handleAddReminder initiates the add reminder flow.
@@ -1619,13 +1640,14 @@ func (h *Handler) handleDeleteReminder() tea.Cmd {
 
 	h.StatusMsg = "Deleting reminder..."
 
-	return func() tea.Msg {
-		err := h.Client.DeleteReminder(id)
+	ctx, client := h.Context(), h.Client
+	return h.accountChange(func() tea.Msg {
+		err := client.DeleteReminder(ctx, id)
 		if err != nil {
 			return errMsg{err}
 		}
 		return reminderDeletedMsg{id: id}
-	}
+	})
 }
 
 // submitReminderForm handles the submission of the reminder form (create/update).
@@ -1673,11 +1695,12 @@ func (h *Handler) submitReminderForm() tea.Cmd {
 	h.StatusMsg = "Saving reminder..."
 	h.Loading = true
 
-	return func() tea.Msg {
-		rem, err := h.Client.CreateReminder(req)
+	ctx, client := h.Context(), h.Client
+	return h.accountChange(func() tea.Msg {
+		rem, err := client.CreateReminder(ctx, req)
 		if err != nil {
 			return errMsg{err}
 		}
 		return reminderCreatedMsg{reminder: rem}
-	}
+	})
 }
@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+)
+
+func TestSubmitForm_Duration(t *testing.T) {
+	h := newTasksHandler(api.Task{ID: "t1", Content: "Write report", ProjectID: "p1", Duration: &api.Duration{Amount: 30, Unit: "minute"}})
+
+	submit := func(duration string) tea.Cmd {
+		t.Helper()
+		h.Loading = false
+		h.PreviousView = h.CurrentView
+		h.TaskForm = state.NewEditTaskForm(&h.Tasks[0], h.Projects, h.Labels)
+		h.TaskForm.Duration.SetValue(duration)
+		return h.submitForm()
+	}
+
+	if submit("1h30m") == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	for _, task := range []api.Task{h.Tasks[0], h.AllTasks[0]} {
+		if task.Duration == nil || task.Duration.Amount != 90 || task.Duration.Unit != "minute" {
+			t.Errorf("expected an optimistic duration of 90 minutes, got %+v", task.Duration)
+		}
+	}
+
+	if submit("") == nil {
+		t.Fatalf("expected a command, status %q", h.StatusMsg)
+	}
+	if h.Tasks[0].Duration != nil || h.AllTasks[0].Duration != nil {
+		t.Errorf("expected the duration to be removed, got %+v", h.Tasks[0].Duration)
+	}
+
+	if submit("soon") != nil {
+		t.Error("expected no command for an unrecognized duration")
+	}
+	if h.StatusMsg != "Unrecognized duration: soon" {
+		t.Errorf("status = %q", h.StatusMsg)
+	}
+}
@@ -0,0 +1,104 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/components"
+	"github.com/hy4ri/todoist-tui/internal/tui/state"
+)
+
+func newViewLoadHandler(client *api.Client) *Handler {
+	project := api.Project{ID: "p1", Name: "Work"}
+	s := &state.State{
+		Client:         client,
+		Projects:       []api.Project{project},
+		CurrentProject: &project,
+		SelectionState: state.SelectionState{
+			SelectedTaskIDs: make(map[string]bool),
+		},
+		CurrentTab:  state.TabProjects,
+		FocusedPane: state.PaneMain,
+		CurrentView: state.ViewProject,
+		SidebarComp: components.NewSidebar(),
+	}
+	return NewHandler(s)
+}
+
+// newTasksHandler returns a handler viewing project "p1" with tasks loaded,
+// for tests of the local state of a change that don't send it.
+func newTasksHandler(tasks ...api.Task) *Handler {
+	h := newViewLoadHandler(api.NewClient("test-token"))
+	h.Tasks = tasks
+	h.AllTasks = append([]api.Task(nil), tasks...)
+	return h
+}
+
+func TestSwitchToTab_CancelsViewLoads(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		w.Write([]byte(`{"results": [{"id": "stale", "project_id": "p1"}]}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := api.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+	h := newViewLoadHandler(client)
+
+	cmd := h.loadProjectTasks("p1")
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+
+	h.switchToTab(state.TabToday)
+
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("load was not cancelled by the tab switch")
+	}
+
+	loaded, ok := msg.(viewLoadedMsg)
+	if !ok {
+		t.Fatalf("expected viewLoadedMsg, got %T", msg)
+	}
+	if inner, ok := loaded.msg.(errMsg); !ok || !errors.Is(inner.err, context.Canceled) {
+		t.Errorf("expected a cancellation error, got %#v", loaded.msg)
+	}
+
+	h.Update(msg)
+	if h.Err != nil {
+		t.Errorf("cancellation should not be reported, got %v", h.Err)
+	}
+	if len(h.Tasks) != 0 {
+		t.Errorf("stale tasks replaced the new view: %v", h.Tasks)
+	}
+}
+
+func TestViewLoad_AppliesCurrentResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results": [{"id": "t1", "project_id": "p1"}]}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient("test-token")
+	client.SetBaseURL(server.URL)
+	h := newViewLoadHandler(client)
+
+	h.Update(h.loadProjectTasks("p1")())
+	if len(h.Tasks) != 1 || h.Tasks[0].ID != "t1" {
+		t.Errorf("expected the loaded task to be shown, got %v", h.Tasks)
+	}
+}
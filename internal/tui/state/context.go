@@ -0,0 +1,62 @@
+package state
+
+import "context"
+
+// ContextState holds the contexts API requests run under. Its methods must
+// be called from the update loop, before a command's closure is built.
+type ContextState struct {
+	// Ctx lives as long as the app; nil means context.Background().
+	Ctx context.Context
+
+	accountCtx    context.Context
+	cancelAccount context.CancelFunc
+
+	viewCtx    context.Context
+	cancelView context.CancelFunc
+}
+
+// Context returns the app context, for requests that must finish even if
+// the user moves on, such as edits.
+func (c *ContextState) Context() context.Context {
+	if c.Ctx == nil {
+		return context.Background()
+	}
+	return c.Ctx
+}
+
+// AccountContext returns the context for loading the data of the signed-in
+// account. It is cancelled by CancelAccountLoads.
+func (c *ContextState) AccountContext() context.Context {
+	if c.accountCtx == nil {
+		c.accountCtx, c.cancelAccount = context.WithCancel(c.Context())
+	}
+	return c.accountCtx
+}
+
+// CancelAccountLoads cancels the requests started for the signed-in account,
+// including those of the current view, when switching to another one.
+func (c *ContextState) CancelAccountLoads() {
+	c.CancelViewLoads()
+	if c.cancelAccount != nil {
+		c.cancelAccount()
+	}
+	c.accountCtx, c.cancelAccount = nil, nil
+}
+
+// ViewContext returns the context for loading data into the current view.
+// It is cancelled by CancelViewLoads.
+func (c *ContextState) ViewContext() context.Context {
+	if c.viewCtx == nil {
+		c.viewCtx, c.cancelView = context.WithCancel(c.AccountContext())
+	}
+	return c.viewCtx
+}
+
+// CancelViewLoads cancels the requests started for the current view. Loads
+// started afterwards get a fresh context.
+func (c *ContextState) CancelViewLoads() {
+	if c.cancelView != nil {
+		c.cancelView()
+	}
+	c.viewCtx, c.cancelView = nil, nil
+}
@@ -38,6 +38,7 @@ type KeymapData struct {
 	EditTask        Key
 	DeleteTask      Key
 	CompleteTask    Key
+	CompleteForever Key
 	Priority1       Key
 	Priority2       Key
 	Priority3       Key
@@ -92,6 +93,7 @@ func DefaultKeymap() KeymapData {
 		EditTask:        Key{Key: "e", Help: "edit task"},
 		DeleteTask:      Key{Key: "d", Help: "delete (dd)"},
 		CompleteTask:    Key{Key: "x", Help: "complete/uncomplete"},
+		CompleteForever: Key{Key: "X", Help: "complete permanently"},
 		Priority1:       Key{Key: "1", Help: "priority 1 (highest)"},
 		Priority2:       Key{Key: "2", Help: "priority 2"},
 		Priority3:       Key{Key: "3", Help: "priority 3"},
@@ -151,6 +153,7 @@ func (k *KeymapData) ApplyOverrides(overrides map[string]string) []string {
 		"edit_task":        &k.EditTask.Key,
 		"delete_task":      &k.DeleteTask.Key,
 		"complete":         &k.CompleteTask.Key,
+		"complete_forever": &k.CompleteForever.Key,
 		"priority1":        &k.Priority1.Key,
 		"priority2":        &k.Priority2.Key,
 		"priority3":        &k.Priority3.Key,
@@ -309,10 +312,14 @@ func (ks *KeyState) HandleKey(msg tea.KeyMsg, km interface{}) (string, bool) {
 		return "manage_sections", true
 	case keymap.CompleteTask.Key:
 		return "complete", true
+	case keymap.CompleteForever.Key:
+		return "complete_forever", true
 	case "ctrl+z":
 		return "undo", true
 	case "u":
 		return "undo", true
+	case "ctrl+r":
+		return "redo", true
 	case "m":
 		return "move_task", true
 	case "M":
@@ -408,6 +415,7 @@ func (k KeymapData) HelpItems() [][]string {
 		{k.AddTaskFull.Key, "Add new task (full)"},
 		{k.EditTask.Key, "Edit task content"},
 		{k.CompleteTask.Key, "Complete/uncomplete task"},
+		{k.CompleteForever.Key, "Complete recurring task permanently"},
 		{"dd", "Delete task"},
 		{"yy", "Copy task Content (+Desc)"},
 		{"Space", "Toggle selection"},
@@ -420,6 +428,8 @@ func (k KeymapData) HelpItems() [][]string {
 		{k.AddComment.Key, "Add/View comments"},
 		{k.Reminder.Key, "Manage reminders"},
 		{k.RescheduleTask.Key, "Smart Reschedule"},
+		{"u/Ctrl+z", "Undo last change"},
+		{"Ctrl+r", "Redo last undone change"},
 		{"", ""},
 
 		{"Label/Project Actions", ""},
@@ -26,7 +26,7 @@ type QuickAddForm struct {
 // NewQuickAddForm creates a new quick add form.
 func NewQuickAddForm() *QuickAddForm {
 	input := textarea.New()
-	input.Placeholder = "e.g. Buy milk tomorrow 3pm @errands #Shopping p1"
+	input.Placeholder = "e.g. Buy milk tomorrow 3pm @errands #Shopping p1 {fri}"
 	input.Focus()
 	input.CharLimit = 500
 	input.SetWidth(60)
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/cache"
 	"github.com/hy4ri/todoist-tui/internal/config"
 	"github.com/hy4ri/todoist-tui/internal/tui/components"
 )
@@ -82,12 +83,6 @@ const (
 	PomodoroLongBreak
 )
 
-// LastAction represents an undoable action.
-type LastAction struct {
-	Type   string // "complete", "uncomplete"
-	TaskID string
-}
-
 // Keymap defines keybindings.
 type Keymap interface {
 	HelpItems() [][]string
@@ -162,10 +157,20 @@ type State struct {
 	SelectionState
 	ReminderState
 	RescheduleState
+	ContextState
 
 	// Dependencies
-	Client *api.Client
-	Config *config.Config
+	Client     *api.Client
+	SyncClient *api.SyncClient
+	Cache      *cache.Store
+	Outbox     *cache.Outbox
+	Config     *config.Config
+
+	// NewClient creates a client for the active profile; nil disables :profile
+	NewClient func() (*api.Client, error)
+
+	// OutboxRetryPending is set while a retry of queued offline changes is scheduled
+	OutboxRetryPending bool
 
 	// View state
 	CurrentView  View
@@ -202,12 +207,15 @@ type State struct {
 	CompletedLimit    int
 	CompletedMore     bool // If there are more tasks to fetch
 	TasksByDate       map[string][]api.Task
+	DeadlinesByDate   map[string][]api.Task // Open tasks by deadline date
 	SelectedTask      *api.Task
 	CurrentProject    *api.Project
 	CurrentLabel      *api.Label
 	LastSelectedTask  *api.Task // Last task highlighted by the cursor before view switch
 	ProductivityStats *api.ProductivityStats
 	StatsError        string
+	User              *api.User                     // Account of the token; nil until loaded
+	Collaborators     map[string][]api.Collaborator // Project ID -> collaborators of shared projects
 
 	SidebarCursor int
 
@@ -223,7 +231,7 @@ type State struct {
 	Width           int
 	Height          int
 	ShowHints       bool
-	LastAction      *LastAction
+	UndoHistory     UndoHistory
 	ShowDetailPanel bool
 
 	// Components
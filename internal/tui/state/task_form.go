@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
 )
 
 // FormField constants for focus management
@@ -16,13 +17,16 @@ const (
 	FormFieldDescription
 	FormFieldDue
 	FormFieldDueTime // New field
+	FormFieldDuration
+	FormFieldDeadline
 	FormFieldPriority
 	FormFieldShowProject
 	FormFieldLabels
+	FormFieldAssignee
 	FormFieldSubmit
 )
 
-const formFieldCount = 8
+const formFieldCount = 11
 
 // TaskForm represents the state of the task creation/editing form.
 type TaskForm struct {
@@ -31,22 +35,27 @@ type TaskForm struct {
 	Priority    int
 	DueString   textinput.Model
 	DueTime     textinput.Model // New field
+	Duration    textinput.Model
+	Deadline    textinput.Model
 	ProjectID   string
 	SectionID   string
 	Labels      []string
+	AssigneeID  string
 	Original    *api.Task
 
 	// Helpers for logic/ui
-	ShowProjectList bool
-	ShowLabelList   bool
-	FocusIndex      int
-	ProjectName     string
-	SectionName     string
-	Context         string
+	ShowProjectList  bool
+	ShowLabelList    bool
+	ShowAssigneeList bool
+	FocusIndex       int
+	ProjectName      string
+	SectionName      string
+	Context          string
 
 	// Dropdown Cursors
-	ProjectListCursor int
-	LabelListCursor   int
+	ProjectListCursor  int
+	LabelListCursor    int
+	AssigneeListCursor int
 
 	// Mode tracking
 	Mode   string // "create" or "edit"
@@ -55,6 +64,7 @@ type TaskForm struct {
 	// Data for completion/selection
 	AvailableProjects []api.Project
 	AvailableLabels   []api.Label
+	Collaborators     map[string][]api.Collaborator // Project ID -> collaborators, shared with the state
 }
 
 // Update updates the form models.
@@ -138,6 +148,10 @@ func (f *TaskForm) Update(msg tea.Msg) tea.Cmd {
 				case "enter", "space":
 					if len(f.AvailableProjects) > 0 && f.ProjectListCursor < len(f.AvailableProjects) {
 						selectedProject := f.AvailableProjects[f.ProjectListCursor]
+						if selectedProject.ID != f.ProjectID {
+							// Assignees are collaborators of a single project
+							f.AssigneeID = ""
+						}
 						f.ProjectID = selectedProject.ID
 						f.ProjectName = selectedProject.Name
 						f.ShowProjectList = false
@@ -189,7 +203,43 @@ func (f *TaskForm) Update(msg tea.Msg) tea.Cmd {
 					}
 				}
 			}
-
+		case FormFieldAssignee:
+			options := f.AssigneeOptions()
+			if !f.ShowAssigneeList {
+				if msg.String() == "enter" || msg.String() == "space" {
+					f.ShowAssigneeList = true
+					// Initialize cursor to current assignee; 0 is "Unassigned"
+					f.AssigneeListCursor = 0
+					for i, c := range options {
+						if c.ID == f.AssigneeID {
+							f.AssigneeListCursor = i + 1
+							break
+						}
+					}
+					return nil
+				}
+			} else {
+				switch msg.String() {
+				case "esc":
+					f.ShowAssigneeList = false
+					return nil
+				case "up", "k":
+					if f.AssigneeListCursor > 0 {
+						f.AssigneeListCursor--
+					}
+				case "down", "j":
+					if f.AssigneeListCursor < len(options) {
+						f.AssigneeListCursor++
+					}
+				case "enter", "space":
+					f.AssigneeID = ""
+					if f.AssigneeListCursor > 0 && f.AssigneeListCursor <= len(options) {
+						f.AssigneeID = options[f.AssigneeListCursor-1].ID
+					}
+					f.ShowAssigneeList = false
+					return nil
+				}
+			}
 		}
 	}
 
@@ -210,18 +260,64 @@ func (f *TaskForm) Update(msg tea.Msg) tea.Cmd {
 		f.DueTime, cmd = f.DueTime.Update(msg)
 		cmds = append(cmds, cmd)
 	}
+	if f.FocusIndex == FormFieldDuration {
+		f.Duration, cmd = f.Duration.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if f.FocusIndex == FormFieldDeadline {
+		f.Deadline, cmd = f.Deadline.Update(msg)
+		cmds = append(cmds, cmd)
+	}
 
 	return tea.Batch(cmds...)
 }
 
 // NextField moves focus to the next field.
 func (f *TaskForm) NextField() {
-	f.Focus((f.FocusIndex + 1) % formFieldCount)
+	next := (f.FocusIndex + 1) % formFieldCount
+	if next == FormFieldAssignee && !f.CanAssign() {
+		next = (next + 1) % formFieldCount
+	}
+	f.Focus(next)
 }
 
 // PrevField moves focus to the previous field.
 func (f *TaskForm) PrevField() {
-	f.Focus((f.FocusIndex - 1 + formFieldCount) % formFieldCount)
+	prev := (f.FocusIndex - 1 + formFieldCount) % formFieldCount
+	if prev == FormFieldAssignee && !f.CanAssign() {
+		prev = (prev - 1 + formFieldCount) % formFieldCount
+	}
+	f.Focus(prev)
+}
+
+// CanAssign reports whether the task's project is shared, so it can be
+// assigned to one of its collaborators.
+func (f *TaskForm) CanAssign() bool {
+	for _, p := range f.AvailableProjects {
+		if p.ID == f.ProjectID {
+			return p.Shared
+		}
+	}
+	return false
+}
+
+// AssigneeOptions returns the collaborators the task can be assigned to.
+func (f *TaskForm) AssigneeOptions() []api.Collaborator {
+	if !f.CanAssign() {
+		return nil
+	}
+	return f.Collaborators[f.ProjectID]
+}
+
+// AssigneeName returns the name of the assignee, or "" if there is none.
+func (f *TaskForm) AssigneeName() string {
+	if f.AssigneeID == "" {
+		return ""
+	}
+	if c, ok := utils.FindCollaborator(f.Collaborators, f.ProjectID, f.AssigneeID); ok {
+		return c.Name
+	}
+	return "Unknown user"
 }
 
 // NewTaskForm creates a new task form.
@@ -251,12 +347,22 @@ func NewTaskForm(projects []api.Project, labels []api.Label) *TaskForm {
 	dueTime.Placeholder = "Time (e.g. 10pm)"
 	dueTime.Width = 15
 
+	duration := textinput.New()
+	duration.Placeholder = "Duration (e.g. 45m)"
+	duration.Width = 20
+
+	deadline := textinput.New()
+	deadline.Placeholder = "Deadline (e.g. fri)"
+	deadline.Width = 20
+
 	return &TaskForm{
 		Content:           content,
 		Description:       desc,
 		Priority:          1, // Default to P4 (1)
 		DueString:         due,
 		DueTime:           dueTime,
+		Duration:          duration,
+		Deadline:          deadline,
 		Labels:            []string{},
 		ShowProjectList:   false,
 		AvailableProjects: projects,
@@ -299,12 +405,21 @@ func NewEditTaskForm(t *api.Task, projects []api.Project, labels []api.Label) *T
 			f.DueString.SetValue(t.Due.String)
 		}
 	}
+	if badge := utils.DurationBadge(t.Duration); badge != "" {
+		f.Duration.SetValue(badge)
+	}
+	if t.Deadline != nil {
+		f.Deadline.SetValue(t.Deadline.Date)
+	}
 	f.ProjectID = t.ProjectID
 	f.SectionID = "" // need to lookup
 	if t.SectionID != nil {
 		f.SectionID = *t.SectionID
 	}
 	f.Labels = t.Labels
+	if t.ResponsibleUID != nil {
+		f.AssigneeID = *t.ResponsibleUID
+	}
 
 	// Find project name
 	for _, p := range projects {
@@ -325,6 +440,34 @@ func (f *TaskForm) IsValid() bool {
 	return strings.TrimSpace(f.Content.Value()) != ""
 }
 
+// DurationValue reads the duration field. An empty field gives nil. It
+// reports false when the text is not a duration.
+func (f *TaskForm) DurationValue() (*api.Duration, bool) {
+	text := strings.TrimSpace(f.Duration.Value())
+	if text == "" {
+		return nil, true
+	}
+	d, ok := utils.ParseDuration(text)
+	if !ok {
+		return nil, false
+	}
+	return &d, true
+}
+
+// DeadlineDate resolves the deadline field to a YYYY-MM-DD date. An empty
+// field gives "". It reports false when the text is not a date.
+func (f *TaskForm) DeadlineDate() (string, bool) {
+	text := strings.TrimSpace(f.Deadline.Value())
+	if text == "" {
+		return "", true
+	}
+	day, ok := utils.ParseDate(text, time.Now())
+	if !ok {
+		return "", false
+	}
+	return day.Format(utils.DateLayout), true
+}
+
 // ToCreateRequest converts form to create request.
 func (f *TaskForm) ToCreateRequest() api.CreateTaskRequest {
 	content := strings.TrimSpace(f.Content.Value())
@@ -344,15 +487,24 @@ func (f *TaskForm) ToCreateRequest() api.CreateTaskRequest {
 	// So internal priority 4 = High.
 	// API AddParams uses Priority.
 
-	return api.CreateTaskRequest{
-		Content:     content,
-		Description: desc,
-		Priority:    f.Priority,
-		DueString:   due,
-		ProjectID:   f.ProjectID,
-		SectionID:   f.SectionID,
-		Labels:      f.Labels,
+	deadline, _ := f.DeadlineDate()
+
+	req := api.CreateTaskRequest{
+		Content:      content,
+		Description:  desc,
+		Priority:     f.Priority,
+		DueString:    due,
+		DeadlineDate: deadline,
+		ProjectID:    f.ProjectID,
+		SectionID:    f.SectionID,
+		Labels:       f.Labels,
+		AssigneeID:   f.AssigneeID,
 	}
+	if d, _ := f.DurationValue(); d != nil {
+		req.Duration = d.Amount
+		req.DurationUnit = d.Unit
+	}
+	return req
 }
 
 // ToUpdateRequest converts form to update request.
@@ -375,6 +527,40 @@ func (f *TaskForm) ToUpdateRequest() api.UpdateTaskRequest {
 		Labels:      f.Labels,
 	}
 
+	// Only send the deadline when it changed, so clearing the field
+	// removes an existing one
+	deadline, _ := f.DeadlineDate()
+	original := ""
+	if f.Original != nil && f.Original.Deadline != nil {
+		original = f.Original.Deadline.Date
+	}
+	if deadline != original {
+		req.DeadlineDate = &deadline
+	}
+
+	// Likewise for the duration; 0 removes it
+	duration, _ := f.DurationValue()
+	var originalDuration *api.Duration
+	if f.Original != nil {
+		originalDuration = f.Original.Duration
+	}
+	switch {
+	case duration == nil && originalDuration != nil:
+		req.Duration = api.IntPtr(0)
+	case duration != nil && (originalDuration == nil || *duration != *originalDuration):
+		req.Duration = api.IntPtr(duration.Amount)
+		req.DurationUnit = &duration.Unit
+	}
+
+	originalAssignee := ""
+	if f.Original != nil && f.Original.ResponsibleUID != nil {
+		originalAssignee = *f.Original.ResponsibleUID
+	}
+	if f.AssigneeID != originalAssignee {
+		assignee := f.AssigneeID
+		req.AssigneeID = &assignee
+	}
+
 	if f.ProjectID != "" {
 		req.ProjectID = &f.ProjectID
 	}
@@ -386,7 +572,7 @@ func (f *TaskForm) ToUpdateRequest() api.UpdateTaskRequest {
 }
 
 // FocusedField returns the index of the focused field.
-// 0: Content, 1: Description, 2: Due, 3: DueTime, 4: Priority, 5: Project, 6: Labels, 7: Submit
+// 0: Content, 1: Description, 2: Due, 3: DueTime, 4: Duration, 5: Deadline, 6: Priority, 7: Project, 8: Labels, 9: Assignee, 10: Submit
 func (f *TaskForm) FocusedField() int {
 	return f.FocusIndex
 }
@@ -398,6 +584,8 @@ func (f *TaskForm) Focus(index int) {
 	f.Description.Blur()
 	f.DueString.Blur()
 	f.DueTime.Blur()
+	f.Duration.Blur()
+	f.Deadline.Blur()
 
 	switch index {
 	case 0:
@@ -408,6 +596,10 @@ func (f *TaskForm) Focus(index int) {
 		f.DueString.Focus()
 	case 3:
 		f.DueTime.Focus()
+	case 4:
+		f.Duration.Focus()
+	case 5:
+		f.Deadline.Focus()
 	}
 }
 
@@ -439,4 +631,6 @@ func (f *TaskForm) SetWidth(width int) {
 	f.Description.SetWidth(inputWidth)
 	f.DueString.Width = 25
 	f.DueTime.Width = 15
+	f.Duration.Width = 20
+	f.Deadline.Width = 20
 }
@@ -0,0 +1,118 @@
+package state
+
+import (
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// maxUndoEntries caps how many changes the undo history remembers.
+const maxUndoEntries = 100
+
+// UndoEntry is a reversible change in the undo history.
+// Both directions are stored as Sync API commands so they can be replayed in
+// a single request.
+type UndoEntry struct {
+	Description string
+	Undo        []api.SyncCommand // Reverts the change
+	Redo        []api.SyncCommand // Applies the change again
+	// Restores maps temp IDs of item_add commands in Undo or Redo to the task
+	// ID they recreate, so other entries can follow the task's new ID.
+	Restores map[string]string
+	At       time.Time
+}
+
+// UndoHistory holds the undo and redo stacks. The zero value is ready to use.
+type UndoHistory struct {
+	undo []UndoEntry
+	redo []UndoEntry
+}
+
+// Record adds a new change to the history and clears the redo stack.
+func (u *UndoHistory) Record(e UndoEntry) {
+	if len(e.Undo) == 0 {
+		return
+	}
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	u.undo = append(u.undo, e)
+	if len(u.undo) > maxUndoEntries {
+		u.undo = u.undo[len(u.undo)-maxUndoEntries:]
+	}
+	u.redo = nil
+}
+
+// TakeUndo removes up to n entries from the undo stack, most recent first.
+func (u *UndoHistory) TakeUndo(n int) []UndoEntry {
+	return take(&u.undo, n)
+}
+
+// TakeRedo removes up to n entries from the redo stack, most recently undone first.
+func (u *UndoHistory) TakeRedo(n int) []UndoEntry {
+	return take(&u.redo, n)
+}
+
+// PushUndo puts entries on the undo stack in order, so the last one ends up on top.
+func (u *UndoHistory) PushUndo(entries ...UndoEntry) {
+	u.undo = append(u.undo, entries...)
+}
+
+// PushRedo puts entries on the redo stack in order, so the last one ends up on top.
+func (u *UndoHistory) PushRedo(entries ...UndoEntry) {
+	u.redo = append(u.redo, entries...)
+}
+
+// Undos returns the undo stack, most recent first.
+func (u *UndoHistory) Undos() []UndoEntry {
+	return reversed(u.undo)
+}
+
+// Redos returns the redo stack, most recently undone first.
+func (u *UndoHistory) Redos() []UndoEntry {
+	return reversed(u.redo)
+}
+
+// Remap replaces task IDs in every stored command, e.g. after undoing a
+// delete recreated a task under a new ID.
+func (u *UndoHistory) Remap(mapping map[string]string) {
+	if len(mapping) == 0 {
+		return
+	}
+	for _, stack := range [][]UndoEntry{u.undo, u.redo} {
+		for i := range stack {
+			e := &stack[i]
+			for j := range e.Undo {
+				e.Undo[j] = api.ReplaceCommandIDs(e.Undo[j], mapping)
+			}
+			for j := range e.Redo {
+				e.Redo[j] = api.ReplaceCommandIDs(e.Redo[j], mapping)
+			}
+			for tempID, taskID := range e.Restores {
+				if newID, ok := mapping[taskID]; ok {
+					e.Restores[tempID] = newID
+				}
+			}
+		}
+	}
+}
+
+// take pops up to n entries off the top of stack.
+func take(stack *[]UndoEntry, n int) []UndoEntry {
+	n = min(n, len(*stack))
+	if n <= 0 {
+		return nil
+	}
+	top := reversed((*stack)[len(*stack)-n:])
+	*stack = (*stack)[:len(*stack)-n]
+	return top
+}
+
+// reversed returns a reversed copy of entries.
+func reversed(entries []UndoEntry) []UndoEntry {
+	out := make([]UndoEntry, len(entries))
+	for i, e := range entries {
+		out[len(entries)-1-i] = e
+	}
+	return out
+}
@@ -109,6 +109,10 @@ func rebuildStyles() {
 	TaskDueToday = lipgloss.NewStyle().Foreground(SuccessColor).PaddingLeft(1)
 	TaskLabel = lipgloss.NewStyle().Foreground(Highlight).PaddingLeft(1)
 	TaskRecurring = lipgloss.NewStyle().Foreground(taskRecurringColor).PaddingLeft(1)
+	TaskDeadline = lipgloss.NewStyle().Foreground(WarningColor).PaddingLeft(1)
+	TaskDuration = lipgloss.NewStyle().Foreground(Subtle).PaddingLeft(1)
+	DayOverbooked = lipgloss.NewStyle().Foreground(ErrorColor).Bold(true).PaddingLeft(1)
+	TaskAssignee = lipgloss.NewStyle().Foreground(Highlight).Bold(true).Width(3)
 
 	// Priority styles
 	TaskPriority1 = lipgloss.NewStyle().Foreground(Priority1Color)
@@ -341,11 +345,33 @@ var (
 			Foreground(Highlight).
 			PaddingLeft(1)
 
-	// TaskRecurring is for the recurring icon
+	// TaskRecurring is for the recurrence rule of recurring tasks
 	TaskRecurring = lipgloss.NewStyle().
 			Foreground(lipgloss.AdaptiveColor{Light: "#00AAAA", Dark: "#00CCCC"}).
 			PaddingLeft(1)
 
+	// TaskDeadline is for deadlines that have not passed
+	TaskDeadline = lipgloss.NewStyle().
+			Foreground(WarningColor).
+			PaddingLeft(1)
+
+	// TaskDuration is for task durations and the total per day
+	TaskDuration = lipgloss.NewStyle().
+			Foreground(Subtle).
+			PaddingLeft(1)
+
+	// DayOverbooked is for days with more scheduled than the workday length
+	DayOverbooked = lipgloss.NewStyle().
+			Foreground(ErrorColor).
+			Bold(true).
+			PaddingLeft(1)
+
+	// TaskAssignee is for the initials of the assignee in task lists
+	TaskAssignee = lipgloss.NewStyle().
+			Foreground(Highlight).
+			Bold(true).
+			Width(3)
+
 	// TaskListDescription is for descriptions in task lists
 	TaskListDescription = lipgloss.NewStyle().
 				Foreground(Subtle).
@@ -696,6 +722,15 @@ var (
 	// CommentContent is for comment text
 	CommentContent = lipgloss.NewStyle().
 			PaddingLeft(2)
+
+	// CommentReaction is for the reactions to a comment
+	CommentReaction = lipgloss.NewStyle().
+			Foreground(Subtle)
+
+	// CommentReactionOwn is for reactions of the current user
+	CommentReactionOwn = lipgloss.NewStyle().
+				Foreground(Highlight).
+				Bold(true)
 )
 
 // Scroll indicator styles
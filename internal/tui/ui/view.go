@@ -39,6 +39,7 @@ func (r *Renderer) View() string {
 		r.DetailComp.SetSize(r.Width, r.Height)
 		r.DetailComp.SetTask(r.SelectedTask)
 		r.DetailComp.SetComments(r.Comments)
+		r.DetailComp.SetCollaborators(r.Collaborators, r.currentUserID())
 		r.DetailComp.SetProjects(r.Projects) // Ensure projects are set
 		r.DetailComp.Focus()
 		content = r.DetailComp.View()
@@ -158,6 +159,7 @@ func (r *Renderer) renderMainView() string {
 			r.DetailComp.SetSize(detailWidth, contentHeight)
 			r.DetailComp.SetTask(r.SelectedTask)
 			r.DetailComp.SetComments(r.Comments)
+			r.DetailComp.SetCollaborators(r.Collaborators, r.currentUserID())
 			if r.CurrentView == state.ViewTaskDetail {
 				r.DetailComp.Focus()
 			} else {
@@ -181,6 +183,7 @@ func (r *Renderer) renderMainView() string {
 			r.DetailComp.SetSize(detailWidth, contentHeight)
 			r.DetailComp.SetTask(r.SelectedTask)
 			r.DetailComp.SetComments(r.Comments)
+			r.DetailComp.SetCollaborators(r.Collaborators, r.currentUserID())
 			if r.CurrentView == state.ViewTaskDetail {
 				r.DetailComp.Focus()
 			} else {
@@ -214,6 +217,15 @@ func (r *Renderer) renderMainView() string {
 	return lipgloss.JoinVertical(lipgloss.Left, tabBar, mainContent, bottomBar)
 }
 
+// currentUserID returns the ID of the signed-in user, or "" until the
+// account is loaded.
+func (r *Renderer) currentUserID() string {
+	if r.User == nil {
+		return ""
+	}
+	return r.User.ID
+}
+
 // renderCommandLine renders the vim-style command line.
 func (r *Renderer) renderCommandLine() string {
 	if r.CommandLine == nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/hy4ri/todoist-tui/internal/api"
 	"github.com/hy4ri/todoist-tui/internal/tui/styles"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
 )
 
 // renderCalendar renders the calendar view (dispatches based on view mode).
@@ -83,8 +84,10 @@ func (r *Renderer) renderCalendarCompact(maxHeight int) string {
 				today.Month() == r.CalendarDate.Month() &&
 				today.Day() == day
 
-			// Check if this day has tasks
+			// Check if this day has tasks or deadlines
 			hasTasks := tasksByDay[day] > 0
+			dateKey := fmt.Sprintf("%04d-%02d-%02d", r.CalendarDate.Year(), r.CalendarDate.Month(), day)
+			hasDeadlines := len(r.DeadlinesByDate[dateKey]) > 0
 
 			// Check if this is the selected day
 			isSelected := day == r.CalendarDay && r.FocusedPane == state.PaneMain
@@ -93,12 +96,14 @@ func (r *Renderer) renderCalendarCompact(maxHeight int) string {
 				style = styles.CalendarDaySelected
 			} else if isToday {
 				style = styles.CalendarDayToday
-			} else if hasTasks {
+			} else if hasTasks || hasDeadlines {
 				style = styles.CalendarDayWithTasks
 			}
 
-			// Add task indicator
-			if hasTasks && !isSelected {
+			// Add task indicator; deadlines take precedence
+			if hasDeadlines && !isSelected {
+				dayStr = fmt.Sprintf(" %2d!", day)
+			} else if hasTasks && !isSelected {
 				dayStr = fmt.Sprintf(" %2d*", day)
 			}
 
@@ -112,16 +117,29 @@ func (r *Renderer) renderCalendarCompact(maxHeight int) string {
 	// Show tasks for selected day
 	b.WriteString("\n")
 	selectedDate := time.Date(r.CalendarDate.Year(), r.CalendarDate.Month(), r.CalendarDay, 0, 0, 0, 0, time.Local)
-	b.WriteString(styles.Subtitle.Render(selectedDate.Format("Monday, January 2")))
-	b.WriteString("\n\n")
-
 	// Find tasks for selected day using cache
 	dateStr := selectedDate.Format("2006-01-02")
 	dayTasks := r.TasksByDate[dateStr]
 
+	b.WriteString(styles.Subtitle.Render(selectedDate.Format("Monday, January 2")))
+	b.WriteString(r.renderDayTotal(dayTasks))
+	b.WriteString("\n\n")
+
 	// Calculate remaining height for task list
 	// Used: title(1) + help(1) + blank(1) + weekdays(1) + calendar(weeksRendered) + blank(1) + subtitle(1) + blank(1)
 	usedHeight := 7 + weeksRendered
+
+	// Deadlines falling on the selected day
+	if deadlines := r.DeadlinesByDate[dateStr]; len(deadlines) > 0 {
+		names := make([]string, len(deadlines))
+		for i, t := range deadlines {
+			names[i] = t.Content
+		}
+		line := "⚑ Deadline: " + strings.Join(names, ", ")
+		b.WriteString(styles.TaskDeadline.Render(truncateString(line, r.Width-4)))
+		b.WriteString("\n\n")
+		usedHeight += 2
+	}
 	taskListHeight := maxHeight - usedHeight
 	if taskListHeight < 1 {
 		taskListHeight = 1
@@ -266,17 +284,32 @@ func (r *Renderer) renderCalendarExpanded(maxHeight int) string {
 				today.Day() == tempDay
 			isSelected := tempDay == r.CalendarDay && r.FocusedPane == state.PaneMain
 			hasTasks := len(tasksByDay[tempDay]) > 0
+			dateKey := fmt.Sprintf("%04d-%02d-%02d", r.CalendarDate.Year(), r.CalendarDate.Month(), tempDay)
+			if len(r.DeadlinesByDate[dateKey]) > 0 {
+				dayStr += " ⚑"
+			}
+			overbooked := false
+			if minutes := utils.ScheduledMinutes(tasksByDay[tempDay]); minutes > 0 {
+				dayStr += " " + utils.FormatMinutes(minutes)
+				if time.Duration(minutes)*time.Minute > r.workday() {
+					dayStr += "⚠"
+					overbooked = true
+				}
+			}
+			dayStr = truncateString(dayStr, cellWidth)
 
 			if isSelected {
 				style = styles.CalendarDaySelected
 			} else if isToday {
 				style = styles.CalendarDayToday
+			} else if overbooked {
+				style = styles.DayOverbooked.UnsetPaddingLeft()
 			} else if hasTasks {
 				style = styles.CalendarDayWithTasks
 			}
 
 			// Pad to cell width
-			paddedDay := fmt.Sprintf("%-*s", cellWidth, dayStr)
+			paddedDay := dayStr + strings.Repeat(" ", max(0, cellWidth-lipgloss.Width(dayStr)))
 			dayNumBuilder.WriteString(style.Render(paddedDay) + "│")
 			tempDay++
 		}
@@ -445,7 +478,7 @@ func (r *Renderer) renderStatusBar() string {
 	// Left side: status message or error, followed by goals
 	left := ""
 	if r.Err != nil {
-		errStr := strings.ReplaceAll(r.Err.Error(), "\n", " ")
+		errStr := strings.ReplaceAll(utils.ErrorMessage(r.Err), "\n", " ")
 		left = styles.StatusBarError.Render("Error: " + errStr)
 	} else if r.StatusMsg != "" {
 		msgStr := strings.ReplaceAll(r.StatusMsg, "\n", " ")
@@ -453,6 +486,13 @@ func (r *Renderer) renderStatusBar() string {
 	}
 	var rightParts []string
 
+	// Show changes queued while offline
+	if r.Outbox != nil {
+		if pending := r.Outbox.Len(); pending > 0 {
+			rightParts = append(rightParts, styles.StatusBarKey.Render(fmt.Sprintf("⇅ %d pending", pending)))
+		}
+	}
+
 	// Add goals to right side
 	goalsDisplay := r.renderGoalsDisplay()
 	if goalsDisplay != "" {
@@ -5,8 +5,10 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/hy4ri/todoist-tui/internal/api"
 	"github.com/hy4ri/todoist-tui/internal/tui/state"
 	"github.com/hy4ri/todoist-tui/internal/tui/styles"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
 )
 
 // renderTaskForm renders the add/edit task form.
@@ -79,8 +81,33 @@ func (r *Renderer) renderTaskForm() string {
 	}
 	timeBlock := timeStyle.Render("🕒 " + f.DueTime.View())
 
+	// Deadline
+	deadlineStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Subtle).
+		Padding(0, 1)
+
+	if f.FocusIndex == state.FormFieldDeadline {
+		deadlineStyle = deadlineStyle.BorderForeground(styles.Highlight)
+	}
+	deadlineBlock := deadlineStyle.Render("⚑ " + f.Deadline.View())
+
 	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, dueBlock, "  ", timeBlock) + "\n\n")
 
+	// Duration
+	b.WriteString(styles.InputLabel.Foreground(styles.Highlight).Underline(true).Render("DURATION / DEADLINE") + "\n")
+	durationStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Subtle).
+		Padding(0, 1)
+
+	if f.FocusIndex == state.FormFieldDuration {
+		durationStyle = durationStyle.BorderForeground(styles.Highlight)
+	}
+	durationBlock := durationStyle.Render("⏱ " + f.Duration.View())
+
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, durationBlock, "  ", deadlineBlock) + "\n\n")
+
 	// Metadata Bar (Priority, Project, Labels)
 
 	// Priority
@@ -120,8 +147,18 @@ func (r *Renderer) renderTaskForm() string {
 	}
 	labelItem := renderMetaItem(state.FormFieldLabels, "🏷️", labelStr, "Labels")
 
+	// Assignee, only for shared projects
+	items := []string{priorityItem, projectItem, labelItem}
+	if f.CanAssign() {
+		assigneeStr := f.AssigneeName()
+		if assigneeStr == "" {
+			assigneeStr = "Unassigned"
+		}
+		items = append(items, renderMetaItem(state.FormFieldAssignee, "👤", assigneeStr, "Assignee"))
+	}
+
 	// Construct the bar
-	bar := lipgloss.JoinHorizontal(lipgloss.Top, priorityItem, projectItem, labelItem)
+	bar := lipgloss.JoinHorizontal(lipgloss.Top, items...)
 	b.WriteString(bar + "\n\n")
 
 	// Project Selector Dropdown
@@ -254,6 +291,61 @@ func (r *Renderer) renderTaskForm() string {
 		b.WriteString(list + "\n\n")
 	}
 
+	// Assignee Selector Dropdown
+	if f.ShowAssigneeList {
+		b.WriteString(styles.InputLabel.Foreground(styles.Highlight).Underline(true).Render("ASSIGN TO") + "\n")
+		var lines []string
+
+		// The first option unassigns the task
+		options := append([]api.Collaborator{{Name: "Unassigned"}}, f.AssigneeOptions()...)
+
+		// Scrolling logic
+		windowHeight := 5
+		startIdx := 0
+		if f.AssigneeListCursor >= windowHeight {
+			startIdx = f.AssigneeListCursor - windowHeight + 1
+		}
+		endIdx := startIdx + windowHeight
+		if endIdx > len(options) {
+			endIdx = len(options)
+		}
+
+		if startIdx > 0 {
+			lines = append(lines, styles.HelpDesc.Render(fmt.Sprintf("▲ %d more", startIdx)))
+		}
+
+		for i := startIdx; i < endIdx; i++ {
+			c := options[i]
+			cursor := "  "
+			style := styles.LabelItem
+			if i == f.AssigneeListCursor {
+				cursor = "> "
+				style = style.Bold(true).Foreground(styles.Highlight)
+			}
+
+			checkMark := "  "
+			if c.ID == f.AssigneeID {
+				checkMark = "✓ "
+			}
+
+			lines = append(lines, style.Render(cursor+checkMark+c.Name))
+		}
+
+		if endIdx < len(options) {
+			lines = append(lines, styles.HelpDesc.Render(fmt.Sprintf("▼ %d more", len(options)-endIdx)))
+		}
+		if len(options) == 1 {
+			lines = append(lines, styles.HelpDesc.Render("No collaborators loaded"))
+		}
+
+		list := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(styles.Highlight).
+			Padding(0, 1).
+			Render(strings.Join(lines, "\n"))
+		b.WriteString(list + "\n\n")
+	}
+
 	// Submit Button
 	submitStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -596,12 +688,46 @@ func (r *Renderer) renderMoveTaskDialog() string {
 // renderCommentDialog renders the add comment dialog.
 func (r *Renderer) renderCommentDialog() string {
 	content := styles.Title.Render("💬 Add Comment") + "\n\n" +
-		r.CommentInput.View() + "\n\n" +
-		styles.HelpDesc.Render("Ctrl+Enter: submit • Esc: cancel")
+		r.CommentInput.View() + "\n\n"
+
+	help := "Ctrl+Enter: submit • Esc: cancel"
+	if suggestions := r.mentionSuggestions(); len(suggestions) > 0 {
+		// Tab completes the first suggestion
+		for i, c := range suggestions {
+			cursor := "  "
+			style := styles.LabelItem
+			if i == 0 {
+				cursor = "> "
+				style = style.Bold(true).Foreground(styles.Highlight)
+			}
+			content += style.Render(cursor+"@"+c.Name) + "\n"
+		}
+		content += "\n"
+		help = "Tab: mention • " + help
+	}
+	content += styles.HelpDesc.Render(help)
 
 	return r.renderCenteredDialog(content, 60)
 }
 
+// mentionSuggestionLimit is the number of collaborators suggested for an
+// @mention.
+const mentionSuggestionLimit = 5
+
+// mentionSuggestions returns the collaborators matching the @mention being
+// typed in the comment input.
+func (r *Renderer) mentionSuggestions() []api.Collaborator {
+	if r.SelectedTask == nil {
+		return nil
+	}
+	query, ok := utils.MentionQuery(r.CommentInput.Value())
+	if !ok {
+		return nil
+	}
+	suggestions := utils.MentionSuggestions(r.Collaborators[r.SelectedTask.ProjectID], query)
+	return suggestions[:min(len(suggestions), mentionSuggestionLimit)]
+}
+
 // renderColorSelectionList renders the list of colors.
 func (r *Renderer) renderColorSelectionList(height int) string {
 	var b strings.Builder
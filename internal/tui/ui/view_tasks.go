@@ -10,7 +10,9 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/hy4ri/todoist-tui/internal/api"
+	"github.com/hy4ri/todoist-tui/internal/config"
 	"github.com/hy4ri/todoist-tui/internal/tui/styles"
+	"github.com/hy4ri/todoist-tui/internal/tui/utils"
 )
 
 // renderTaskList renders the task list for Today/Upcoming/Labels views.
@@ -265,6 +267,14 @@ func (r *Renderer) renderGroupedTasks(width, maxHeight int) string {
 		if len(overdue) > 0 {
 			lines = append(lines, lineInfo{content: "", taskIndex: -1})
 		}
+		// Only days with timed work get a header, showing the total
+		todayTasks := make([]api.Task, len(today))
+		for k, i := range today {
+			todayTasks[k] = r.Tasks[i]
+		}
+		if total := r.renderDayTotal(todayTasks); total != "" {
+			lines = append(lines, lineInfo{content: styles.SectionHeader.Render("TODAY") + total, taskIndex: -1})
+		}
 		for _, i := range today {
 			i, pos := i, currentDisplayPos
 			currentDisplayPos++
@@ -405,12 +415,38 @@ func (r *Renderer) renderTaskByDisplayIndex(taskIndex int, displayPos int, width
 	}
 	indent := strings.Repeat("  ", depth)
 
+	// Assignee initials, in a column shown once any listed task is assigned
+	assignee := ""
+	assigneeWidth := 0
+	if r.hasAssignedTasks() {
+		assignee = styles.TaskAssignee.Render(r.assigneeInitials(t))
+		assigneeWidth = lipgloss.Width(assignee)
+	}
+
 	// Calculate metadata widths
 	dueStr := ""
 	dueWidth := 0
+	recurrenceStr := ""
 	if t.Due != nil {
-		dueStr = "| " + t.DueDisplay()
-		dueWidth = lipgloss.Width(dueStr) + 1
+		// The recurrence rule is styled apart from the date
+		dueStr = "| " + t.DueDateDisplay()
+		if t.Due.IsRecurring && t.Due.String != "" {
+			recurrenceStr = "↻ " + t.Due.String
+		}
+		dueWidth = lipgloss.Width(dueStr) + lipgloss.Width(recurrenceStr) + 2
+	}
+
+	durationStr := utils.DurationBadge(t.Duration)
+	durationWidth := 0
+	if durationStr != "" {
+		durationWidth = lipgloss.Width(durationStr) + 1
+	}
+
+	deadlineStr := ""
+	deadlineWidth := 0
+	if t.Deadline != nil {
+		deadlineStr = "⚑ " + t.DeadlineDisplay()
+		deadlineWidth = lipgloss.Width(deadlineStr) + 1
 	}
 
 	labelStr := ""
@@ -432,11 +468,11 @@ func (r *Renderer) renderTaskByDisplayIndex(taskIndex int, displayPos int, width
 		labelWidth = lipgloss.Width(labelStr) + 1
 	}
 
-	// Calculate fixed overhead (cursor + selection + indent + checkbox + spaces + recurring icon)
+	// Calculate fixed overhead (cursor + selection + indent + checkbox + spaces)
 	// "> ●  [ ] " = 2 + 1 + indentLen + 4 = 7 + indentLen
-	// recurring adds 1 char if present, plus potential spacing artifacts.
+	// plus potential spacing artifacts.
 	// We bump safety margin from 2 to 6 to be absolutely safe against wrapping.
-	overhead := 7 + len(indent) + dueWidth + labelWidth + 6
+	overhead := 7 + len(indent) + assigneeWidth + dueWidth + durationWidth + deadlineWidth + labelWidth + 6
 
 	// Truncate content if needed
 	content := t.Content
@@ -462,18 +498,30 @@ func (r *Renderer) renderTaskByDisplayIndex(taskIndex int, displayPos int, width
 		}
 	}
 
+	if recurrenceStr != "" {
+		styledDue += styles.TaskRecurring.Render(recurrenceStr)
+	}
+
+	if durationStr != "" {
+		styledDue += styles.TaskDuration.Render(durationStr)
+	}
+
+	// A passed deadline is shown like an overdue date
+	if deadlineStr != "" {
+		if days, _ := t.DaysUntilDeadline(time.Now()); days < 0 {
+			styledDue += styles.TaskDueOverdue.Render(deadlineStr)
+		} else {
+			styledDue += styles.TaskDeadline.Render(deadlineStr)
+		}
+	}
+
 	styledLabels := ""
 	if labelStr != "" {
 		styledLabels = styles.TaskLabel.Render(labelStr)
 	}
 
-	styledRecurring := ""
-	if t.Due != nil && t.Due.IsRecurring {
-		styledRecurring = styles.TaskRecurring.Render("↻")
-	}
-
 	// Build line with selection mark
-	line := fmt.Sprintf("%s%s%s%s %s%s %s %s", cursor, selectionMark, indent, checkbox, styledContent, styledRecurring, styledDue, styledLabels)
+	line := fmt.Sprintf("%s%s%s%s%s %s %s %s", cursor, selectionMark, assignee, indent, checkbox, styledContent, styledDue, styledLabels)
 
 	// Apply base style
 	style := styles.TaskItem
@@ -488,6 +536,32 @@ func (r *Renderer) renderTaskByDisplayIndex(taskIndex int, displayPos int, width
 	return style.MaxWidth(width - 2).Render(line)
 }
 
+// hasAssignedTasks reports whether any listed task has an assignee.
+func (r *Renderer) hasAssignedTasks() bool {
+	for _, t := range r.Tasks {
+		if t.ResponsibleUID != nil && *t.ResponsibleUID != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// assigneeInitials returns the initials of the user a task is assigned to,
+// "me" for the current user, or "" if it is unassigned.
+func (r *Renderer) assigneeInitials(t api.Task) string {
+	if t.ResponsibleUID == nil || *t.ResponsibleUID == "" {
+		return ""
+	}
+	uid := *t.ResponsibleUID
+	if uid == r.currentUserID() {
+		return "me"
+	}
+	if c, ok := utils.FindCollaborator(r.Collaborators, t.ProjectID, uid); ok {
+		return utils.Initials(c.Name)
+	}
+	return "?"
+}
+
 // taskDepth calculates the nesting depth of a task.
 func (r *Renderer) taskDepth(task *api.Task, allTasks []api.Task) int {
 	if task.ParentID == nil {
@@ -765,8 +839,12 @@ func (r *Renderer) renderUpcoming(width, maxHeight int) string {
 			lines = append(lines, lineInfo{content: "", taskIndex: -1})
 		}
 
+		dayTasks := make([]api.Task, len(tasksByDate[date]))
+		for k, i := range tasksByDate[date] {
+			dayTasks[k] = r.Tasks[i]
+		}
 		lines = append(lines, lineInfo{
-			content:   styles.DateGroupHeader.Render(displayDate),
+			content:   styles.DateGroupHeader.Render(displayDate) + r.renderDayTotal(dayTasks),
 			taskIndex: -1,
 		})
 
@@ -812,6 +890,29 @@ func (r *Renderer) renderUpcoming(width, maxHeight int) string {
 	return b.String()
 }
 
+// renderDayTotal renders the time scheduled by the tasks of a day, flagged
+// when it exceeds the workday length. It is empty when no task has a
+// duration.
+func (r *Renderer) renderDayTotal(tasks []api.Task) string {
+	minutes := utils.ScheduledMinutes(tasks)
+	if minutes == 0 {
+		return ""
+	}
+	total := "⏱ " + utils.FormatMinutes(minutes)
+	if workday := r.workday(); time.Duration(minutes)*time.Minute > workday {
+		return styles.DayOverbooked.Render(fmt.Sprintf("%s ⚠ over %s workday", total, utils.FormatMinutes(int(workday/time.Minute))))
+	}
+	return styles.TaskDuration.Render(total)
+}
+
+// workday returns the configured workday length.
+func (r *Renderer) workday() time.Duration {
+	if r.Config == nil {
+		return config.DefaultWorkdayLength
+	}
+	return r.Config.UI.Workday()
+}
+
 // renderLabelsView renders the labels view.
 func (r *Renderer) renderLabelsView(width, maxHeight int) string {
 	var b strings.Builder
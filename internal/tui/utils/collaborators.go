@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// Initials returns up to two uppercase initials of a name, e.g. "AL" for
+// "Ada Lovelace", or "?" for an empty name.
+func Initials(name string) string {
+	var initials []rune
+	for _, word := range strings.Fields(name) {
+		r := []rune(word)[0]
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		initials = append(initials, unicode.ToUpper(r))
+		if len(initials) == 2 {
+			break
+		}
+	}
+	if len(initials) == 0 {
+		return "?"
+	}
+	return string(initials)
+}
+
+// FindCollaborator looks up a user by ID among the collaborators of
+// projectID, then among those of all other projects.
+func FindCollaborator(byProject map[string][]api.Collaborator, projectID, uid string) (api.Collaborator, bool) {
+	for _, c := range byProject[projectID] {
+		if c.ID == uid {
+			return c, true
+		}
+	}
+	for _, collaborators := range byProject {
+		for _, c := range collaborators {
+			if c.ID == uid {
+				return c, true
+			}
+		}
+	}
+	return api.Collaborator{}, false
+}
+
+// MatchCollaborator finds the collaborator whose name or email matches
+// query: an exact match, else the only one whose name or email contains it.
+func MatchCollaborator(collaborators []api.Collaborator, query string) (api.Collaborator, bool) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return api.Collaborator{}, false
+	}
+
+	var partial []api.Collaborator
+	for _, c := range collaborators {
+		name, email := strings.ToLower(c.Name), strings.ToLower(c.Email)
+		if name == query || email == query {
+			return c, true
+		}
+		if strings.Contains(name, query) || strings.Contains(email, query) {
+			partial = append(partial, c)
+		}
+	}
+	if len(partial) == 1 {
+		return partial[0], true
+	}
+	return api.Collaborator{}, false
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+func TestInitials(t *testing.T) {
+	tests := map[string]string{
+		"Ada Lovelace":          "AL",
+		"grace":                 "G",
+		"Jean Baptiste Lamarck": "JB",
+		"":                      "?",
+		"  ":                    "?",
+	}
+	for name, want := range tests {
+		if got := Initials(name); got != want {
+			t.Errorf("Initials(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestMatchCollaborator(t *testing.T) {
+	collaborators := []api.Collaborator{
+		{ID: "1", Name: "Ada Lovelace", Email: "ada@example.com"},
+		{ID: "2", Name: "Alan Turing", Email: "alan@example.com"},
+		{ID: "3", Name: "Al", Email: "al@example.com"},
+	}
+
+	tests := []struct {
+		query  string
+		wantID string
+	}{
+		{"ada", "1"},
+		{"ALAN@example.com", "2"},
+		{"al", "3"},     // Exact name wins over partial matches
+		{"example", ""}, // Ambiguous
+		{"grace", ""},
+	}
+	for _, tt := range tests {
+		c, ok := MatchCollaborator(collaborators, tt.query)
+		if tt.wantID == "" {
+			if ok {
+				t.Errorf("MatchCollaborator(%q) = %+v, want no match", tt.query, c)
+			}
+			continue
+		}
+		if !ok || c.ID != tt.wantID {
+			t.Errorf("MatchCollaborator(%q) = %+v, %v, want ID %s", tt.query, c, ok, tt.wantID)
+		}
+	}
+}
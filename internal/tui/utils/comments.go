@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// MentionQuery returns the partial @mention being typed at the end of text,
+// e.g. "ale" for "thanks @ale". It reports false if text doesn't end in one.
+func MentionQuery(text string) (string, bool) {
+	at := strings.LastIndex(text, "@")
+	if at < 0 {
+		return "", false
+	}
+	// An @ inside a word, as in an email address, isn't a mention
+	if at > 0 && !unicode.IsSpace(rune(text[at-1])) {
+		return "", false
+	}
+	query := text[at+1:]
+	if strings.ContainsAny(query, "\n@") {
+		return "", false
+	}
+	return query, true
+}
+
+// MentionSuggestions returns the collaborators whose name, a word of it, or
+// email starts with query.
+func MentionSuggestions(collaborators []api.Collaborator, query string) []api.Collaborator {
+	query = strings.ToLower(query)
+	var matches []api.Collaborator
+	for _, c := range collaborators {
+		name := strings.ToLower(c.Name)
+		if strings.HasPrefix(name, query) || strings.HasPrefix(strings.ToLower(c.Email), query) {
+			matches = append(matches, c)
+			continue
+		}
+		for _, word := range strings.Fields(name) {
+			if strings.HasPrefix(word, query) {
+				matches = append(matches, c)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// CompleteMention replaces the partial @mention at the end of text with the
+// full name of c.
+func CompleteMention(text string, c api.Collaborator) string {
+	at := strings.LastIndex(text, "@")
+	if at < 0 {
+		return text
+	}
+	return text[:at] + "@" + c.Name + " "
+}
+
+// MentionedUIDs returns the IDs of the collaborators @mentioned by name in
+// text, in the order they are mentioned. A mention must end at a word
+// boundary, so "@Anna" doesn't mention "Ann", and the longest name wins
+// when several match.
+func MentionedUIDs(collaborators []api.Collaborator, text string) []string {
+	text = strings.ToLower(text)
+	var uids []string
+	for rest := text; ; {
+		at := strings.Index(rest, "@")
+		if at < 0 {
+			break
+		}
+		// As in MentionQuery, an @ inside a word isn't a mention
+		inWord := at > 0 && !unicode.IsSpace(rune(rest[at-1]))
+		rest = rest[at+1:]
+		if inWord {
+			continue
+		}
+
+		var mentioned api.Collaborator
+		longest := 0
+		for _, c := range collaborators {
+			name := strings.ToLower(c.Name)
+			if name == "" || len(name) <= longest || !strings.HasPrefix(rest, name) {
+				continue
+			}
+			if next, _ := utf8.DecodeRuneInString(rest[len(name):]); unicode.IsLetter(next) || unicode.IsDigit(next) {
+				continue
+			}
+			mentioned, longest = c, len(name)
+		}
+		if mentioned.ID != "" && !slices.Contains(uids, mentioned.ID) {
+			uids = append(uids, mentioned.ID)
+		}
+	}
+	return uids
+}
+
+// ToggleReaction returns a copy of reactions with the reaction of uid with
+// emoji added, or removed if it was already there.
+func ToggleReaction(reactions map[string][]string, emoji, uid string) map[string][]string {
+	toggled := make(map[string][]string, len(reactions)+1)
+	for e, uids := range reactions {
+		toggled[e] = slices.Clone(uids)
+	}
+	if i := slices.Index(toggled[emoji], uid); i >= 0 {
+		toggled[emoji] = slices.Delete(toggled[emoji], i, i+1)
+		if len(toggled[emoji]) == 0 {
+			delete(toggled, emoji)
+		}
+	} else {
+		toggled[emoji] = append(toggled[emoji], uid)
+	}
+	return toggled
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+func TestMentions(t *testing.T) {
+	collaborators := []api.Collaborator{
+		{ID: "1", Name: "Ada Lovelace", Email: "ada@example.com"},
+		{ID: "2", Name: "Alan Turing", Email: "alan@example.com"},
+	}
+
+	tests := []struct {
+		text    string
+		query   string
+		ok      bool
+		matches int
+	}{
+		{"thanks @a", "a", true, 2},
+		{"@turing", "turing", true, 1}, // Any word of the name
+		{"cc @Ada L", "Ada L", true, 1},
+		{"mail ada@example.com", "", false, 0},
+		{"@ada\nsee above", "", false, 0},
+		{"no mention", "", false, 0},
+	}
+	for _, tt := range tests {
+		query, ok := MentionQuery(tt.text)
+		if query != tt.query || ok != tt.ok {
+			t.Errorf("MentionQuery(%q) = %q, %v, want %q, %v", tt.text, query, ok, tt.query, tt.ok)
+			continue
+		}
+		if ok {
+			if got := MentionSuggestions(collaborators, query); len(got) != tt.matches {
+				t.Errorf("MentionSuggestions(%q) = %+v, want %d", query, got, tt.matches)
+			}
+		}
+	}
+
+	text := CompleteMention("thanks @tur", collaborators[1])
+	if text != "thanks @Alan Turing " {
+		t.Fatalf("CompleteMention = %q", text)
+	}
+	if got := MentionedUIDs(collaborators, text+"and @ada lovelace"); !reflect.DeepEqual(got, []string{"2", "1"}) {
+		t.Errorf("MentionedUIDs = %v, want [2 1]", got)
+	}
+}
+
+func TestMentionedUIDs_WordBoundary(t *testing.T) {
+	collaborators := []api.Collaborator{
+		{ID: "1", Name: "Ann"},
+		{ID: "2", Name: "Anna"},
+		{ID: "3", Name: "Anna Lee"},
+	}
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"@Anna can you check?", []string{"2"}},       // Not Ann
+		{"@anna lee, then @ann.", []string{"3", "1"}}, // Longest name first, punctuation ends a name
+		{"@Annabel", nil},
+		{"@Ann @ann", []string{"1"}},
+		{"mail me@anna.dev", nil},
+	}
+	for _, tt := range tests {
+		if got := MentionedUIDs(collaborators, tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MentionedUIDs(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestToggleReaction(t *testing.T) {
+	reactions := map[string][]string{"👍": {"2"}}
+
+	added := ToggleReaction(reactions, "👍", "1")
+	if !reflect.DeepEqual(added, map[string][]string{"👍": {"2", "1"}}) {
+		t.Errorf("add: got %v", added)
+	}
+	if len(reactions["👍"]) != 1 {
+		t.Error("the original reactions were modified")
+	}
+
+	removed := ToggleReaction(added, "👍", "2")
+	removed = ToggleReaction(removed, "👍", "1")
+	if len(removed) != 0 {
+		t.Errorf("expected the emptied emoji to be dropped, got %v", removed)
+	}
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is the layout of date-only values sent to the API.
+const DateLayout = "2006-01-02"
+
+// ParseDate resolves a date typed by the user relative to today: "today",
+// "tomorrow", weekday names ("fri", "next friday"), "next week", "in 3
+// days", "in 2 weeks", "jan 15", "15 jan 2027" and YYYY-MM-DD. Month-day
+// dates without a year that have already passed refer to next year. The
+// result is midnight in the location of today.
+func ParseDate(text string, today time.Time) (time.Time, bool) {
+	text = strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	loc := today.Location()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, loc)
+
+	switch text {
+	case "":
+		return time.Time{}, false
+	case "today", "tod":
+		return today, true
+	case "tomorrow", "tom":
+		return today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "next week":
+		return NextWeekday(today, time.Monday), true
+	}
+
+	if rest, ok := strings.CutPrefix(text, "in "); ok {
+		count, unit, _ := strings.Cut(rest, " ")
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return time.Time{}, false
+		}
+		switch strings.TrimSuffix(unit, "s") {
+		case "day":
+			return today.AddDate(0, 0, n), true
+		case "week":
+			return today.AddDate(0, 0, 7*n), true
+		case "month":
+			return today.AddDate(0, n, 0), true
+		}
+		return time.Time{}, false
+	}
+
+	if rest, ok := strings.CutPrefix(text, "next "); ok {
+		if wd, ok := ParseWeekday(rest); ok {
+			return NextWeekday(today, wd), true
+		}
+		return time.Time{}, false
+	}
+	if wd, ok := ParseWeekday(strings.TrimPrefix(text, "this ")); ok {
+		// The coming one, which may be today
+		return NextWeekday(today.AddDate(0, 0, -1), wd), true
+	}
+
+	if day, err := time.ParseInLocation(DateLayout, text, loc); err == nil {
+		return day, true
+	}
+	for _, layout := range []string{"Jan 2 2006", "January 2 2006", "2 Jan 2006", "2 January 2006"} {
+		if day, err := time.ParseInLocation(layout, text, loc); err == nil {
+			return day, true
+		}
+	}
+	for _, layout := range []string{"Jan 2", "January 2", "2 Jan", "2 January"} {
+		if day, err := time.ParseInLocation(layout, text, loc); err == nil {
+			day = day.AddDate(today.Year()-day.Year(), 0, 0)
+			if day.Before(today) {
+				day = day.AddDate(1, 0, 0)
+			}
+			return day, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ParseWeekday reads a weekday name or its three-letter abbreviation.
+func ParseWeekday(text string) (time.Weekday, bool) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if text == name || text == name[:3] {
+			return wd, true
+		}
+	}
+	return 0, false
+}
+
+// nextWeekday returns the first day after from that falls on wd.
+func NextWeekday(from time.Time, wd time.Weekday) time.Time {
+	days := (int(wd)-int(from.Weekday())+6)%7 + 1
+	return from.AddDate(0, 0, days)
+}
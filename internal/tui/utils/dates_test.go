@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	// A Tuesday
+	today := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"today", "2026-03-10"},
+		{"Tomorrow", "2026-03-11"},
+		{"fri", "2026-03-13"},
+		{"tuesday", "2026-03-10"},
+		{"next tuesday", "2026-03-17"},
+		{"next week", "2026-03-16"},
+		{"in 3 days", "2026-03-13"},
+		{"in 2 weeks", "2026-03-24"},
+		{"2026-04-01", "2026-04-01"},
+		{"Apr 1", "2026-04-01"},
+		{"1 march", "2027-03-01"},
+		{"jan 5 2028", "2028-01-05"},
+		{"someday", ""},
+		{"in two days", ""},
+	}
+	for _, tt := range tests {
+		got, ok := ParseDate(tt.text, today)
+		if tt.want == "" {
+			if ok {
+				t.Errorf("ParseDate(%q) = %v, want no date", tt.text, got)
+			}
+			continue
+		}
+		if !ok || got.Format(DateLayout) != tt.want {
+			t.Errorf("ParseDate(%q) = %v, %v, want %s", tt.text, got, ok, tt.want)
+		}
+	}
+}
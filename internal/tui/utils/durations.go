@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+// ParseDuration reads a task duration typed by the user: minutes ("45",
+// "45m"), hours ("2h", "1h30m", "1.5h") or whole days ("2d").
+func ParseDuration(text string) (api.Duration, bool) {
+	text = strings.ToLower(strings.Join(strings.Fields(text), ""))
+	if text == "" {
+		return api.Duration{}, false
+	}
+
+	if days, ok := strings.CutSuffix(text, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return api.Duration{}, false
+		}
+		return api.Duration{Amount: n, Unit: "day"}, true
+	}
+
+	if n, err := strconv.Atoi(text); err == nil {
+		text = strconv.Itoa(n) + "m"
+	}
+	d, err := time.ParseDuration(text)
+	if err != nil || d < time.Minute || d%time.Minute != 0 {
+		return api.Duration{}, false
+	}
+	return api.Duration{Amount: int(d / time.Minute), Unit: "minute"}, true
+}
+
+// FormatMinutes formats a number of minutes compactly: "45m", "2h", "1h30m".
+func FormatMinutes(minutes int) string {
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh%dm", h, m)
+}
+
+// DurationBadge returns the short form of a task duration shown in task rows,
+// or "" if there is none.
+func DurationBadge(d *api.Duration) string {
+	if d == nil || d.Amount <= 0 {
+		return ""
+	}
+	if d.Unit == "day" {
+		return fmt.Sprintf("%dd", d.Amount)
+	}
+	return FormatMinutes(d.Amount)
+}
+
+// ScheduledMinutes sums the durations in minutes of the open tasks. Tasks
+// lasting whole days are left out, as they don't take up working time.
+func ScheduledMinutes(tasks []api.Task) int {
+	total := 0
+	for _, t := range tasks {
+		if t.Checked || t.Duration == nil || t.Duration.Unit != "minute" {
+			continue
+		}
+		total += t.Duration.Amount
+	}
+	return total
+}
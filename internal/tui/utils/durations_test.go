@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/hy4ri/todoist-tui/internal/api"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		text string
+		want api.Duration
+		ok   bool
+	}{
+		{"45", api.Duration{Amount: 45, Unit: "minute"}, true},
+		{"45m", api.Duration{Amount: 45, Unit: "minute"}, true},
+		{"2h", api.Duration{Amount: 120, Unit: "minute"}, true},
+		{"1h 30m", api.Duration{Amount: 90, Unit: "minute"}, true},
+		{"1.5h", api.Duration{Amount: 90, Unit: "minute"}, true},
+		{"2d", api.Duration{Amount: 2, Unit: "day"}, true},
+		{"0", api.Duration{}, false},
+		{"30s", api.Duration{}, false},
+		{"soon", api.Duration{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseDuration(tt.text)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseDuration(%q) = %+v, %v, want %+v, %v", tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFormatMinutes(t *testing.T) {
+	for minutes, want := range map[int]string{45: "45m", 120: "2h", 90: "1h30m", 0: "0m"} {
+		if got := FormatMinutes(minutes); got != want {
+			t.Errorf("FormatMinutes(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+}
+
+func TestScheduledMinutes(t *testing.T) {
+	tasks := []api.Task{
+		{Duration: &api.Duration{Amount: 45, Unit: "minute"}},
+		{Duration: &api.Duration{Amount: 90, Unit: "minute"}},
+		{Duration: &api.Duration{Amount: 1, Unit: "day"}},
+		{Duration: &api.Duration{Amount: 30, Unit: "minute"}, Checked: true},
+		{},
+	}
+	if got := ScheduledMinutes(tasks); got != 135 {
+		t.Errorf("ScheduledMinutes() = %d, want 135", got)
+	}
+}
@@ -0,0 +1,239 @@
+package utils
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence is a repeating due date read from an "every ..." rule.
+type Recurrence struct {
+	Unit     string         // "day", "week", "month" or "year"
+	Interval int            // Number of units between occurrences
+	Weekdays []time.Weekday // Days of weekly rules; empty repeats on the same day
+	Days     []int          // Days of monthly rules, -1 for the last day; empty repeats on the same day
+	Month    time.Month     // Month of yearly rules on a date; 0 repeats on the same date
+	// AfterCompletion is set for "every!" and "after" rules, which count
+	// from the day the task is completed rather than from its due date.
+	AfterCompletion bool
+}
+
+// ParseRecurrence reads the common forms of Todoist's recurring due dates:
+// "every day", "every 3 days", "every other week", "every weekday", "every
+// mon, wed and fri", "every month", "every 15th", "every last day", "every
+// year", "every jan 15", with "every!" or "after" for rules counted from
+// completion. A trailing time ("at 9am") or start and end ("starting ...",
+// "until ...") is ignored. It reports false for anything else.
+func ParseRecurrence(text string) (Recurrence, bool) {
+	text = strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	switch text {
+	case "daily":
+		return Recurrence{Unit: "day", Interval: 1}, true
+	case "weekly":
+		return Recurrence{Unit: "week", Interval: 1}, true
+	case "monthly":
+		return Recurrence{Unit: "month", Interval: 1}, true
+	case "yearly", "annually":
+		return Recurrence{Unit: "year", Interval: 1}, true
+	}
+
+	var r Recurrence
+	rule, ok := "", false
+	for _, prefix := range []string{"every! ", "after "} {
+		if rule, ok = strings.CutPrefix(text, prefix); ok {
+			r.AfterCompletion = true
+			break
+		}
+	}
+	if !ok {
+		if rule, ok = strings.CutPrefix(text, "every "); !ok {
+			return Recurrence{}, false
+		}
+	}
+	for _, sep := range []string{" at ", " starting ", " from ", " until ", " ending ", " for "} {
+		rule, _, _ = strings.Cut(rule, sep)
+	}
+
+	r.Interval = 1
+	if rest, ok := strings.CutPrefix(rule, "other "); ok {
+		r.Interval, rule = 2, rest
+	} else if count, rest, ok := strings.Cut(rule, " "); ok {
+		// "3 days", but not the day of "15 jan"
+		switch strings.TrimSuffix(rest, "s") {
+		case "day", "week", "month", "year":
+			if n, err := strconv.Atoi(count); err == nil && n > 0 {
+				r.Interval, rule = n, rest
+			}
+		}
+	}
+
+	switch strings.TrimSuffix(rule, "s") {
+	case "day", "morning", "afternoon", "evening", "night":
+		r.Unit = "day"
+		return r, true
+	case "week":
+		r.Unit = "week"
+		return r, true
+	case "month":
+		r.Unit = "month"
+		return r, true
+	case "year":
+		r.Unit = "year"
+		return r, true
+	case "weekday", "workday", "work day":
+		r.Unit = "week"
+		r.Weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+		return r, true
+	case "weekend":
+		r.Unit = "week"
+		r.Weekdays = []time.Weekday{time.Saturday, time.Sunday}
+		return r, true
+	case "last day":
+		r.Unit = "month"
+		r.Days = []int{-1}
+		return r, true
+	}
+
+	items := strings.FieldsFunc(strings.ReplaceAll(rule, " and ", ","), func(c rune) bool { return c == ',' })
+	if weekdays, ok := parseList(items, ParseWeekday); ok {
+		r.Unit = "week"
+		r.Weekdays = weekdays
+		return r, true
+	}
+	if days, ok := parseList(items, parseMonthDay); ok {
+		r.Unit = "month"
+		r.Days = days
+		return r, true
+	}
+	for _, layout := range []string{"jan 2", "january 2", "2 jan", "2 january"} {
+		if day, err := time.Parse(layout, stripOrdinal(rule)); err == nil {
+			r.Unit = "year"
+			r.Month = day.Month()
+			r.Days = []int{day.Day()}
+			return r, true
+		}
+	}
+	return Recurrence{}, false
+}
+
+// parseList reads comma separated items, all of which must parse.
+func parseList[T any](items []string, parse func(string) (T, bool)) ([]T, bool) {
+	if len(items) == 0 {
+		return nil, false
+	}
+	values := make([]T, 0, len(items))
+	for _, item := range items {
+		v, ok := parse(strings.TrimSpace(item))
+		if !ok {
+			return nil, false
+		}
+		values = append(values, v)
+	}
+	return values, true
+}
+
+// parseMonthDay reads a day of the month such as "15th", "1st" or "last
+// day" (-1).
+func parseMonthDay(text string) (int, bool) {
+	if text == "last day" || text == "last" {
+		return -1, true
+	}
+	if !strings.HasSuffix(text, "st") && !strings.HasSuffix(text, "nd") &&
+		!strings.HasSuffix(text, "rd") && !strings.HasSuffix(text, "th") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(text[:len(text)-2])
+	if err != nil || n < 1 || n > 31 {
+		return 0, false
+	}
+	return n, true
+}
+
+// stripOrdinal turns "jan 15th" into "jan 15".
+func stripOrdinal(text string) string {
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if rest, ok := strings.CutSuffix(text, suffix); ok && rest != "" && rest[len(rest)-1] >= '0' && rest[len(rest)-1] <= '9' {
+			return rest
+		}
+	}
+	return text
+}
+
+// Next returns the first occurrence after the day of from, at midnight in
+// its location. from is normally the current occurrence, which anchors
+// rules that repeat on the same day.
+func (r Recurrence) Next(from time.Time) time.Time {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	interval := max(r.Interval, 1)
+
+	switch r.Unit {
+	case "week":
+		if len(r.Weekdays) == 0 {
+			return from.AddDate(0, 0, 7*interval)
+		}
+		next := from.AddDate(0, 0, 1)
+		for !slices.Contains(r.Weekdays, next.Weekday()) {
+			next = next.AddDate(0, 0, 1)
+		}
+		// Skip the weeks in between when the next day falls in a new week
+		if interval > 1 && !weekStart(next).Equal(weekStart(from)) {
+			next = next.AddDate(0, 0, 7*(interval-1))
+		}
+		return next
+	case "month":
+		if len(r.Days) == 0 {
+			return addMonths(from, interval, from.Day())
+		}
+		for months := 0; ; months += interval {
+			month := addMonths(from, months, 1)
+			var candidates []time.Time
+			for _, day := range r.Days {
+				candidates = append(candidates, addMonths(month, 0, day))
+			}
+			slices.SortFunc(candidates, func(a, b time.Time) int { return a.Compare(b) })
+			for _, c := range candidates {
+				if c.After(from) {
+					return c
+				}
+			}
+		}
+	case "year":
+		if r.Month == 0 || len(r.Days) == 0 {
+			return addMonths(from, 12*interval, from.Day())
+		}
+		for years := 0; ; years += interval {
+			next := addMonths(time.Date(from.Year()+years, r.Month, 1, 0, 0, 0, 0, from.Location()), 0, r.Days[0])
+			if next.After(from) {
+				return next
+			}
+		}
+	}
+	return from.AddDate(0, 0, interval)
+}
+
+// Occurrences returns the next n occurrences after the day of from.
+func (r Recurrence) Occurrences(from time.Time, n int) []time.Time {
+	dates := make([]time.Time, 0, n)
+	for range n {
+		from = r.Next(from)
+		dates = append(dates, from)
+	}
+	return dates
+}
+
+// addMonths returns the given day of the month months after that of t,
+// clamped to the last day of that month; -1 is the last day.
+func addMonths(t time.Time, months, day int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	if day < 0 || day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// weekStart returns the Monday of the week of t.
+func weekStart(t time.Time) time.Time {
+	return t.AddDate(0, 0, -(int(t.Weekday())+6)%7)
+}
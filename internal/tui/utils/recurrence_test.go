@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	// A Tuesday
+	from := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{"every day", "2026-03-11 2026-03-12 2026-03-13"},
+		{"Every 3 days at 9am", "2026-03-13 2026-03-16 2026-03-19"},
+		{"every week", "2026-03-17 2026-03-24 2026-03-31"},
+		{"every other week", "2026-03-24 2026-04-07 2026-04-21"},
+		{"every weekday", "2026-03-11 2026-03-12 2026-03-13"},
+		{"every mon, wed and fri", "2026-03-11 2026-03-13 2026-03-16"},
+		{"every other monday", "2026-03-23 2026-04-06 2026-04-20"},
+		{"every month", "2026-04-10 2026-05-10 2026-06-10"},
+		{"every 1st and 15th", "2026-03-15 2026-04-01 2026-04-15"},
+		{"every last day", "2026-03-31 2026-04-30 2026-05-31"},
+		{"every jan 15th", "2027-01-15 2028-01-15 2029-01-15"},
+		{"every 2 years", "2028-03-10 2030-03-10 2032-03-10"},
+		{"every! 2 days", "2026-03-12 2026-03-14 2026-03-16"},
+	}
+
+	for _, tt := range tests {
+		r, ok := ParseRecurrence(tt.rule)
+		if !ok {
+			t.Errorf("ParseRecurrence(%q) failed", tt.rule)
+			continue
+		}
+		var got []string
+		for _, d := range r.Occurrences(from, 3) {
+			got = append(got, d.Format(DateLayout))
+		}
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("%q: got %v, want %s", tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule string
+		want Recurrence
+	}{
+		{"daily", Recurrence{Unit: "day", Interval: 1}},
+		{"every  Other week until jun 1", Recurrence{Unit: "week", Interval: 2}},
+		{"every weekend", Recurrence{Unit: "week", Interval: 1, Weekdays: []time.Weekday{time.Saturday, time.Sunday}}},
+		{"every 2nd, 16th and last", Recurrence{Unit: "month", Interval: 1, Days: []int{2, 16, -1}}},
+		{"every 15 january", Recurrence{Unit: "year", Interval: 1, Month: time.January, Days: []int{15}}},
+		{"after 3 weeks", Recurrence{Unit: "week", Interval: 3, AfterCompletion: true}},
+	}
+	for _, tt := range tests {
+		got, ok := ParseRecurrence(tt.rule)
+		if !ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseRecurrence(%q) = %+v, %v, want %+v", tt.rule, got, ok, tt.want)
+		}
+	}
+}
+
+func TestParseRecurrence_Unsupported(t *testing.T) {
+	for _, rule := range []string{"", "tomorrow", "every", "every 3rd friday", "every hour", "every 15 fortnights"} {
+		if _, ok := ParseRecurrence(rule); ok {
+			t.Errorf("ParseRecurrence(%q) should fail", rule)
+		}
+	}
+	if r, _ := ParseRecurrence("after 3 days"); !r.AfterCompletion {
+		t.Error(`"after 3 days" should count from completion`)
+	}
+}
@@ -2,6 +2,7 @@
 package utils
 
 import (
+	"errors"
 	"sort"
 	"unicode/utf8"
 
@@ -48,3 +49,38 @@ func ExtractLabelsFromTasks(tasks []api.Task) []api.Label {
 
 	return labels
 }
+
+// ErrorMessage returns a short, readable description of err for the status
+// bar. Todoist errors are shown by their decoded message rather than the raw
+// response.
+func ErrorMessage(err error) string {
+	var syncErr *api.SyncError
+	if errors.As(err, &syncErr) {
+		if syncErr.Details.IsPremiumRequired() {
+			return "Premium required"
+		}
+		return syncErr.Details.Message
+	}
+
+	apiErr, ok := api.IsAPIError(err)
+	if !ok {
+		return err.Error()
+	}
+	switch {
+	case apiErr.Details.IsPremiumRequired():
+		return "Premium required"
+	case apiErr.IsUnauthorized():
+		return "Invalid or expired API token"
+	case apiErr.IsRateLimited():
+		return "Too many requests, try again shortly"
+	case apiErr.IsServerError():
+		return "Todoist is unavailable, try again later"
+	case apiErr.Details.Message != "":
+		return apiErr.Details.Message
+	case apiErr.IsNotFound():
+		return "Not found"
+	case apiErr.IsForbidden():
+		return "Access denied"
+	}
+	return err.Error()
+}
@@ -76,8 +76,9 @@ func (v *CalendarView) loadCalendarDayTasks() tea.Cmd {
 }
 
 func (v *CalendarView) loadAllTasks() tea.Cmd {
+	ctx := v.State.ViewContext()
 	return func() tea.Msg {
-		allTasks, err := v.Client.GetTasks(api.TaskFilter{})
+		allTasks, err := v.Client.GetTasks(ctx, api.TaskFilter{})
 		if err != nil {
 			return errMsg{err}
 		}
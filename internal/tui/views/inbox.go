@@ -68,12 +68,13 @@ func (v *InboxView) loadInboxTasks() tea.Cmd {
 	}
 
 	if inboxID != "" {
+		ctx := v.State.ViewContext()
 		return func() tea.Msg {
-			tasks, err := v.Client.GetTasks(api.TaskFilter{ProjectID: inboxID})
+			tasks, err := v.Client.GetTasks(ctx, api.TaskFilter{ProjectID: inboxID})
 			if err != nil {
 				return errMsg{err}
 			}
-			sections, err := v.Client.GetSections(inboxID)
+			sections, err := v.Client.GetSections(ctx, inboxID)
 			if err != nil {
 				return errMsg{err}
 			}
@@ -81,8 +82,9 @@ func (v *InboxView) loadInboxTasks() tea.Cmd {
 		}
 	}
 
+	ctx := v.State.ViewContext()
 	return func() tea.Msg {
-		tasks, err := v.Client.GetTasksByFilter("inbox")
+		tasks, err := v.Client.GetTasksByFilter(ctx, "inbox")
 		if err != nil {
 			return errMsg{err}
 		}
@@ -95,8 +97,9 @@ func (v *InboxView) loadTaskComments() tea.Cmd {
 		return nil
 	}
 	taskID := v.State.SelectedTask.ID
+	ctx := v.State.ViewContext()
 	return func() tea.Msg {
-		comments, err := v.Client.GetComments(taskID, "")
+		comments, err := v.Client.GetComments(ctx, taskID, "")
 		if err != nil {
 			return errMsg{err}
 		}
@@ -131,8 +131,9 @@ func (v *LabelsView) loadTaskComments() tea.Cmd {
 		return nil
 	}
 	taskID := v.State.SelectedTask.ID
+	ctx := v.State.ViewContext()
 	return func() tea.Msg {
-		comments, err := v.Client.GetComments(taskID, "")
+		comments, err := v.Client.GetComments(ctx, taskID, "")
 		if err != nil {
 			return errMsg{err}
 		}
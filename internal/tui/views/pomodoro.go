@@ -9,6 +9,9 @@ import (
 	"github.com/hy4ri/todoist-tui/internal/tui/state"
 )
 
+// PomodoroTimerID identifies the ticks of the Pomodoro timer.
+const PomodoroTimerID = 99
+
 // PomodoroView handles the Pomodoro timer tab.
 type PomodoroView struct {
 	*BaseView
@@ -19,7 +22,7 @@ type PomodoroView struct {
 func NewPomodoroView(s *state.State) *PomodoroView {
 	return &PomodoroView{
 		BaseView: NewBaseView(s),
-		timer:    components.NewTimerModel(99), // 99 as unique ID for Pomodoro timer
+		timer:    components.NewTimerModel(PomodoroTimerID),
 	}
 }
 
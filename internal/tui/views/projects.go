@@ -126,12 +126,13 @@ func (v *ProjectsView) selectTask() tea.Cmd {
 }
 
 func (v *ProjectsView) loadProjectTasks(projectID string) tea.Cmd {
+	ctx := v.State.ViewContext()
 	return func() tea.Msg {
-		tasks, err := v.Client.GetTasks(api.TaskFilter{ProjectID: projectID})
+		tasks, err := v.Client.GetTasks(ctx, api.TaskFilter{ProjectID: projectID})
 		if err != nil {
 			return errMsg{err}
 		}
-		sections, err := v.Client.GetSections(projectID)
+		sections, err := v.Client.GetSections(ctx, projectID)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -144,8 +145,9 @@ func (v *ProjectsView) loadTaskComments() tea.Cmd {
 		return nil
 	}
 	taskID := v.State.SelectedTask.ID
+	ctx := v.State.ViewContext()
 	return func() tea.Msg {
-		comments, err := v.Client.GetComments(taskID, "")
+		comments, err := v.Client.GetComments(ctx, taskID, "")
 		if err != nil {
 			return errMsg{err}
 		}
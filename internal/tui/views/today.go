@@ -102,8 +102,9 @@ func (v *TodayView) loadTaskComments() tea.Cmd {
 		return nil
 	}
 	taskID := v.State.SelectedTask.ID
+	ctx := v.State.ViewContext()
 	return func() tea.Msg {
-		comments, err := v.Client.GetComments(taskID, "")
+		comments, err := v.Client.GetComments(ctx, taskID, "")
 		if err != nil {
 			return errMsg{err}
 		}